@@ -0,0 +1,36 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+const (
+	// ConditionTypeReady indicates that the PostgreSQL object exists and has been fully reconciled at least once.
+	// It only becomes false again if the object is not available anymore.
+	ConditionTypeReady = "Ready"
+
+	// ConditionTypeSynced indicates whether the last reconciliation applied the whole specification.
+	ConditionTypeSynced = "Synced"
+
+	// ConditionTypeDegraded indicates that the PostgreSQL object is available but the last reconciliation failed.
+	ConditionTypeDegraded = "Degraded"
+
+	// ConditionTypeDeletionBlocked indicates that the resource is being deleted but the PostgreSQL object cannot be dropped.
+	ConditionTypeDeletionBlocked = "DeletionBlocked"
+
+	// ConditionTypeConflict indicates that another resource already manages the same PostgreSQL object,
+	// so the resource isn't reconciled and its deletion doesn't drop the object.
+	ConditionTypeConflict = "Conflict"
+)
@@ -0,0 +1,27 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// Management policies, deciding how a resource handles a PostgreSQL object which already exists
+const (
+	// ManagementPolicyAdopt takes over an existing object and reconciles it like an object created by the resource
+	ManagementPolicyAdopt = "Adopt"
+	// ManagementPolicyCreateOnly only manages an object created by the resource, and rejects an existing one
+	ManagementPolicyCreateOnly = "CreateOnly"
+	// ManagementPolicyObserve only reports the state of an existing object, without creating, altering or dropping it
+	ManagementPolicyObserve = "Observe"
+)
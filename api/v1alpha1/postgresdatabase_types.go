@@ -27,8 +27,63 @@ type PostgresDatabasePrivilegesSpec struct {
 	Temporary bool `json:"temporary,omitempty"`
 }
 
+// Extension policies
+const (
+	// ExtensionPolicyAdditive drops only the extensions created by the operator and removed from the resource
+	ExtensionPolicyAdditive = "Additive"
+	// ExtensionPolicyExclusive drops every extension not declared in the resource, except the built-in ones
+	ExtensionPolicyExclusive = "Exclusive"
+)
+
+// PostgresDatabaseExtension defines an extension to install on the database
+type PostgresDatabaseExtension struct {
+	// Name is the extension's name.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Version is the extension's version. If omitted, the default version is installed on creation and the extension is never updated.
+	// Changing it updates the extension with ALTER EXTENSION ... UPDATE TO, which requires an update path from the installed version.
+	Version string `json:"version,omitempty"`
+
+	// Schema is the schema containing the extension's objects. If omitted, the first schema of the search_path is used on creation.
+	// Changing it moves the objects of relocatable extensions.
+	Schema string `json:"schema,omitempty"`
+
+	// Cascade installs the extensions required by the extension on creation. Default is false.
+	Cascade bool `json:"cascade,omitempty"`
+}
+
+// PostgresDatabaseExtensionStatus defines the observed state of an extension installed on the database
+type PostgresDatabaseExtensionStatus struct {
+	// Name is the extension's name.
+	Name string `json:"name"`
+
+	// Version is the installed version of the extension.
+	Version string `json:"version,omitempty"`
+
+	// Schema is the schema containing the extension's objects.
+	Schema string `json:"schema,omitempty"`
+
+	// AvailableUpgrades are the versions available on the server the extension can be updated to,
+	// ordered by the number of update scripts to run.
+	AvailableUpgrades []string `json:"availableUpgrades,omitempty"`
+}
+
 // PostgresDatabaseSpec defines the desired state of PostgresDatabase.
+// +kubebuilder:validation:XValidation:message="serverRef is immutable",rule="has(self.serverRef) == has(oldSelf.serverRef) && (!has(self.serverRef) || self.serverRef == oldSelf.serverRef)"
+// +kubebuilder:validation:XValidation:message="owner and ownerRef are mutually exclusive",rule="!has(self.owner) || !has(self.ownerRef)"
+// +kubebuilder:validation:XValidation:message="template is immutable",rule="has(self.template) == has(oldSelf.template) && (!has(self.template) || self.template == oldSelf.template)"
+// +kubebuilder:validation:XValidation:message="encoding is immutable",rule="has(self.encoding) == has(oldSelf.encoding) && (!has(self.encoding) || self.encoding == oldSelf.encoding)"
+// +kubebuilder:validation:XValidation:message="lcCollate is immutable",rule="has(self.lcCollate) == has(oldSelf.lcCollate) && (!has(self.lcCollate) || self.lcCollate == oldSelf.lcCollate)"
+// +kubebuilder:validation:XValidation:message="lcCtype is immutable",rule="has(self.lcCtype) == has(oldSelf.lcCtype) && (!has(self.lcCtype) || self.lcCtype == oldSelf.lcCtype)"
+// +kubebuilder:validation:XValidation:message="localeProvider is immutable",rule="has(self.localeProvider) == has(oldSelf.localeProvider) && (!has(self.localeProvider) || self.localeProvider == oldSelf.localeProvider)"
+// +kubebuilder:validation:XValidation:message="icuLocale is immutable",rule="has(self.icuLocale) == has(oldSelf.icuLocale) && (!has(self.icuLocale) || self.icuLocale == oldSelf.icuLocale)"
+// +kubebuilder:validation:XValidation:message="icuLocale requires the icu localeProvider",rule="!has(self.icuLocale) || (has(self.localeProvider) && self.localeProvider == 'icu')"
+// +kubebuilder:validation:XValidation:message="extensions can't be managed when allowConnections is false",rule="!has(self.allowConnections) || self.allowConnections || ((!has(self.extensions) || size(self.extensions) == 0) && (!has(self.extensionsWithOptions) || size(self.extensionsWithOptions) == 0))"
 type PostgresDatabaseSpec struct {
+	// ServerRef is the name of the PostgresServer on which the database is managed. If omitted, the operator's default server is used.
+	ServerRef string `json:"serverRef,omitempty"`
 
 	// Name is the PostgreSQL database's name.
 	// +kubebuilder:validation:Required
@@ -38,9 +93,75 @@ type PostgresDatabaseSpec struct {
 	// Owner is the PostgreSQL database's owner. It must be a valid existing role.
 	Owner string `json:"owner,omitempty"`
 
+	// OwnerRef is the name of the PostgresRole, in the same namespace, owning the database.
+	// The database isn't reconciled until the PostgresRole is ready.
+	OwnerRef string `json:"ownerRef,omitempty"`
+
+	// Template is the database copied to create the database, e.g. "template0" or a template database with PostGIS installed.
+	// Only applies on creation.
+	Template string `json:"template,omitempty"`
+
+	// Encoding is the character set encoding of the database, e.g. "UTF8". Only applies on creation.
+	Encoding string `json:"encoding,omitempty"`
+
+	// LcCollate is the collation order (LC_COLLATE) of the database, e.g. "C". Only applies on creation.
+	LcCollate string `json:"lcCollate,omitempty"`
+
+	// LcCtype is the character classification (LC_CTYPE) of the database, e.g. "C". Only applies on creation.
+	LcCtype string `json:"lcCtype,omitempty"`
+
+	// LocaleProvider is the provider of the database's default collation. Only applies on creation.
+	// +kubebuilder:validation:Enum=libc;icu;builtin
+	LocaleProvider string `json:"localeProvider,omitempty"`
+
+	// IcuLocale is the ICU locale of the database, e.g. "en-US". It requires the icu LocaleProvider. Only applies on creation.
+	IcuLocale string `json:"icuLocale,omitempty"`
+
+	// Tablespace is the default tablespace of the database. If omitted, the server's default is used on creation.
+	// Changing it moves the database, which requires no other session to be connected to it.
+	Tablespace string `json:"tablespace,omitempty"`
+
+	// ConnectionLimit is the maximum number of concurrent connections to the database. -1 means no limit.
+	// +kubebuilder:validation:Minimum=-1
+	// +kubebuilder:default=-1
+	ConnectionLimit *int32 `json:"connectionLimit,omitempty"`
+
+	// AllowConnections determines if the database accepts connections. Default is true.
+	// +kubebuilder:default=true
+	AllowConnections *bool `json:"allowConnections,omitempty"`
+
+	// IsTemplate determines if the database can be cloned by any role with the CREATEDB option. Default is false.
+	IsTemplate bool `json:"isTemplate,omitempty"`
+
+	// Parameters holds the configuration parameters set on the sessions connected to the database, e.g. timezone or statement_timeout.
+	// Parameters set on the database but missing from the list are reset.
+	Parameters map[string]string `json:"parameters,omitempty"`
+
 	// Extensions is the list of database extensions to install on the database.
 	Extensions []string `json:"extensions,omitempty"`
 
+	// ExtensionsWithOptions is the list of database extensions to install on the database, with their version, schema and dependencies.
+	// An extension listed in both Extensions and ExtensionsWithOptions uses the options declared here.
+	// +listType=map
+	// +listMapKey=name
+	ExtensionsWithOptions []PostgresDatabaseExtension `json:"extensionsWithOptions,omitempty"`
+
+	// ExtensionPolicy determines which undeclared extensions are dropped from the database.
+	// With Additive, only the extensions created by the operator and then removed from the resource are dropped.
+	// With Exclusive, every extension not declared in the resource is dropped, except the ones built in PostgreSQL like plpgsql.
+	// +kubebuilder:validation:Enum=Additive;Exclusive
+	// +kubebuilder:default=Additive
+	ExtensionPolicy string `json:"extensionPolicy,omitempty"`
+
+	// ManagementPolicy determines how the resource handles a database which already exists.
+	// With Adopt, an existing database is taken over and reconciled like a database created by the resource.
+	// With CreateOnly, the resource fails if the database already exists and hasn't been created by it.
+	// With Observe, the database is never created, altered or dropped, and the resource only reports whether it exists.
+	// Default is CreateOnly, so that an existing database is only taken over when requested.
+	// +kubebuilder:validation:Enum=Adopt;CreateOnly;Observe
+	// +kubebuilder:default=CreateOnly
+	ManagementPolicy string `json:"managementPolicy,omitempty"`
+
 	// KeepOnDelete will determine if the deletion of the resource should drop the remote PostgreSQL database. Default is false.
 	KeepOnDelete bool `json:"keepOnDelete,omitempty"`
 
@@ -54,10 +175,46 @@ type PostgresDatabaseSpec struct {
 // PostgresDatabaseStatus defines the observed state of PostgresDatabase.
 type PostgresDatabaseStatus struct {
 	Succeeded bool `json:"succeeded"`
+
+	// ObservedGeneration is the last generation of the resource that has been reconciled.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Owner is the database's owner, resolved from OwnerRef if defined.
+	Owner string `json:"owner,omitempty"`
+
+	// Owned is true once the resource has created or adopted the database, or claims it after a resource owning it.
+	// A resource with the CreateOnly management policy only manages a database it owns.
+	Owned bool `json:"owned,omitempty"`
+
+	// Creating is true while the resource creates the database, until its ownership is recorded.
+	// A database found while it is set has been created by an interrupted reconciliation, and is owned by the resource.
+	Creating bool `json:"creating,omitempty"`
+
+	// Conditions represent the latest observations of the database's state.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// PlannedStatements are the statements the last reconciliation would have executed, if it hadn't been a dry run.
+	PlannedStatements []string `json:"plannedStatements,omitempty"`
+
+	// Extensions are the extensions installed on the database, with their available upgrades.
+	// +listType=map
+	// +listMapKey=name
+	Extensions []PostgresDatabaseExtensionStatus `json:"extensions,omitempty"`
+
+	// ManagedExtensions are the extensions created by the operator, dropped once removed from the resource.
+	// +listType=set
+	ManagedExtensions []string `json:"managedExtensions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Synced",type=string,JSONPath=`.status.conditions[?(@.type=="Synced")].status`
+// +kubebuilder:selectablefield:JSONPath=`.spec.name`
+// +kubebuilder:selectablefield:JSONPath=`.spec.ownerRef`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // PostgresDatabase is the Schema for the postgresdatabases API.
 type PostgresDatabase struct {
@@ -0,0 +1,125 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PostgresGrantSpec defines the desired privileges of a role on a PostgreSQL object
+// +kubebuilder:validation:XValidation:message="serverRef is immutable",rule="has(self.serverRef) == has(oldSelf.serverRef) && (!has(self.serverRef) || self.serverRef == oldSelf.serverRef)"
+// +kubebuilder:validation:XValidation:message="columns must be set if and only if objectType is COLUMN",rule="(self.objectType == 'COLUMN') == (has(self.columns) && size(self.columns) > 0)"
+// +kubebuilder:validation:XValidation:message="identity must be an OID for the LARGE OBJECT objectType",rule="self.objectType != 'LARGE OBJECT' || self.identity.matches('^[0-9]+$')"
+type PostgresGrantSpec struct {
+	// ServerRef is the name of the PostgresServer on which the privileges are managed. If omitted, the operator's default server is used.
+	ServerRef string `json:"serverRef,omitempty"`
+
+	// Database is the PostgreSQL database's name in which the object exists
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:message="database is immutable",rule="self == oldSelf"
+	Database string `json:"database"`
+
+	// ObjectType is the type of the object on which the privileges are granted
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=TABLE;COLUMN;VIEW;SEQUENCE;FUNCTION;FOREIGN DATA WRAPPER;LARGE OBJECT;TABLESPACE
+	// +kubebuilder:validation:XValidation:message="objectType is immutable",rule="self == oldSelf"
+	ObjectType string `json:"objectType"`
+
+	// Identity identifies the object on which the privileges are granted:
+	// a qualified name for tables, columns' table, views and sequences (e.g. "public.mytable"),
+	// a qualified name with argument types for functions (e.g. "public.myfunction(integer, text)"),
+	// a name for foreign data wrappers and tablespaces, and an OID for large objects.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:message="identity is immutable",rule="self == oldSelf"
+	Identity string `json:"identity"`
+
+	// Columns is the list of the table's columns on which the privileges are granted. Only used with the COLUMN object type.
+	Columns []string `json:"columns,omitempty"`
+
+	// Grantee is the role to which the privileges are granted
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:message="grantee is immutable",rule="self == oldSelf"
+	Grantee string `json:"grantee"`
+
+	// Privileges is the list of privileges granted to the grantee
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +listType=set
+	Privileges []PostgresGrantPrivilege `json:"privileges"`
+
+	// WithGrantOption allows the grantee to grant the privileges to other roles. Default is false.
+	WithGrantOption bool `json:"withGrantOption,omitempty"`
+}
+
+// PostgresGrantPrivilege is a privilege that can be granted on a PostgreSQL object
+// +kubebuilder:validation:Enum=SELECT;INSERT;UPDATE;DELETE;TRUNCATE;REFERENCES;TRIGGER;USAGE;EXECUTE;CREATE
+type PostgresGrantPrivilege string
+
+// PostgresGrantStatus defines the observed state of PostgresGrant.
+type PostgresGrantStatus struct {
+	Succeeded bool `json:"succeeded"`
+
+	// ObservedGeneration is the last generation of the resource that has been reconciled.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest observations of the grant's state.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// PlannedStatements are the statements the last reconciliation would have executed, if it hadn't been a dry run.
+	PlannedStatements []string `json:"plannedStatements,omitempty"`
+
+	// Drift lists the differences found between the desired and the existing privileges during the last reconciliation.
+	// They have been corrected by the operator.
+	Drift []string `json:"drift,omitempty"`
+
+	// LastDriftTime is the last time a drift has been detected and corrected.
+	LastDriftTime *metav1.Time `json:"lastDriftTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Grantee",type=string,JSONPath=`.spec.grantee`
+// +kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.objectType`
+// +kubebuilder:printcolumn:name="Identity",type=string,JSONPath=`.spec.identity`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Synced",type=string,JSONPath=`.status.conditions[?(@.type=="Synced")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:selectablefield:JSONPath=`.spec.grantee`
+
+// PostgresGrant is the Schema for the postgresgrants API.
+type PostgresGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PostgresGrantSpec   `json:"spec,omitempty"`
+	Status PostgresGrantStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PostgresGrantList contains a list of PostgresGrant.
+type PostgresGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PostgresGrant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PostgresGrant{}, &PostgresGrantList{})
+}
@@ -24,14 +24,85 @@ import (
 type PostgresSchemaPrivilegesSpec struct {
 	Create bool `json:"create,omitempty"`
 	Usage  bool `json:"usage,omitempty"`
+
+	// Tables defines the privileges granted on all the existing tables of the schema. If omitted, the tables' privileges are not managed.
+	Tables *PostgresTablePrivilegesSpec `json:"tables,omitempty"`
+
+	// Sequences defines the privileges granted on all the existing sequences of the schema. If omitted, the sequences' privileges are not managed.
+	Sequences *PostgresSequencePrivilegesSpec `json:"sequences,omitempty"`
+
+	// Functions defines the privileges granted on all the existing functions of the schema. If omitted, the functions' privileges are not managed.
+	Functions *PostgresFunctionPrivilegesSpec `json:"functions,omitempty"`
+}
+
+// PostgresTablePrivilegesSpec defines the desired privileges on tables
+type PostgresTablePrivilegesSpec struct {
+	Select     bool `json:"select,omitempty"`
+	Insert     bool `json:"insert,omitempty"`
+	Update     bool `json:"update,omitempty"`
+	Delete     bool `json:"delete,omitempty"`
+	Truncate   bool `json:"truncate,omitempty"`
+	References bool `json:"references,omitempty"`
+	Trigger    bool `json:"trigger,omitempty"`
+}
+
+// PostgresSequencePrivilegesSpec defines the desired privileges on sequences
+type PostgresSequencePrivilegesSpec struct {
+	Usage  bool `json:"usage,omitempty"`
+	Select bool `json:"select,omitempty"`
+	Update bool `json:"update,omitempty"`
+}
+
+// PostgresFunctionPrivilegesSpec defines the desired privileges on functions
+type PostgresFunctionPrivilegesSpec struct {
+	Execute bool `json:"execute,omitempty"`
+}
+
+// PostgresTypePrivilegesSpec defines the desired privileges on types
+type PostgresTypePrivilegesSpec struct {
+	Usage bool `json:"usage,omitempty"`
+}
+
+// PostgresSchemaDefaultPrivilegesSpec defines the privileges granted by default to a role on the objects created by another role in the schema
+type PostgresSchemaDefaultPrivilegesSpec struct {
+	// Grantor is the role creating the objects on which the privileges are granted
+	// +kubebuilder:validation:Required
+	Grantor string `json:"grantor"`
+
+	// Grantee is the role to which the privileges are granted
+	// +kubebuilder:validation:Required
+	Grantee string `json:"grantee"`
+
+	// Tables defines the privileges granted on the tables created by the grantor
+	Tables PostgresTablePrivilegesSpec `json:"tables,omitempty"`
+
+	// Sequences defines the privileges granted on the sequences created by the grantor
+	Sequences PostgresSequencePrivilegesSpec `json:"sequences,omitempty"`
+
+	// Functions defines the privileges granted on the functions created by the grantor
+	Functions PostgresFunctionPrivilegesSpec `json:"functions,omitempty"`
+
+	// Types defines the privileges granted on the types created by the grantor
+	Types PostgresTypePrivilegesSpec `json:"types,omitempty"`
 }
 
 // PostgresSchemaSpec defines the desired state of a PostgreSQL schema
+// +kubebuilder:validation:XValidation:message="serverRef is immutable",rule="has(self.serverRef) == has(oldSelf.serverRef) && (!has(self.serverRef) || self.serverRef == oldSelf.serverRef)"
+// +kubebuilder:validation:XValidation:message="database or databaseRef is required",rule="has(self.database) || has(self.databaseRef)"
+// +kubebuilder:validation:XValidation:message="owner and ownerRef are mutually exclusive",rule="!has(self.owner) || !has(self.ownerRef)"
+// +kubebuilder:validation:XValidation:message="database is immutable",rule="has(self.database) == has(oldSelf.database) && (!has(self.database) || self.database == oldSelf.database)"
+// +kubebuilder:validation:XValidation:message="databaseRef is immutable",rule="has(self.databaseRef) == has(oldSelf.databaseRef) && (!has(self.databaseRef) || self.databaseRef == oldSelf.databaseRef)"
 type PostgresSchemaSpec struct {
-	// Database is the PostgreSQL database's name in which the schema exists
-	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:XValidation:message="database is immutable",rule="self == oldSelf"
-	Database string `json:"database"`
+	// ServerRef is the name of the PostgresServer on which the schema is managed. If omitted, the operator's default server is used.
+	ServerRef string `json:"serverRef,omitempty"`
+
+	// Database is the PostgreSQL database's name in which the schema exists.
+	// If omitted, the database of the PostgresDatabase referenced by DatabaseRef is used.
+	Database string `json:"database,omitempty"`
+
+	// DatabaseRef is the name of the PostgresDatabase, in the same namespace, in which the schema exists.
+	// The schema isn't reconciled until the PostgresDatabase is ready.
+	DatabaseRef string `json:"databaseRef,omitempty"`
 
 	// Name is the PostgreSQL schema's name
 	// +kubebuilder:validation:Required
@@ -41,20 +112,53 @@ type PostgresSchemaSpec struct {
 	// Owner is the PostgreSQL schema's owner. It must be a valid existing role.
 	Owner string `json:"owner,omitempty"`
 
+	// OwnerRef is the name of the PostgresRole, in the same namespace, owning the schema.
+	// The schema isn't reconciled until the PostgresRole is ready.
+	OwnerRef string `json:"ownerRef,omitempty"`
+
 	// KeepOnDelete will determine if the deletion of the resource should drop the remote PostgreSQL schema. Default is false.
 	KeepOnDelete bool `json:"keepOnDelete,omitempty"`
 
 	// PrivilegesByRole will grant privileges to roles on this schema
 	PrivilegesByRole map[string]PostgresSchemaPrivilegesSpec `json:"privilegesByRole,omitempty"`
+
+	// DefaultPrivileges will grant privileges to roles on the objects created later in this schema
+	// +listType=map
+	// +listMapKey=grantor
+	// +listMapKey=grantee
+	DefaultPrivileges []PostgresSchemaDefaultPrivilegesSpec `json:"defaultPrivileges,omitempty"`
 }
 
 // PostgresSchemaStatus defines the observed state of PostgresSchema.
 type PostgresSchemaStatus struct {
 	Succeeded bool `json:"succeeded"`
+
+	// ObservedGeneration is the last generation of the resource that has been reconciled.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Database is the database in which the schema exists, resolved from DatabaseRef if defined.
+	Database string `json:"database,omitempty"`
+
+	// Owner is the schema's owner, resolved from OwnerRef if defined.
+	Owner string `json:"owner,omitempty"`
+
+	// Conditions represent the latest observations of the schema's state.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// PlannedStatements are the statements the last reconciliation would have executed, if it hadn't been a dry run.
+	PlannedStatements []string `json:"plannedStatements,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Synced",type=string,JSONPath=`.status.conditions[?(@.type=="Synced")].status`
+// +kubebuilder:selectablefield:JSONPath=`.spec.name`
+// +kubebuilder:selectablefield:JSONPath=`.spec.databaseRef`
+// +kubebuilder:selectablefield:JSONPath=`.spec.ownerRef`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // PostgresSchema is the Schema for the postgresschemas API.
 type PostgresSchema struct {
@@ -0,0 +1,121 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PostgresServerCredentialsFromSecret references the Secret holding the credentials used by the operator to connect to the server
+type PostgresServerCredentialsFromSecret struct {
+	// Namespace is the Secret's namespace, which must be the operator's namespace. Default is the operator's namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// UsernameKey is the key of the Secret containing the username. Default is "username".
+	// +kubebuilder:default=username
+	UsernameKey string `json:"usernameKey,omitempty"`
+
+	// PasswordKey is the key of the Secret containing the password. Default is "password".
+	// +kubebuilder:default=password
+	PasswordKey string `json:"passwordKey,omitempty"`
+}
+
+// PostgresServerCAFromSecret references the Secret holding the certificate authority used to verify the server.
+// The Secret is read from the namespace of the credentials Secret.
+type PostgresServerCAFromSecret struct {
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Key is the key of the Secret containing the PEM-encoded certificates. Default is "ca.crt".
+	// +kubebuilder:default=ca.crt
+	Key string `json:"key,omitempty"`
+}
+
+// PostgresServerSpec defines the desired state of PostgresServer.
+type PostgresServerSpec struct {
+	// Host is the PostgreSQL server's hostname or IP address.
+	// +kubebuilder:validation:Required
+	Host string `json:"host"`
+
+	// Port is the PostgreSQL server's port. Default is 5432.
+	// +kubebuilder:default=5432
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port,omitempty"`
+
+	// Database is the database the operator connects to by default. Default is "postgres".
+	// +kubebuilder:default=postgres
+	Database string `json:"database,omitempty"`
+
+	// SSLMode is the SSL mode used to connect to the server. If omitted, the libpq default is used.
+	// +kubebuilder:validation:Enum=disable;allow;prefer;require;verify-ca;verify-full
+	SSLMode string `json:"sslMode,omitempty"`
+
+	// CAFromSecret references the Secret containing the certificate authority used to verify the server
+	// with the SSL modes verify-ca and verify-full. If omitted, the system's certificate authorities are used.
+	// +optional
+	CAFromSecret *PostgresServerCAFromSecret `json:"caFromSecret,omitempty"`
+
+	// CredentialsFromSecret references the Secret containing the operator's credentials for this server.
+	// +kubebuilder:validation:Required
+	CredentialsFromSecret PostgresServerCredentialsFromSecret `json:"credentialsFromSecret"`
+}
+
+// PostgresServerStatus defines the observed state of PostgresServer.
+type PostgresServerStatus struct {
+	Succeeded bool `json:"succeeded"`
+
+	// ObservedGeneration is the last generation of the resource that has been reconciled.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest observations of the server's state.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Synced",type=string,JSONPath=`.status.conditions[?(@.type=="Synced")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:resource:scope=Cluster
+
+// PostgresServer is the Schema for the postgresservers API.
+type PostgresServer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PostgresServerSpec   `json:"spec,omitempty"`
+	Status PostgresServerStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PostgresServerList contains a list of PostgresServer.
+type PostgresServerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PostgresServer `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PostgresServer{}, &PostgresServerList{})
+}
@@ -21,6 +21,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -30,7 +31,7 @@ func (in *PostgresDatabase) DeepCopyInto(out *PostgresDatabase) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresDatabase.
@@ -51,6 +52,41 @@ func (in *PostgresDatabase) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresDatabaseExtension) DeepCopyInto(out *PostgresDatabaseExtension) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresDatabaseExtension.
+func (in *PostgresDatabaseExtension) DeepCopy() *PostgresDatabaseExtension {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresDatabaseExtension)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresDatabaseExtensionStatus) DeepCopyInto(out *PostgresDatabaseExtensionStatus) {
+	*out = *in
+	if in.AvailableUpgrades != nil {
+		in, out := &in.AvailableUpgrades, &out.AvailableUpgrades
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresDatabaseExtensionStatus.
+func (in *PostgresDatabaseExtensionStatus) DeepCopy() *PostgresDatabaseExtensionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresDatabaseExtensionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgresDatabaseList) DeepCopyInto(out *PostgresDatabaseList) {
 	*out = *in
@@ -101,11 +137,33 @@ func (in *PostgresDatabasePrivilegesSpec) DeepCopy() *PostgresDatabasePrivileges
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgresDatabaseSpec) DeepCopyInto(out *PostgresDatabaseSpec) {
 	*out = *in
+	if in.ConnectionLimit != nil {
+		in, out := &in.ConnectionLimit, &out.ConnectionLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AllowConnections != nil {
+		in, out := &in.AllowConnections, &out.AllowConnections
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.Extensions != nil {
 		in, out := &in.Extensions, &out.Extensions
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ExtensionsWithOptions != nil {
+		in, out := &in.ExtensionsWithOptions, &out.ExtensionsWithOptions
+		*out = make([]PostgresDatabaseExtension, len(*in))
+		copy(*out, *in)
+	}
 	if in.PrivilegesByRole != nil {
 		in, out := &in.PrivilegesByRole, &out.PrivilegesByRole
 		*out = make(map[string]PostgresDatabasePrivilegesSpec, len(*in))
@@ -128,6 +186,30 @@ func (in *PostgresDatabaseSpec) DeepCopy() *PostgresDatabaseSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgresDatabaseStatus) DeepCopyInto(out *PostgresDatabaseStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PlannedStatements != nil {
+		in, out := &in.PlannedStatements, &out.PlannedStatements
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = make([]PostgresDatabaseExtensionStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ManagedExtensions != nil {
+		in, out := &in.ManagedExtensions, &out.ManagedExtensions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresDatabaseStatus.
@@ -140,13 +222,148 @@ func (in *PostgresDatabaseStatus) DeepCopy() *PostgresDatabaseStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresFunctionPrivilegesSpec) DeepCopyInto(out *PostgresFunctionPrivilegesSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresFunctionPrivilegesSpec.
+func (in *PostgresFunctionPrivilegesSpec) DeepCopy() *PostgresFunctionPrivilegesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresFunctionPrivilegesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresGrant) DeepCopyInto(out *PostgresGrant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresGrant.
+func (in *PostgresGrant) DeepCopy() *PostgresGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PostgresGrant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresGrantList) DeepCopyInto(out *PostgresGrantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PostgresGrant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresGrantList.
+func (in *PostgresGrantList) DeepCopy() *PostgresGrantList {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresGrantList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PostgresGrantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresGrantSpec) DeepCopyInto(out *PostgresGrantSpec) {
+	*out = *in
+	if in.Columns != nil {
+		in, out := &in.Columns, &out.Columns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]PostgresGrantPrivilege, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresGrantSpec.
+func (in *PostgresGrantSpec) DeepCopy() *PostgresGrantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresGrantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresGrantStatus) DeepCopyInto(out *PostgresGrantStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PlannedStatements != nil {
+		in, out := &in.PlannedStatements, &out.PlannedStatements
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastDriftTime != nil {
+		in, out := &in.LastDriftTime, &out.LastDriftTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresGrantStatus.
+func (in *PostgresGrantStatus) DeepCopy() *PostgresGrantStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresGrantStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgresRole) DeepCopyInto(out *PostgresRole) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresRole.
@@ -199,6 +416,31 @@ func (in *PostgresRoleList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresRoleMembership) DeepCopyInto(out *PostgresRoleMembership) {
+	*out = *in
+	if in.Inherit != nil {
+		in, out := &in.Inherit, &out.Inherit
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Set != nil {
+		in, out := &in.Set, &out.Set
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresRoleMembership.
+func (in *PostgresRoleMembership) DeepCopy() *PostgresRoleMembership {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresRoleMembership)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgresRoleOnDeleteSpec) DeepCopyInto(out *PostgresRoleOnDeleteSpec) {
 	*out = *in
@@ -214,6 +456,21 @@ func (in *PostgresRoleOnDeleteSpec) DeepCopy() *PostgresRoleOnDeleteSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresRolePasswordEncryptionSpec) DeepCopyInto(out *PostgresRolePasswordEncryptionSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresRolePasswordEncryptionSpec.
+func (in *PostgresRolePasswordEncryptionSpec) DeepCopy() *PostgresRolePasswordEncryptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresRolePasswordEncryptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgresRolePasswordFromSecret) DeepCopyInto(out *PostgresRolePasswordFromSecret) {
 	*out = *in
@@ -229,9 +486,68 @@ func (in *PostgresRolePasswordFromSecret) DeepCopy() *PostgresRolePasswordFromSe
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresRolePasswordRotationSpec) DeepCopyInto(out *PostgresRolePasswordRotationSpec) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresRolePasswordRotationSpec.
+func (in *PostgresRolePasswordRotationSpec) DeepCopy() *PostgresRolePasswordRotationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresRolePasswordRotationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgresRoleSpec) DeepCopyInto(out *PostgresRoleSpec) {
 	*out = *in
+	if in.ConnectionLimit != nil {
+		in, out := &in.ConnectionLimit, &out.ConnectionLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ValidUntil != nil {
+		in, out := &in.ValidUntil, &out.ValidUntil
+		*out = (*in).DeepCopy()
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DatabaseConfig != nil {
+		in, out := &in.DatabaseConfig, &out.DatabaseConfig
+		*out = make(map[string]map[string]string, len(*in))
+		for key, val := range *in {
+			var outVal map[string]string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make(map[string]string, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
 	if in.PasswordFromSecret != nil {
 		in, out := &in.PasswordFromSecret, &out.PasswordFromSecret
 		*out = new(PostgresRolePasswordFromSecret)
@@ -244,11 +560,33 @@ func (in *PostgresRoleSpec) DeepCopyInto(out *PostgresRoleSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.SecretFormats != nil {
+		in, out := &in.SecretFormats, &out.SecretFormats
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PasswordEncryption != nil {
+		in, out := &in.PasswordEncryption, &out.PasswordEncryption
+		*out = new(PostgresRolePasswordEncryptionSpec)
+		**out = **in
+	}
+	if in.PasswordRotation != nil {
+		in, out := &in.PasswordRotation, &out.PasswordRotation
+		*out = new(PostgresRolePasswordRotationSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.MemberOfRoles != nil {
 		in, out := &in.MemberOfRoles, &out.MemberOfRoles
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Memberships != nil {
+		in, out := &in.Memberships, &out.Memberships
+		*out = make([]PostgresRoleMembership, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.OnDelete != nil {
 		in, out := &in.OnDelete, &out.OnDelete
 		*out = new(PostgresRoleOnDeleteSpec)
@@ -269,6 +607,22 @@ func (in *PostgresRoleSpec) DeepCopy() *PostgresRoleSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgresRoleStatus) DeepCopyInto(out *PostgresRoleStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PlannedStatements != nil {
+		in, out := &in.PlannedStatements, &out.PlannedStatements
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastRotationTime != nil {
+		in, out := &in.LastRotationTime, &out.LastRotationTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresRoleStatus.
@@ -287,7 +641,7 @@ func (in *PostgresSchema) DeepCopyInto(out *PostgresSchema) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresSchema.
@@ -308,6 +662,25 @@ func (in *PostgresSchema) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresSchemaDefaultPrivilegesSpec) DeepCopyInto(out *PostgresSchemaDefaultPrivilegesSpec) {
+	*out = *in
+	out.Tables = in.Tables
+	out.Sequences = in.Sequences
+	out.Functions = in.Functions
+	out.Types = in.Types
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresSchemaDefaultPrivilegesSpec.
+func (in *PostgresSchemaDefaultPrivilegesSpec) DeepCopy() *PostgresSchemaDefaultPrivilegesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresSchemaDefaultPrivilegesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgresSchemaList) DeepCopyInto(out *PostgresSchemaList) {
 	*out = *in
@@ -343,6 +716,21 @@ func (in *PostgresSchemaList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgresSchemaPrivilegesSpec) DeepCopyInto(out *PostgresSchemaPrivilegesSpec) {
 	*out = *in
+	if in.Tables != nil {
+		in, out := &in.Tables, &out.Tables
+		*out = new(PostgresTablePrivilegesSpec)
+		**out = **in
+	}
+	if in.Sequences != nil {
+		in, out := &in.Sequences, &out.Sequences
+		*out = new(PostgresSequencePrivilegesSpec)
+		**out = **in
+	}
+	if in.Functions != nil {
+		in, out := &in.Functions, &out.Functions
+		*out = new(PostgresFunctionPrivilegesSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresSchemaPrivilegesSpec.
@@ -362,9 +750,14 @@ func (in *PostgresSchemaSpec) DeepCopyInto(out *PostgresSchemaSpec) {
 		in, out := &in.PrivilegesByRole, &out.PrivilegesByRole
 		*out = make(map[string]PostgresSchemaPrivilegesSpec, len(*in))
 		for key, val := range *in {
-			(*out)[key] = val
+			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.DefaultPrivileges != nil {
+		in, out := &in.DefaultPrivileges, &out.DefaultPrivileges
+		*out = make([]PostgresSchemaDefaultPrivilegesSpec, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresSchemaSpec.
@@ -380,6 +773,18 @@ func (in *PostgresSchemaSpec) DeepCopy() *PostgresSchemaSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgresSchemaStatus) DeepCopyInto(out *PostgresSchemaStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PlannedStatements != nil {
+		in, out := &in.PlannedStatements, &out.PlannedStatements
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresSchemaStatus.
@@ -391,3 +796,180 @@ func (in *PostgresSchemaStatus) DeepCopy() *PostgresSchemaStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresSequencePrivilegesSpec) DeepCopyInto(out *PostgresSequencePrivilegesSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresSequencePrivilegesSpec.
+func (in *PostgresSequencePrivilegesSpec) DeepCopy() *PostgresSequencePrivilegesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresSequencePrivilegesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresServer) DeepCopyInto(out *PostgresServer) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresServer.
+func (in *PostgresServer) DeepCopy() *PostgresServer {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresServer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PostgresServer) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresServerCAFromSecret) DeepCopyInto(out *PostgresServerCAFromSecret) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresServerCAFromSecret.
+func (in *PostgresServerCAFromSecret) DeepCopy() *PostgresServerCAFromSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresServerCAFromSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresServerCredentialsFromSecret) DeepCopyInto(out *PostgresServerCredentialsFromSecret) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresServerCredentialsFromSecret.
+func (in *PostgresServerCredentialsFromSecret) DeepCopy() *PostgresServerCredentialsFromSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresServerCredentialsFromSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresServerList) DeepCopyInto(out *PostgresServerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PostgresServer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresServerList.
+func (in *PostgresServerList) DeepCopy() *PostgresServerList {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresServerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PostgresServerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresServerSpec) DeepCopyInto(out *PostgresServerSpec) {
+	*out = *in
+	if in.CAFromSecret != nil {
+		in, out := &in.CAFromSecret, &out.CAFromSecret
+		*out = new(PostgresServerCAFromSecret)
+		**out = **in
+	}
+	out.CredentialsFromSecret = in.CredentialsFromSecret
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresServerSpec.
+func (in *PostgresServerSpec) DeepCopy() *PostgresServerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresServerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresServerStatus) DeepCopyInto(out *PostgresServerStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresServerStatus.
+func (in *PostgresServerStatus) DeepCopy() *PostgresServerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresServerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresTablePrivilegesSpec) DeepCopyInto(out *PostgresTablePrivilegesSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresTablePrivilegesSpec.
+func (in *PostgresTablePrivilegesSpec) DeepCopy() *PostgresTablePrivilegesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresTablePrivilegesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresTypePrivilegesSpec) DeepCopyInto(out *PostgresTypePrivilegesSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresTypePrivilegesSpec.
+func (in *PostgresTypePrivilegesSpec) DeepCopy() *PostgresTypePrivilegesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresTypePrivilegesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
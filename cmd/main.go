@@ -11,15 +11,18 @@ import (
 	"go.uber.org/zap/zapcore"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 
@@ -27,6 +30,7 @@ import (
 	"github.com/hoppscale/managed-postgres-operator/internal/controller"
 	"github.com/hoppscale/managed-postgres-operator/internal/postgresql"
 	"github.com/hoppscale/managed-postgres-operator/internal/utils"
+	webhookv1alpha1 "github.com/hoppscale/managed-postgres-operator/internal/webhook/v1alpha1"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -46,13 +50,17 @@ func init() {
 func main() {
 	var metricsAddr string
 	var metricsCertPath, metricsCertName, metricsCertKey string
+	var webhookCertPath, webhookCertName, webhookCertKey string
+	var enableWebhooks bool
 	var enableLeaderElection bool
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
 	var tlsOpts []func(*tls.Config)
 	var operatorInstanceName string
+	var operatorNamespace string
 	var reconciliationRequeueInterval time.Duration
+	var dryRun bool
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -66,11 +74,22 @@ func main() {
 		"The directory that contains the metrics server certificate.")
 	flag.StringVar(&metricsCertName, "metrics-cert-name", "tls.crt", "The name of the metrics server certificate file.")
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"If set, the validating webhooks of the resources are served. "+
+			"They must be registered in a ValidatingWebhookConfiguration.")
+	flag.StringVar(&webhookCertPath, "webhook-cert-path", "", "The directory that contains the webhook certificate.")
+	flag.StringVar(&webhookCertName, "webhook-cert-name", "tls.crt", "The name of the webhook certificate file.")
+	flag.StringVar(&webhookCertKey, "webhook-cert-key", "tls.key", "The name of the webhook key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
 	flag.StringVar(&operatorInstanceName, "operator-instance-name", "", "The name of this operator instance.")
+	flag.StringVar(&operatorNamespace, "operator-namespace", "",
+		"The namespace of the operator, the only one the Secrets of the PostgresServers are read from.")
 	flag.DurationVar(&reconciliationRequeueInterval, "reconciliation-requeue-interval", 5*time.Minute,
 		"Default interval between resource reconciliation")
+	flag.BoolVar(&dryRun, "dry-run", false,
+		"If set, the statements changing the PostgreSQL servers are only planned and reported in the resources' status and events. "+
+			"A single resource can also be put in dry-run mode with the annotation "+utils.DryRunAnnotationName+"=true.")
 
 	opts := zap.Options{
 		Development:     true,
@@ -100,6 +119,10 @@ func main() {
 		operatorInstanceName = os.Getenv("OPERATOR_INSTANCE_NAME")
 	}
 
+	if operatorNamespace == "" {
+		operatorNamespace = os.Getenv("POD_NAMESPACE")
+	}
+
 	pgpool, err := pgxpool.New(context.Background(), os.Getenv("DATABASE_URL"))
 	if err != nil {
 		setupLog.Error(err, "Failed to connect PostgreSQL server: %s", err)
@@ -112,12 +135,37 @@ func main() {
 		Databases: map[string]postgresql.PGPoolInterface{
 			pgpool.Config().ConnConfig.Database: pgpool,
 		},
+		Servers: map[string]*postgresql.PGPools{},
 	}
 
-	cacheRolePasswords := make(map[string]string)
+	// Create watchers for metrics and webhooks certificates
+	var metricsCertWatcher, webhookCertWatcher *certwatcher.CertWatcher
+
+	// Initial webhook TLS options
+	webhookTLSOpts := tlsOpts
 
-	// Create watchers for metrics certificate
-	var metricsCertWatcher *certwatcher.CertWatcher
+	if len(webhookCertPath) > 0 {
+		setupLog.Info("Initializing webhook certificate watcher using provided certificates",
+			"webhook-cert-path", webhookCertPath, "webhook-cert-name", webhookCertName, "webhook-cert-key", webhookCertKey)
+
+		var err error
+		webhookCertWatcher, err = certwatcher.New(
+			filepath.Join(webhookCertPath, webhookCertName),
+			filepath.Join(webhookCertPath, webhookCertKey),
+		)
+		if err != nil {
+			setupLog.Error(err, "Failed to initialize webhook certificate watcher")
+			os.Exit(1)
+		}
+
+		webhookTLSOpts = append(webhookTLSOpts, func(config *tls.Config) {
+			config.GetCertificate = webhookCertWatcher.GetCertificate
+		})
+	}
+
+	webhookServer := webhook.NewServer(webhook.Options{
+		TLSOpts: webhookTLSOpts,
+	})
 
 	// Metrics endpoint is enabled in 'config/default/kustomization.yaml'. The Metrics options configure the server.
 	// More info:
@@ -162,9 +210,17 @@ func main() {
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
 		Metrics:                metricsServerOptions,
+		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       utils.GetLeaderElectionID(operatorInstanceName),
+		// The Secrets are read from the API server instead of being cached: the operator only reads a few of them,
+		// and the controllers only watch the metadata of the others
+		Client: client.Options{
+			Cache: &client.CacheOptions{
+				DisableFor: []client.Object{&corev1.Secret{}},
+			},
+		},
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -185,9 +241,11 @@ func main() {
 	if err = (&controller.PostgresDatabaseReconciler{
 		Client:               mgr.GetClient(),
 		Scheme:               mgr.GetScheme(),
+		Recorder:             mgr.GetEventRecorder("postgresdatabase-controller"),
 		RequeueInterval:      reconciliationRequeueInterval,
 		PGPools:              pgpools,
 		OperatorInstanceName: operatorInstanceName,
+		DryRun:               dryRun,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "PostgresDatabase")
 
@@ -196,26 +254,73 @@ func main() {
 	if err = (&controller.PostgresRoleReconciler{
 		Client:               mgr.GetClient(),
 		Scheme:               mgr.GetScheme(),
+		Recorder:             mgr.GetEventRecorder("postgresrole-controller"),
 		RequeueInterval:      reconciliationRequeueInterval,
 		PGPools:              pgpools,
 		OperatorInstanceName: operatorInstanceName,
-		CacheRolePasswords:   cacheRolePasswords,
+		DryRun:               dryRun,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "PostgresRole")
 		os.Exit(1)
 	}
+	if err = (&controller.PostgresGrantReconciler{
+		Client:               mgr.GetClient(),
+		Scheme:               mgr.GetScheme(),
+		Recorder:             mgr.GetEventRecorder("postgresgrant-controller"),
+		RequeueInterval:      reconciliationRequeueInterval,
+		PGPools:              pgpools,
+		OperatorInstanceName: operatorInstanceName,
+		DryRun:               dryRun,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "PostgresGrant")
+		os.Exit(1)
+	}
 	if err = (&controller.PostgresSchemaReconciler{
 		Client:               mgr.GetClient(),
 		Scheme:               mgr.GetScheme(),
+		Recorder:             mgr.GetEventRecorder("postgresschema-controller"),
 		RequeueInterval:      reconciliationRequeueInterval,
 		PGPools:              pgpools,
 		OperatorInstanceName: operatorInstanceName,
+		DryRun:               dryRun,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "PostgresSchema")
 		os.Exit(1)
 	}
+	postgresServerReconciler := &controller.PostgresServerReconciler{
+		Client:               mgr.GetClient(),
+		Scheme:               mgr.GetScheme(),
+		RequeueInterval:      reconciliationRequeueInterval,
+		PGPools:              pgpools,
+		OperatorInstanceName: operatorInstanceName,
+		OperatorNamespace:    operatorNamespace,
+	}
+	if err = postgresServerReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "PostgresServer")
+		os.Exit(1)
+	}
+	if enableWebhooks {
+		if err = webhookv1alpha1.SetupWebhooksWithManager(mgr, webhookv1alpha1.OperatorIdentity{
+			InstanceName: operatorInstanceName,
+			RoleName:     pgpool.Config().ConnConfig.User,
+			DatabaseName: pgpool.Config().ConnConfig.Database,
+			// The role and the database used to connect to each PostgresServer are read from its credentials Secret
+			ServerConnection: postgresServerReconciler.ServerConnection,
+		}); err != nil {
+			setupLog.Error(err, "unable to create webhooks")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
+	if webhookCertWatcher != nil {
+		setupLog.Info("Adding webhook certificate watcher to manager")
+		if err := mgr.Add(webhookCertWatcher); err != nil {
+			setupLog.Error(err, "unable to add webhook certificate watcher to manager")
+			os.Exit(1)
+		}
+	}
+
 	if metricsCertWatcher != nil {
 		setupLog.Info("Adding metrics certificate watcher to manager")
 		if err := mgr.Add(metricsCertWatcher); err != nil {
@@ -60,3 +60,10 @@ Create the name of the service account to use
 {{- default "default" .Values.serviceAccount.name }}
 {{- end }}
 {{- end }}
+
+{{/*
+Create the name of the Secret holding the webhook's certificate
+*/}}
+{{- define "managed-postgres-operator.webhookSecretName" -}}
+{{- default (printf "%s-webhook-cert" (include "managed-postgres-operator.fullname" .)) .Values.webhook.certSecretName }}
+{{- end }}
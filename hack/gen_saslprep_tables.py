@@ -0,0 +1,64 @@
+#!/usr/bin/env python3
+"""Generates internal/postgresql/saslprep_tables.go from the tables of RFC 3454 (Unicode 3.2),
+as provided by the stringprep module of Python's standard library."""
+
+import stringprep
+
+TABLES = [
+    ("saslprepNonASCIISpace", "non-ASCII space characters, mapped to a space (C.1.2)",
+     [stringprep.in_table_c12]),
+    ("saslprepMappedToNothing", "characters commonly mapped to nothing (B.1)",
+     [stringprep.in_table_b1]),
+    ("saslprepProhibited", "prohibited output characters (C.1.2, C.2.1, C.2.2, C.3, C.4, C.5, C.6, C.7, C.8 and C.9)",
+     [stringprep.in_table_c12, stringprep.in_table_c21, stringprep.in_table_c22, stringprep.in_table_c3,
+      stringprep.in_table_c4, stringprep.in_table_c5, stringprep.in_table_c6, stringprep.in_table_c7,
+      stringprep.in_table_c8, stringprep.in_table_c9]),
+    ("saslprepUnassigned", "unassigned code points in Unicode 3.2 (A.1)",
+     [stringprep.in_table_a1]),
+    ("saslprepRandALCat", "characters with the bidirectional property R or AL (D.1)",
+     [stringprep.in_table_d1]),
+    ("saslprepLCat", "characters with the bidirectional property L (D.2)",
+     [stringprep.in_table_d2]),
+]
+
+
+def ranges(predicates):
+    result = []
+    start = None
+    for code in range(0x110000 + 1):
+        inside = code <= 0x10FFFF and not 0xD800 <= code <= 0xDFFF and any(p(chr(code)) for p in predicates)
+        if inside and start is None:
+            start = code
+        elif not inside and start is not None:
+            result.append((start, code - 1))
+            start = None
+    return result
+
+
+def main():
+    lines = [
+        "// Code generated by hack/gen_saslprep_tables.py. DO NOT EDIT.",
+        "",
+        "package postgresql",
+        "",
+        'import "unicode"',
+    ]
+    for name, description, predicates in TABLES:
+        r16 = [r for r in ranges(predicates) if r[1] <= 0xFFFF]
+        r32 = [r for r in ranges(predicates) if r[1] > 0xFFFF]
+        lines += ["", "// %s holds the %s" % (name, description), "var %s = &unicode.RangeTable{" % name]
+        if r16:
+            lines.append("\tR16: []unicode.Range16{")
+            lines += ["\t\t{Lo: 0x%04x, Hi: 0x%04x, Stride: 1}," % r for r in r16]
+            lines.append("\t},")
+        if r32:
+            lines.append("\tR32: []unicode.Range32{")
+            lines += ["\t\t{Lo: 0x%x, Hi: 0x%x, Stride: 1}," % r for r in r32]
+            lines.append("\t},")
+        lines.append("}")
+    with open("internal/postgresql/saslprep_tables.go", "w") as f:
+        f.write("\n".join(lines) + "\n")
+
+
+if __name__ == "__main__":
+    main()
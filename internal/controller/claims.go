@@ -0,0 +1,76 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SpecNameField is the field indexed in the cache to list the resources claiming the same PostgreSQL object.
+// It is also a selectable field of the CRDs, only so that users can list them with kubectl on a recent API server.
+const SpecNameField = "spec.name"
+
+// indexSpecName registers the index of the PostgreSQL object's name of the resources of the given kind
+func indexSpecName[T client.Object](mgr ctrl.Manager, object T, name func(T) string) error {
+	return mgr.GetFieldIndexer().IndexField(context.Background(), object, SpecNameField, func(obj client.Object) []string {
+		return []string{name(obj.(T))}
+	})
+}
+
+// claimOwner returns the resource managing the PostgreSQL object among the resources claiming it,
+// which is the oldest one, or the first one by namespace and name if they have been created at the same time.
+func claimOwner(claimants []client.Object) client.Object {
+	if len(claimants) == 0 {
+		return nil
+	}
+
+	return slices.MinFunc(claimants, func(a, b client.Object) int {
+		return cmp.Or(
+			a.GetCreationTimestamp().Time.Compare(b.GetCreationTimestamp().Time),
+			strings.Compare(client.ObjectKeyFromObject(a).String(), client.ObjectKeyFromObject(b).String()),
+		)
+	})
+}
+
+// conflictingClaimant returns the resource managing the PostgreSQL object if it isn't the given resource
+func conflictingClaimant(resource client.Object, claimants []client.Object) client.Object {
+	owner := claimOwner(claimants)
+	if owner == nil || owner.GetUID() == resource.GetUID() {
+		return nil
+	}
+	return owner
+}
+
+// conflictError describes the conflict with the resource managing the PostgreSQL object
+func conflictError(kind, name string, owner client.Object) error {
+	return fmt.Errorf("%s \"%s\" is already managed by the resource \"%s\"", kind, name, client.ObjectKeyFromObject(owner))
+}
+
+// hasSuccessor returns whether another resource, not being deleted, claims the PostgreSQL object managed by the resource.
+// The object is then left to it instead of being dropped.
+func hasSuccessor(resource client.Object, claimants []client.Object) bool {
+	return slices.ContainsFunc(claimants, func(claimant client.Object) bool {
+		return claimant.GetUID() != resource.GetUID() && claimant.GetDeletionTimestamp().IsZero()
+	})
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	managedpostgresoperatorhoppscalecomv1alpha1 "github.com/hoppscale/managed-postgres-operator/api/v1alpha1"
+)
+
+var _ = Describe("Claims", func() {
+	now := time.Now()
+
+	newClaimant := func(namespace, name string, createdAt time.Time) *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole {
+		return &managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         namespace,
+				Name:              name,
+				UID:               types.UID(namespace + "/" + name),
+				CreationTimestamp: metav1.NewTime(createdAt),
+			},
+		}
+	}
+
+	When("several resources claim the same object", func() {
+		It("should be managed by the oldest resource", func() {
+			oldest := newClaimant("foo", "b", now.Add(-time.Hour))
+			newest := newClaimant("default", "a", now)
+
+			claimants := []client.Object{newest, oldest}
+
+			Expect(claimOwner(claimants)).To(Equal(oldest))
+			Expect(conflictingClaimant(oldest, claimants)).To(BeNil())
+			Expect(conflictingClaimant(newest, claimants)).To(Equal(oldest))
+		})
+
+		It("should be managed by the first resource by namespace and name if they have been created at the same time", func() {
+			first := newClaimant("default", "a", now)
+			second := newClaimant("default", "b", now)
+
+			Expect(claimOwner([]client.Object{second, first})).To(Equal(first))
+		})
+	})
+
+	When("the resource managing the object is deleted", func() {
+		It("should have a successor only if another resource isn't being deleted", func() {
+			owner := newClaimant("default", "a", now.Add(-time.Hour))
+			successor := newClaimant("default", "b", now)
+
+			Expect(hasSuccessor(owner, []client.Object{owner})).To(BeFalse())
+			Expect(hasSuccessor(owner, []client.Object{owner, successor})).To(BeTrue())
+
+			deletionTimestamp := metav1.NewTime(now)
+			successor.DeletionTimestamp = &deletionTimestamp
+			Expect(hasSuccessor(owner, []client.Object{owner, successor})).To(BeFalse())
+		})
+	})
+})
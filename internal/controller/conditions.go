@@ -0,0 +1,177 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	managedpostgresoperatorhoppscalecomv1alpha1 "github.com/hoppscale/managed-postgres-operator/api/v1alpha1"
+)
+
+// Condition reasons, named after the reconciliation step that sets them
+const (
+	ReasonReconciled                       = "Reconciled"
+	ReasonServerNotReady                   = "ServerNotReady"
+	ReasonOpenPoolFailed                   = "OpenPoolFailed"
+	ReasonRetrieveCredentialsFailed        = "RetrieveCredentialsFailed"
+	ReasonRetrieveServerCAFailed           = "RetrieveServerCAFailed"
+	ReasonRetrieveRolePasswordFailed       = "RetrieveRolePasswordFailed"
+	ReasonGetRoleFailed                    = "GetRoleFailed"
+	ReasonGetDatabaseFailed                = "GetDatabaseFailed"
+	ReasonGetSchemaFailed                  = "GetSchemaFailed"
+	ReasonGetObjectFailed                  = "GetObjectFailed"
+	ReasonObjectNotFound                   = "ObjectNotFound"
+	ReasonObjectAlreadyExists              = "ObjectAlreadyExists"
+	ReasonInvalidSpec                      = "InvalidSpec"
+	ReasonReconcileOnCreationFailed        = "ReconcileOnCreationFailed"
+	ReasonReconcileOnDeletionFailed        = "ReconcileOnDeletionFailed"
+	ReasonReconcileRoleMembershipFailed    = "ReconcileRoleMembershipFailed"
+	ReasonReconcileRoleSecretFailed        = "ReconcileRoleSecretFailed"
+	ReasonReconcileLoginRolesFailed        = "ReconcileLoginRolesFailed"
+	ReasonReconcileRoleConfigFailed        = "ReconcileRoleConfigFailed"
+	ReasonReconcileDatabaseConfigFailed    = "ReconcileDatabaseConfigFailed"
+	ReasonReconcileExtensionsFailed        = "ReconcileExtensionsFailed"
+	ReasonReconcilePrivilegesFailed        = "ReconcilePrivilegesFailed"
+	ReasonReconcileDefaultPrivilegesFailed = "ReconcileDefaultPrivilegesFailed"
+	ReasonReconcileObjectPrivilegesFailed  = "ReconcileObjectPrivilegesFailed"
+	ReasonConflict                         = "Conflict"
+	ReasonDependencyNotReady               = "DependencyNotReady"
+	ReasonResolveReferenceFailed           = "ResolveReferenceFailed"
+	ReasonDryRun                           = "DryRun"
+	ReasonDryRunNoChanges                  = "DryRunNoChanges"
+)
+
+// setSucceededConditions marks the resource as ready and synced
+func setSucceededConditions(conditions *[]metav1.Condition, generation int64) {
+	setReconciledConditions(conditions, generation, ReasonReconciled)
+}
+
+// setReconciledConditions marks the resource as ready and synced with the given reason
+func setReconciledConditions(conditions *[]metav1.Condition, generation int64, reason string) {
+	for _, conditionType := range []string{
+		managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeReady,
+		managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeSynced,
+	} {
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               conditionType,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: generation,
+			Reason:             reason,
+		})
+	}
+
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeDegraded,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             reason,
+	})
+
+	meta.RemoveStatusCondition(conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeDeletionBlocked)
+	meta.RemoveStatusCondition(conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeConflict)
+}
+
+// setConflictConditions marks the resource as in conflict with the resource managing the same PostgreSQL object
+func setConflictConditions(conditions *[]metav1.Condition, generation int64, err error) {
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeConflict,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             ReasonConflict,
+		Message:            err.Error(),
+	})
+
+	setFailedConditions(conditions, generation, false, ReasonConflict, err)
+}
+
+// setPlannedConditions reports the result of a dry run, with reasons telling it apart from a reconciliation applying the changes.
+// Without planned statements, the resource is marked as ready and synced, as the server already matches it.
+// Otherwise, the resource is marked as not synced because the statements haven't been executed,
+// and a resource which has never been ready is marked as not ready.
+func setPlannedConditions(conditions *[]metav1.Condition, generation int64, statements []string) {
+	if len(statements) == 0 {
+		setReconciledConditions(conditions, generation, ReasonDryRunNoChanges)
+		return
+	}
+
+	message := fmt.Sprintf("%d statement(s) planned by the dry run haven't been executed", len(statements))
+
+	readyStatus := metav1.ConditionFalse
+	if meta.IsStatusConditionTrue(*conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeReady) {
+		readyStatus = metav1.ConditionTrue
+	}
+
+	for conditionType, status := range map[string]metav1.ConditionStatus{
+		managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeSynced: metav1.ConditionFalse,
+		managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeReady:  readyStatus,
+	} {
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               conditionType,
+			Status:             status,
+			ObservedGeneration: generation,
+			Reason:             ReasonDryRun,
+			Message:            message,
+		})
+	}
+}
+
+// setFailedConditions marks the resource as not synced and not ready because of the failing step,
+// so that the failure of a new generation isn't hidden by a previous success.
+// A resource which has already been ready is also marked as degraded.
+// If the resource is being deleted, it is marked as deletion blocked instead.
+func setFailedConditions(conditions *[]metav1.Condition, generation int64, deleting bool, reason string, err error) {
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeSynced,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            err.Error(),
+	})
+
+	if deleting {
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeDeletionBlocked,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: generation,
+			Reason:             reason,
+			Message:            err.Error(),
+		})
+		return
+	}
+
+	if meta.IsStatusConditionTrue(*conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeReady) ||
+		meta.IsStatusConditionTrue(*conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeDegraded) {
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeDegraded,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: generation,
+			Reason:             reason,
+			Message:            err.Error(),
+		})
+	}
+
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeReady,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            err.Error(),
+	})
+}
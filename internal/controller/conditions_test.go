@@ -0,0 +1,164 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	managedpostgresoperatorhoppscalecomv1alpha1 "github.com/hoppscale/managed-postgres-operator/api/v1alpha1"
+)
+
+var _ = Describe("Conditions", func() {
+	When("the reconciliation succeeds", func() {
+		It("should mark the resource as ready, synced and not degraded", func() {
+			conditions := []metav1.Condition{}
+
+			setSucceededConditions(&conditions, 2)
+
+			Expect(meta.IsStatusConditionTrue(conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeReady)).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeSynced)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeDegraded)).To(BeTrue())
+			for _, condition := range conditions {
+				Expect(condition.ObservedGeneration).To(Equal(int64(2)))
+			}
+		})
+	})
+
+	When("the reconciliation fails on a resource which has never been ready", func() {
+		It("should mark the resource as not ready", func() {
+			conditions := []metav1.Condition{}
+
+			setFailedConditions(&conditions, 1, false, ReasonGetRoleFailed, fmt.Errorf("connection refused"))
+
+			readyCondition := meta.FindStatusCondition(conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeReady)
+			Expect(readyCondition).NotTo(BeNil())
+			Expect(readyCondition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(readyCondition.Reason).To(Equal(ReasonGetRoleFailed))
+			Expect(readyCondition.Message).To(Equal("connection refused"))
+			Expect(meta.IsStatusConditionFalse(conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeSynced)).To(BeTrue())
+			Expect(meta.FindStatusCondition(conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeDegraded)).To(BeNil())
+		})
+	})
+
+	When("the reconciliation fails on a resource which is ready", func() {
+		It("should mark the resource as not ready and degraded", func() {
+			conditions := []metav1.Condition{}
+			setSucceededConditions(&conditions, 1)
+
+			setFailedConditions(&conditions, 2, false, ReasonReconcilePrivilegesFailed, fmt.Errorf("permission denied"))
+
+			readyCondition := meta.FindStatusCondition(conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeReady)
+			Expect(readyCondition).NotTo(BeNil())
+			Expect(readyCondition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(readyCondition.Reason).To(Equal(ReasonReconcilePrivilegesFailed))
+			Expect(readyCondition.ObservedGeneration).To(Equal(int64(2)))
+			Expect(meta.IsStatusConditionFalse(conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeSynced)).To(BeTrue())
+			degradedCondition := meta.FindStatusCondition(conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeDegraded)
+			Expect(degradedCondition).NotTo(BeNil())
+			Expect(degradedCondition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(degradedCondition.Reason).To(Equal(ReasonReconcilePrivilegesFailed))
+
+			By("Failing again, the resource should stay degraded with the new failing step")
+			setFailedConditions(&conditions, 2, false, ReasonReconcileExtensionsFailed, fmt.Errorf("extension not available"))
+			degradedCondition = meta.FindStatusCondition(conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeDegraded)
+			Expect(degradedCondition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(degradedCondition.Reason).To(Equal(ReasonReconcileExtensionsFailed))
+
+			By("Succeeding again, the resource should not be degraded anymore")
+			setSucceededConditions(&conditions, 2)
+			Expect(meta.IsStatusConditionFalse(conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeDegraded)).To(BeTrue())
+		})
+	})
+
+	When("the reconciliation fails on a resource being deleted", func() {
+		It("should mark the deletion as blocked", func() {
+			conditions := []metav1.Condition{}
+			setSucceededConditions(&conditions, 1)
+
+			setFailedConditions(&conditions, 1, true, ReasonReconcileOnDeletionFailed, fmt.Errorf("database is being accessed by other users"))
+
+			deletionBlockedCondition := meta.FindStatusCondition(conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeDeletionBlocked)
+			Expect(deletionBlockedCondition).NotTo(BeNil())
+			Expect(deletionBlockedCondition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(deletionBlockedCondition.Reason).To(Equal(ReasonReconcileOnDeletionFailed))
+			Expect(meta.IsStatusConditionFalse(conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeSynced)).To(BeTrue())
+		})
+	})
+
+	When("the resource claims an object managed by another resource", func() {
+		It("should mark the resource as in conflict until it succeeds", func() {
+			conditions := []metav1.Condition{}
+
+			setConflictConditions(&conditions, 1, fmt.Errorf("role \"myrole\" is already managed by the resource \"default/other\""))
+
+			conflictCondition := meta.FindStatusCondition(conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeConflict)
+			Expect(conflictCondition).NotTo(BeNil())
+			Expect(conflictCondition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(conflictCondition.Reason).To(Equal(ReasonConflict))
+			Expect(meta.IsStatusConditionFalse(conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeReady)).To(BeTrue())
+
+			By("Succeeding, the resource should not be in conflict anymore")
+			setSucceededConditions(&conditions, 1)
+			Expect(meta.FindStatusCondition(conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeConflict)).To(BeNil())
+		})
+	})
+
+	When("the statements of a dry run have been planned", func() {
+		It("should mark the resource as not synced, and as not ready only if it has never been ready", func() {
+			conditions := []metav1.Condition{}
+
+			setPlannedConditions(&conditions, 1, []string{`CREATE ROLE "myrole"`})
+
+			syncedCondition := meta.FindStatusCondition(conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeSynced)
+			Expect(syncedCondition).NotTo(BeNil())
+			Expect(syncedCondition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(syncedCondition.Reason).To(Equal(ReasonDryRun))
+			Expect(syncedCondition.Message).To(Equal("1 statement(s) planned by the dry run haven't been executed"))
+			Expect(meta.IsStatusConditionFalse(conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeReady)).To(BeTrue())
+
+			By("Planning statements on a resource which is ready, the resource should stay ready")
+			setSucceededConditions(&conditions, 1)
+			setPlannedConditions(&conditions, 2, []string{`ALTER ROLE "myrole" WITH LOGIN`})
+			Expect(meta.IsStatusConditionTrue(conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeReady)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeSynced)).To(BeTrue())
+			Expect(meta.FindStatusCondition(conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeReady).Reason).To(Equal(ReasonDryRun))
+		})
+	})
+
+	When("a dry run has planned no statement", func() {
+		It("should mark the resource as ready and synced with the dry run's reason", func() {
+			conditions := []metav1.Condition{}
+
+			setPlannedConditions(&conditions, 1, nil)
+
+			for _, conditionType := range []string{
+				managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeReady,
+				managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeSynced,
+			} {
+				condition := meta.FindStatusCondition(conditions, conditionType)
+				Expect(condition).NotTo(BeNil())
+				Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+				Expect(condition.Reason).To(Equal(ReasonDryRunNoChanges))
+			}
+		})
+	})
+})
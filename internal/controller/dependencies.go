@@ -0,0 +1,162 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	managedpostgresoperatorhoppscalecomv1alpha1 "github.com/hoppscale/managed-postgres-operator/api/v1alpha1"
+)
+
+// Fields indexed in the cache to list the resources referencing a PostgresDatabase, a PostgresRole or a Secret.
+// They are also selectable fields of the CRDs, only so that users can list them with kubectl on a recent API server.
+const (
+	DatabaseRefField        = "spec.databaseRef"
+	OwnerRefField           = "spec.ownerRef"
+	PasswordFromSecretField = "spec.passwordFromSecret.name"
+)
+
+// dependencyError reports a referenced resource which doesn't exist or isn't ready yet.
+// The resource referencing it waits for it instead of failing.
+type dependencyError struct {
+	message string
+}
+
+func (e *dependencyError) Error() string {
+	return e.message
+}
+
+// indexReference registers the index of the resource referenced by the given field, for the resources of the given kind
+func indexReference[T client.Object](mgr ctrl.Manager, object T, field string, reference func(T) string) error {
+	return mgr.GetFieldIndexer().IndexField(context.Background(), object, field, func(obj client.Object) []string {
+		if name := reference(obj.(T)); name != "" {
+			return []string{name}
+		}
+		return nil
+	})
+}
+
+// resolveDatabaseRef returns the name of the database managed by the referenced PostgresDatabase, once it is ready.
+// If no PostgresDatabase is referenced, the given database is returned.
+func resolveDatabaseRef(ctx context.Context, c client.Reader, namespace, serverRef, databaseRef, database string) (string, error) {
+	if databaseRef == "" {
+		return database, nil
+	}
+
+	resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase{}
+	if err := getDependency(ctx, c, "PostgresDatabase", types.NamespacedName{Namespace: namespace, Name: databaseRef}, resource); err != nil {
+		return "", err
+	}
+
+	if err := checkDependency("PostgresDatabase", databaseRef, resource.Spec.ServerRef, serverRef, resource.Status.Conditions); err != nil {
+		return "", err
+	}
+
+	if database != "" && database != resource.Spec.Name {
+		return "", fmt.Errorf("database \"%s\" doesn't match the database \"%s\" of the PostgresDatabase \"%s\"", database, resource.Spec.Name, databaseRef)
+	}
+
+	return resource.Spec.Name, nil
+}
+
+// resolveOwnerRef returns the name of the role managed by the referenced PostgresRole, once it is ready.
+// If no PostgresRole is referenced, the given owner is returned.
+// The owner and the reference are mutually exclusive, but a resource created before this rule may set both.
+func resolveOwnerRef(ctx context.Context, c client.Reader, namespace, serverRef, ownerRef, owner string) (string, error) {
+	if ownerRef == "" {
+		return owner, nil
+	}
+
+	resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole{}
+	if err := getDependency(ctx, c, "PostgresRole", types.NamespacedName{Namespace: namespace, Name: ownerRef}, resource); err != nil {
+		return "", err
+	}
+
+	if err := checkDependency("PostgresRole", ownerRef, resource.Spec.ServerRef, serverRef, resource.Status.Conditions); err != nil {
+		return "", err
+	}
+
+	if owner != "" && owner != resource.Spec.Name {
+		return "", fmt.Errorf("owner \"%s\" doesn't match the role \"%s\" of the PostgresRole \"%s\"", owner, resource.Spec.Name, ownerRef)
+	}
+
+	return resource.Spec.Name, nil
+}
+
+// dependentRequests returns the requests reconciling the resources which reference the dependency through the given field.
+// A dependency which isn't ready has no dependent to reconcile.
+func dependentRequests(ctx context.Context, c client.Reader, dependents client.ObjectList, field string, dependency client.Object, conditions []metav1.Condition) []reconcile.Request {
+	if !meta.IsStatusConditionTrue(conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeReady) {
+		return nil
+	}
+
+	return referencingRequests(ctx, c, dependents, field, dependency)
+}
+
+// referencingRequests returns the requests reconciling the resources which reference the object through the given field
+func referencingRequests(ctx context.Context, c client.Reader, resources client.ObjectList, field string, object client.Object) []reconcile.Request {
+	if err := c.List(ctx, resources, client.InNamespace(object.GetNamespace()), client.MatchingFields{field: object.GetName()}); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list the referencing resources", "field", field)
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	_ = meta.EachListItem(resources, func(obj runtime.Object) error {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(obj.(client.Object))})
+		return nil
+	})
+	return requests
+}
+
+func getDependency(ctx context.Context, c client.Reader, kind string, key types.NamespacedName, resource client.Object) error {
+	if err := c.Get(ctx, key, resource); err != nil {
+		if apierrors.IsNotFound(err) {
+			return &dependencyError{message: fmt.Sprintf("%s \"%s\" doesn't exist", kind, key.Name)}
+		}
+		return fmt.Errorf("failed to get %s \"%s\": %s", kind, key.Name, err)
+	}
+	return nil
+}
+
+func checkDependency(kind, name, dependencyServerRef, serverRef string, conditions []metav1.Condition) error {
+	if dependencyServerRef != serverRef {
+		return fmt.Errorf("%s \"%s\" is managed on another server", kind, name)
+	}
+
+	if !meta.IsStatusConditionTrue(conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeReady) {
+		return &dependencyError{message: fmt.Sprintf("%s \"%s\" isn't ready", kind, name)}
+	}
+	return nil
+}
+
+// isDependencyError returns whether the error reports a referenced resource which isn't ready yet
+func isDependencyError(err error) bool {
+	var dependencyErr *dependencyError
+	return errors.As(err, &dependencyErr)
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	managedpostgresoperatorhoppscalecomv1alpha1 "github.com/hoppscale/managed-postgres-operator/api/v1alpha1"
+)
+
+var _ = Describe("Dependencies", func() {
+	ctx := context.Background()
+
+	When("no resource is referenced", func() {
+		It("should return the given name", func() {
+			database, err := resolveDatabaseRef(ctx, k8sClient, "default", "", "", "mydb")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(database).To(Equal("mydb"))
+
+			owner, err := resolveOwnerRef(ctx, k8sClient, "default", "", "", "myrole")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(owner).To(Equal("myrole"))
+		})
+	})
+
+	When("the referenced resource doesn't exist", func() {
+		It("should return a dependency error", func() {
+			_, err := resolveOwnerRef(ctx, k8sClient, "default", "", "missing-role", "")
+			Expect(err).To(MatchError(`PostgresRole "missing-role" doesn't exist`))
+			Expect(isDependencyError(err)).To(BeTrue())
+		})
+	})
+
+	When("the referenced resource is ready", func() {
+		var database *managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase
+
+		BeforeEach(func() {
+			database = &managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "dependency-database",
+					Namespace: "default",
+				},
+				Spec: managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabaseSpec{
+					ServerRef: "myserver",
+					Name:      "mydb",
+				},
+			}
+			Expect(k8sClient.Create(ctx, database)).To(Succeed())
+			setSucceededConditions(&database.Status.Conditions, database.Generation)
+			Expect(k8sClient.Status().Update(ctx, database)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.Delete(ctx, database)).To(Succeed())
+		})
+
+		It("should return the name of its PostgreSQL object", func() {
+			name, err := resolveDatabaseRef(ctx, k8sClient, "default", "myserver", "dependency-database", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(name).To(Equal("mydb"))
+		})
+
+		It("should fail if it is managed on another server", func() {
+			_, err := resolveDatabaseRef(ctx, k8sClient, "default", "", "dependency-database", "")
+			Expect(err).To(MatchError(`PostgresDatabase "dependency-database" is managed on another server`))
+			Expect(isDependencyError(err)).To(BeFalse())
+		})
+
+		It("should fail if the role doesn't match the resource's owner", func() {
+			role := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "dependency-role",
+					Namespace: "default",
+				},
+				Spec: managedpostgresoperatorhoppscalecomv1alpha1.PostgresRoleSpec{
+					ServerRef: "myserver",
+					Name:      "myrole",
+				},
+			}
+			Expect(k8sClient.Create(ctx, role)).To(Succeed())
+			defer func() {
+				Expect(k8sClient.Delete(ctx, role)).To(Succeed())
+			}()
+			setSucceededConditions(&role.Status.Conditions, role.Generation)
+			Expect(k8sClient.Status().Update(ctx, role)).To(Succeed())
+
+			_, err := resolveOwnerRef(ctx, k8sClient, "default", "myserver", "dependency-role", "otherrole")
+			Expect(err).To(MatchError(`owner "otherrole" doesn't match the role "myrole" of the PostgresRole "dependency-role"`))
+		})
+
+		It("should fail if it doesn't match the resource's database", func() {
+			_, err := resolveDatabaseRef(ctx, k8sClient, "default", "myserver", "dependency-database", "otherdb")
+			Expect(err).To(MatchError(`database "otherdb" doesn't match the database "mydb" of the PostgresDatabase "dependency-database"`))
+		})
+	})
+})
@@ -0,0 +1,29 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"github.com/hoppscale/managed-postgres-operator/internal/postgresql"
+)
+
+// startDryRun returns the pools to reconcile a resource in dry-run mode, which record in the returned plan
+// the statements changing the server instead of executing them. The events about changes are dropped.
+func startDryRun(pgpools *postgresql.PGPools, eventing *eventRecorder) (*postgresql.PGPools, *postgresql.DryRunPlan) {
+	plan := &postgresql.DryRunPlan{}
+	eventing.dryRun = true
+	return pgpools.DryRun(plan), plan
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+)
+
+// Event reasons for the changes made by the reconcilers
+const (
+	EventReasonRoleCreated             = "RoleCreated"
+	EventReasonRoleAltered             = "RoleAltered"
+	EventReasonRoleDropped             = "RoleDropped"
+	EventReasonRoleAdopted             = "RoleAdopted"
+	EventReasonOwnedObjectsReassigned  = "OwnedObjectsReassigned"
+	EventReasonRoleMembershipGranted   = "RoleMembershipGranted"
+	EventReasonRoleMembershipRevoked   = "RoleMembershipRevoked"
+	EventReasonRoleMembershipUpdated   = "RoleMembershipUpdated"
+	EventReasonSecretCreated           = "SecretCreated"
+	EventReasonSecretUpdated           = "SecretUpdated"
+	EventReasonPasswordRotated         = "PasswordRotated"
+	EventReasonLoginRoleSwitched       = "LoginRoleSwitched"
+	EventReasonRoleConfigSet           = "RoleConfigSet"
+	EventReasonRoleConfigReset         = "RoleConfigReset"
+	EventReasonDatabaseCreated         = "DatabaseCreated"
+	EventReasonDatabaseAltered         = "DatabaseAltered"
+	EventReasonDatabaseOwnerAltered    = "DatabaseOwnerAltered"
+	EventReasonDatabaseDropped         = "DatabaseDropped"
+	EventReasonDatabaseAdopted         = "DatabaseAdopted"
+	EventReasonDatabaseConfigSet       = "DatabaseConfigSet"
+	EventReasonDatabaseConfigReset     = "DatabaseConfigReset"
+	EventReasonExtensionCreated        = "ExtensionCreated"
+	EventReasonExtensionUpdated        = "ExtensionUpdated"
+	EventReasonExtensionAltered        = "ExtensionAltered"
+	EventReasonExtensionDropped        = "ExtensionDropped"
+	EventReasonSchemaCreated           = "SchemaCreated"
+	EventReasonSchemaOwnerAltered      = "SchemaOwnerAltered"
+	EventReasonSchemaDropped           = "SchemaDropped"
+	EventReasonPrivilegeGranted        = "PrivilegeGranted"
+	EventReasonPrivilegeRevoked        = "PrivilegeRevoked"
+	EventReasonDefaultPrivilegeGranted = "DefaultPrivilegeGranted"
+	EventReasonDefaultPrivilegeRevoked = "DefaultPrivilegeRevoked"
+	EventReasonObjectPrivilegeGranted  = "ObjectPrivilegeGranted"
+	EventReasonObjectPrivilegeRevoked  = "ObjectPrivilegeRevoked"
+	EventReasonGrantOptionRevoked      = "GrantOptionRevoked"
+	EventReasonDriftDetected           = "DriftDetected"
+	EventReasonStatementPlanned        = "StatementPlanned"
+)
+
+// Event actions, describing the kind of change made by the reconcilers
+const (
+	EventActionCreate    = "Create"
+	EventActionAlter     = "Alter"
+	EventActionDrop      = "Drop"
+	EventActionReassign  = "Reassign"
+	EventActionAdopt     = "Adopt"
+	EventActionGrant     = "Grant"
+	EventActionRevoke    = "Revoke"
+	EventActionUpdate    = "Update"
+	EventActionReconcile = "Reconcile"
+	EventActionPlan      = "Plan"
+)
+
+// eventRecorder emits events regarding the resource being reconciled.
+// It does nothing if no recorder has been configured.
+type eventRecorder struct {
+	recorder events.EventRecorder
+	object   runtime.Object
+
+	// dryRun drops the events about changes, as they haven't been made
+	dryRun bool
+}
+
+// newEventRecorder returns an eventRecorder emitting events regarding the given object
+func newEventRecorder(recorder events.EventRecorder, object runtime.Object) eventRecorder {
+	return eventRecorder{
+		recorder: recorder,
+		object:   object,
+	}
+}
+
+// Normal emits an event about a change made on the PostgreSQL server
+func (e eventRecorder) Normal(reason, action, note string, args ...interface{}) {
+	if e.dryRun {
+		return
+	}
+	e.emit(corev1.EventTypeNormal, reason, action, note, args...)
+}
+
+// Planned emits an event about each statement planned by a dry run
+func (e eventRecorder) Planned(statements []string) {
+	for _, statement := range statements {
+		e.emit(corev1.EventTypeNormal, EventReasonStatementPlanned, EventActionPlan, "Dry run planned the statement: %s", statement)
+	}
+}
+
+// Warning emits an event about a reconciliation failure
+func (e eventRecorder) Warning(reason, action, note string, args ...interface{}) {
+	e.emit(corev1.EventTypeWarning, reason, action, note, args...)
+}
+
+func (e eventRecorder) emit(eventType, reason, action, note string, args ...interface{}) {
+	if e.recorder == nil || e.object == nil {
+		return
+	}
+	e.recorder.Eventf(e.object, nil, eventType, reason, action, note, args...)
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	managedpostgresoperatorhoppscalecomv1alpha1 "github.com/hoppscale/managed-postgres-operator/api/v1alpha1"
+)
+
+// checkManagementPolicy returns the reason and the error preventing the resource from managing the PostgreSQL object,
+// depending on whether the object exists and whether the resource has created or adopted it
+func checkManagementPolicy(policy, kind, name string, exists, owned bool) (reason string, err error) {
+	switch policy {
+	case managedpostgresoperatorhoppscalecomv1alpha1.ManagementPolicyCreateOnly:
+		if exists && !owned {
+			return ReasonObjectAlreadyExists, fmt.Errorf("%s \"%s\" already exists and the CreateOnly management policy doesn't adopt it, set the management policy to Adopt to take it over", kind, name)
+		}
+	case managedpostgresoperatorhoppscalecomv1alpha1.ManagementPolicyObserve:
+		if !exists {
+			return ReasonObjectNotFound, fmt.Errorf("%s \"%s\" doesn't exist and the Observe management policy doesn't create it", kind, name)
+		}
+	}
+	return "", nil
+}
+
+// keepsObjectOnDelete returns whether the deletion of the resource leaves the PostgreSQL object, as the resource has never managed it
+func keepsObjectOnDelete(policy string, owned bool) bool {
+	switch policy {
+	case managedpostgresoperatorhoppscalecomv1alpha1.ManagementPolicyObserve:
+		return true
+	case managedpostgresoperatorhoppscalecomv1alpha1.ManagementPolicyCreateOnly:
+		return !owned
+	}
+	return false
+}
+
+// managedByPreviousVersion returns whether the resource has been reconciled by a version of the operator without management policies,
+// which took the existing objects over without recording it. Such a resource has succeeded without reporting any condition.
+func managedByPreviousVersion(succeeded bool, conditions []metav1.Condition) bool {
+	return succeeded && len(conditions) == 0
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	managedpostgresoperatorhoppscalecomv1alpha1 "github.com/hoppscale/managed-postgres-operator/api/v1alpha1"
+)
+
+var _ = Describe("Management policies", func() {
+	When("the object already exists", func() {
+		It("should only be rejected by CreateOnly if the resource doesn't own it", func() {
+			_, err := checkManagementPolicy(managedpostgresoperatorhoppscalecomv1alpha1.ManagementPolicyAdopt, "role", "myrole", true, false)
+			Expect(err).NotTo(HaveOccurred())
+
+			reason, err := checkManagementPolicy(managedpostgresoperatorhoppscalecomv1alpha1.ManagementPolicyCreateOnly, "role", "myrole", true, false)
+			Expect(err).To(MatchError(`role "myrole" already exists and the CreateOnly management policy doesn't adopt it, set the management policy to Adopt to take it over`))
+			Expect(reason).To(Equal(ReasonObjectAlreadyExists))
+
+			_, err = checkManagementPolicy(managedpostgresoperatorhoppscalecomv1alpha1.ManagementPolicyCreateOnly, "role", "myrole", true, true)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	When("the object doesn't exist", func() {
+		It("should only be rejected by Observe", func() {
+			_, err := checkManagementPolicy(managedpostgresoperatorhoppscalecomv1alpha1.ManagementPolicyCreateOnly, "database", "mydb", false, false)
+			Expect(err).NotTo(HaveOccurred())
+
+			reason, err := checkManagementPolicy(managedpostgresoperatorhoppscalecomv1alpha1.ManagementPolicyObserve, "database", "mydb", false, false)
+			Expect(err).To(MatchError(`database "mydb" doesn't exist and the Observe management policy doesn't create it`))
+			Expect(reason).To(Equal(ReasonObjectNotFound))
+		})
+	})
+
+	When("the resource has been reconciled by a previous version", func() {
+		It("should only be detected if it has succeeded without reporting any condition", func() {
+			Expect(managedByPreviousVersion(true, nil)).To(BeTrue())
+			Expect(managedByPreviousVersion(false, nil)).To(BeFalse())
+			Expect(managedByPreviousVersion(true, []metav1.Condition{{Type: managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeReady}})).To(BeFalse())
+		})
+	})
+
+	When("the resource is deleted", func() {
+		It("should keep the objects the resource has never managed", func() {
+			Expect(keepsObjectOnDelete(managedpostgresoperatorhoppscalecomv1alpha1.ManagementPolicyAdopt, false)).To(BeFalse())
+			Expect(keepsObjectOnDelete(managedpostgresoperatorhoppscalecomv1alpha1.ManagementPolicyCreateOnly, false)).To(BeTrue())
+			Expect(keepsObjectOnDelete(managedpostgresoperatorhoppscalecomv1alpha1.ManagementPolicyCreateOnly, true)).To(BeFalse())
+			Expect(keepsObjectOnDelete(managedpostgresoperatorhoppscalecomv1alpha1.ManagementPolicyObserve, true)).To(BeTrue())
+		})
+	})
+})
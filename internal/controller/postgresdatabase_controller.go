@@ -1,20 +1,25 @@
 package controller
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	"golang.org/x/time/rate"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -28,41 +33,128 @@ const PostgresDatabaseFinalizer = "postgresdatabase.managed-postgres-operator.ho
 // PostgresDatabaseReconciler reconciles a PostgresDatabase object
 type PostgresDatabaseReconciler struct {
 	client.Client
-	Scheme  *runtime.Scheme
-	logging logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder events.EventRecorder
 
 	RequeueInterval time.Duration
 
 	PGPools              *postgresql.PGPools
 	OperatorInstanceName string
+
+	// DryRun only plans the statements changing the server, for every resource
+	DryRun bool
+}
+
+// postgresDatabaseReconciliation holds the state of a single reconciliation,
+// so that the concurrent reconciliations don't share it
+type postgresDatabaseReconciliation struct {
+	*PostgresDatabaseReconciler
+	logging  logr.Logger
+	eventing eventRecorder
+	plan     *postgresql.DryRunPlan
 }
 
 // +kubebuilder:rbac:groups=managed-postgres-operator.hoppscale.com,resources=postgresdatabases,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=managed-postgres-operator.hoppscale.com,resources=postgresdatabases/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=managed-postgres-operator.hoppscale.com,resources=postgresdatabases/finalizers,verbs=update
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
 func (r *PostgresDatabaseReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	r.logging = log.FromContext(ctx)
+	reconciliation := &postgresDatabaseReconciliation{
+		PostgresDatabaseReconciler: r,
+		logging:                    log.FromContext(ctx),
+	}
+	return reconciliation.reconcile(ctx, req)
+}
 
+// reconcile runs a single reconciliation of the resource
+func (r *postgresDatabaseReconciliation) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase{}
 
 	if err := r.Client.Get(ctx, req.NamespacedName, resource); err != nil {
 		return r.Result(client.IgnoreNotFound(err))
 	}
 
+	r.eventing = newEventRecorder(r.Recorder, resource)
+
 	// Skip reconcile if the resource is not managed by this operator
 	if !utils.IsManagedByOperatorInstance(resource.ObjectMeta.Annotations, r.OperatorInstanceName) {
 		return r.Result(nil)
 	}
 
-	existingDatabase, err := postgresql.GetDatabase(r.PGPools.Default, resource.Spec.Name)
+	// The references to other resources are resolved on each reconciliation, once they are ready.
+	// The spec is left as written by the user, the resolved owner is recorded in the status.
+	owner := resource.Spec.Owner
+	if resource.ObjectMeta.DeletionTimestamp.IsZero() {
+		var err error
+		owner, err = resolveOwnerRef(ctx, r.Client, resource.Namespace, resource.Spec.ServerRef, resource.Spec.OwnerRef, resource.Spec.Owner)
+		if err != nil {
+			if isDependencyError(err) {
+				return r.Waiting(ctx, resource, err)
+			}
+			return r.Failure(ctx, resource, ReasonResolveReferenceFailed, err)
+		}
+
+		status := resource.Status.DeepCopy()
+		status.Owner = owner
+		if err := r.updateStatus(ctx, resource, status); err != nil {
+			return r.Result(err)
+		}
+	}
+
+	// The database is managed by the oldest of the resources claiming it
+	claimants, err := r.listClaimants(ctx, resource)
 	if err != nil {
-		return r.Result(fmt.Errorf("failed to retrieve database: %s", err))
+		return r.Result(err)
+	}
+	if manager := conflictingClaimant(resource, claimants); manager != nil {
+		if !resource.ObjectMeta.DeletionTimestamp.IsZero() {
+			// The database is left to the resource managing it
+			controllerutil.RemoveFinalizer(resource, PostgresDatabaseFinalizer)
+			return r.Result(r.Update(ctx, resource))
+		}
+		// The resource takes the database over once the resource owning it is deleted
+		managerStatus := manager.(*managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase).Status
+		return r.Conflict(ctx, resource, managerStatus.Owned || managerStatus.Creating, conflictError("database", resource.Spec.Name, manager))
+	}
+
+	// A resource reconciled by a previous version has already taken the database over
+	if !resource.Status.Owned && managedByPreviousVersion(resource.Status.Succeeded, resource.Status.Conditions) {
+		if err := r.recordOwnership(ctx, resource, false); err != nil {
+			return r.Result(err)
+		}
+	}
+
+	pgpools, err := postgresql.GetServerPGPools(r.PGPools, resource.Spec.ServerRef)
+	if err != nil {
+		return r.Failure(ctx, resource, ReasonServerNotReady, err)
+	}
+
+	if utils.IsDryRun(resource.ObjectMeta.Annotations, r.DryRun) {
+		pgpools, r.plan = startDryRun(pgpools, &r.eventing)
+		r.logging = r.logging.WithValues("dryRun", true)
+	}
+
+	existingDatabase, err := postgresql.GetDatabase(pgpools.Default, resource.Spec.Name)
+	if err != nil {
+		return r.Failure(ctx, resource, ReasonGetDatabaseFailed, fmt.Errorf("failed to retrieve database: %s", err))
 	}
 
 	desiredDatabase := postgresql.Database{
-		Name:       resource.Spec.Name,
-		Owner:      resource.Spec.Owner,
-		Extensions: resource.Spec.Extensions,
+		Name:             resource.Spec.Name,
+		Owner:            owner,
+		ConnectionLimit:  databaseConnectionLimit(resource),
+		AllowConnections: databaseAllowConnections(resource),
+		IsTemplate:       resource.Spec.IsTemplate,
+		Tablespace:       resource.Spec.Tablespace,
+
+		Template:       resource.Spec.Template,
+		Encoding:       resource.Spec.Encoding,
+		LcCollate:      resource.Spec.LcCollate,
+		LcCtype:        resource.Spec.LcCtype,
+		LocaleProvider: resource.Spec.LocaleProvider,
+		IcuLocale:      resource.Spec.IcuLocale,
+
+		Extensions: databaseExtensions(resource),
 	}
 
 	if resource.ObjectMeta.DeletionTimestamp.IsZero() {
@@ -83,11 +175,20 @@ func (r *PostgresDatabaseReconciler) Reconcile(ctx context.Context, req ctrl.Req
 			return r.Result(nil)
 		}
 
-		err = r.reconcileOnDeletion(resource, existingDatabase)
-		if err != nil {
-			return r.Result(err)
+		// The database is left to the next resource claiming it, or kept if the resource has never managed it
+		if hasSuccessor(resource, claimants) {
+			r.logging.Info(fmt.Sprintf("Database \"%s\" is claimed by another resource, skipping DROP DATABASE", resource.Spec.Name))
+		} else if keepsObjectOnDelete(resource.Spec.ManagementPolicy, resource.Status.Owned || resource.Status.Creating) {
+			r.logging.Info(fmt.Sprintf("Database \"%s\" isn't managed by the resource, skipping DROP DATABASE", resource.Spec.Name))
+		} else {
+			err = r.reconcileOnDeletion(pgpools, resource, existingDatabase)
+			if err != nil {
+				return r.Failure(ctx, resource, ReasonReconcileOnDeletionFailed, err)
+			}
 		}
 
+		r.eventing.Planned(r.plan.Statements())
+
 		// Remove our finalizer from the list and update it.
 		controllerutil.RemoveFinalizer(resource, PostgresDatabaseFinalizer)
 		if err := r.Update(ctx, resource); err != nil {
@@ -98,45 +199,104 @@ func (r *PostgresDatabaseReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return r.Result(nil)
 	}
 
+	reason, err := checkManagementPolicy(resource.Spec.ManagementPolicy, "database", resource.Spec.Name, existingDatabase != nil, resource.Status.Owned || resource.Status.Creating)
+	if err != nil {
+		return r.Failure(ctx, resource, reason, err)
+	}
+
+	// An observed database is only reported with its extensions
+	if resource.Spec.ManagementPolicy == managedpostgresoperatorhoppscalecomv1alpha1.ManagementPolicyObserve {
+		installedExtensions, err := r.observeExtensions(pgpools, existingDatabase)
+		if err != nil {
+			return r.Failure(ctx, resource, ReasonReconcileExtensionsFailed, err)
+		}
+		return r.Success(ctx, resource, installedExtensions)
+	}
+
 	//
 	// Creation logic
 	//
 
-	err = r.reconcileOnCreation(existingDatabase, &desiredDatabase)
-	if err != nil {
+	// An existing database is adopted before being altered, and the creation of a new one is recorded beforehand
+	if existingDatabase != nil {
+		if err := r.recordOwnership(ctx, resource, true); err != nil {
+			return r.Result(err)
+		}
+	} else if err := r.recordCreation(ctx, resource); err != nil {
 		return r.Result(err)
 	}
 
-	err = r.reconcileExtensions(&desiredDatabase)
+	err = r.reconcileOnCreation(pgpools, existingDatabase, &desiredDatabase)
 	if err != nil {
+		return r.Failure(ctx, resource, ReasonReconcileOnCreationFailed, err)
+	}
+
+	if err := r.recordOwnership(ctx, resource, false); err != nil {
 		return r.Result(err)
 	}
 
+	// The next steps of a dry run would connect to the database, which hasn't been created
+	if r.plan != nil && existingDatabase == nil {
+		return r.Success(ctx, resource, nil)
+	}
+
+	err = r.reconcileParameters(pgpools, desiredDatabase.Name, databaseConfigParameters(resource))
+	if err != nil {
+		return r.Failure(ctx, resource, ReasonReconcileDatabaseConfigFailed, err)
+	}
+
+	// The operator can't connect to a database which doesn't accept connections
+	var installedExtensions []postgresql.Extension
+	if desiredDatabase.AllowConnections {
+		// A resource whose extensions have never been recorded has been reconciled by a previous version of the operator, at most
+		seedManagedExtensions := resource.Status.Extensions == nil && resource.Status.ManagedExtensions == nil
+
+		var managedExtensions []string
+		installedExtensions, managedExtensions, err = r.reconcileExtensions(pgpools, &desiredDatabase, resource.Spec.ExtensionPolicy, resource.Status.ManagedExtensions, seedManagedExtensions)
+
+		// The created extensions are recorded even if the reconciliation failed, so that they can be dropped later
+		if statusErr := r.recordManagedExtensions(ctx, resource, managedExtensions); statusErr != nil {
+			return r.Result(statusErr)
+		}
+		if err != nil {
+			return r.Failure(ctx, resource, ReasonReconcileExtensionsFailed, err)
+		}
+	}
+
 	for roleName, rolePrivileges := range resource.Spec.PrivilegesByRole {
 		err = r.reconcilePrivileges(
+			pgpools,
 			desiredDatabase.Name,
 			roleName,
 			r.convertPrivilegesSpecToList(rolePrivileges),
 		)
 		if err != nil {
-			return r.Result(err)
+			return r.Failure(ctx, resource, ReasonReconcilePrivilegesFailed, err)
 		}
 	}
 
-	if !resource.Status.Succeeded {
-		resource.Status.Succeeded = true
-		if err = r.Client.Status().Update(context.Background(), resource); err != nil {
-			return r.Result(fmt.Errorf("failed to update object: %s", err))
-		}
-	}
-
-	return r.Result(nil)
+	return r.Success(ctx, resource, installedExtensions)
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *PostgresDatabaseReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := indexSpecName(mgr, &managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase{}, func(resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase) string {
+		return resource.Spec.Name
+	})
+	if err != nil {
+		return err
+	}
+
+	err = indexReference(mgr, &managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase{}, OwnerRefField, func(resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase) string {
+		return resource.Spec.OwnerRef
+	})
+	if err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase{}).
+		Watches(&managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole{}, handler.EnqueueRequestsFromMapFunc(r.requestsForRole)).
 		Named("postgresdatabase").
 		WithOptions(controller.Options{
 			RateLimiter: workqueue.NewTypedMaxOfRateLimiter(
@@ -155,8 +315,160 @@ func (r *PostgresDatabaseReconciler) Result(err error) (ctrl.Result, error) {
 	return ctrl.Result{RequeueAfter: r.RequeueInterval}, nil
 }
 
+// Success marks the resource as ready and synced or reports the statements planned by a dry run, records the installed extensions, then builds the reconciler result
+func (r *postgresDatabaseReconciliation) Success(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase, installedExtensions []postgresql.Extension) (ctrl.Result, error) {
+	status := resource.Status.DeepCopy()
+	status.Succeeded = true
+	status.ObservedGeneration = resource.Generation
+	status.Extensions = nil
+	for _, extension := range installedExtensions {
+		status.Extensions = append(status.Extensions, managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabaseExtensionStatus{
+			Name:              extension.Name,
+			Version:           extension.Version,
+			Schema:            extension.Schema,
+			AvailableUpgrades: extension.AvailableUpgrades,
+		})
+	}
+	// The statements planned by a dry run are reported instead of marking the resource as synced
+	status.PlannedStatements = r.plan.Statements()
+	if r.plan != nil {
+		setPlannedConditions(&status.Conditions, resource.Generation, status.PlannedStatements)
+		r.eventing.Planned(status.PlannedStatements)
+	} else {
+		setSucceededConditions(&status.Conditions, resource.Generation)
+	}
+
+	return r.Result(r.updateStatus(ctx, resource, status))
+}
+
+// Conflict marks the resource as in conflict with the resource managing the same database, then builds the reconciler result
+func (r *postgresDatabaseReconciliation) Conflict(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase, inheritsOwnership bool, err error) (ctrl.Result, error) {
+	status := resource.Status.DeepCopy()
+	status.Succeeded = false
+	// The database owned by the resource managing it is owned by its successor, which doesn't adopt it
+	if inheritsOwnership {
+		status.Owned = true
+	}
+	status.ObservedGeneration = resource.Generation
+	setConflictConditions(&status.Conditions, resource.Generation, err)
+
+	r.eventing.Warning(ReasonConflict, EventActionReconcile, "%s", err)
+
+	return r.Result(r.updateStatus(ctx, resource, status))
+}
+
+// listClaimants returns the resources managed by this operator's instance claiming the same database as the resource
+func (r *PostgresDatabaseReconciler) listClaimants(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase) ([]client.Object, error) {
+	resources := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabaseList{}
+	if err := r.List(ctx, resources, client.MatchingFields{SpecNameField: resource.Spec.Name}); err != nil {
+		return nil, fmt.Errorf("failed to list the resources claiming the database: %s", err)
+	}
+
+	claimants := []client.Object{}
+	for i := range resources.Items {
+		claimant := &resources.Items[i]
+		if claimant.Spec.ServerRef == resource.Spec.ServerRef && utils.IsManagedByOperatorInstance(claimant.ObjectMeta.Annotations, r.OperatorInstanceName) {
+			claimants = append(claimants, claimant)
+		}
+	}
+	return claimants, nil
+}
+
+// requestsForRole returns the requests reconciling the databases owned by the PostgresRole, once it is ready
+func (r *PostgresDatabaseReconciler) requestsForRole(ctx context.Context, obj client.Object) []reconcile.Request {
+	role := obj.(*managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole)
+	return dependentRequests(ctx, r.Client, &managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabaseList{}, OwnerRefField, role, role.Status.Conditions)
+}
+
+// Waiting records the referenced resource the resource is waiting for in its conditions, then builds the reconciler result.
+// The resource is reconciled again as soon as the referenced resource is ready.
+func (r *postgresDatabaseReconciliation) Waiting(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase, err error) (ctrl.Result, error) {
+	status := resource.Status.DeepCopy()
+	status.Succeeded = false
+	status.ObservedGeneration = resource.Generation
+	setFailedConditions(&status.Conditions, resource.Generation, false, ReasonDependencyNotReady, err)
+
+	r.logging.Info("Waiting for a referenced resource", "reason", err.Error())
+
+	return r.Result(r.updateStatus(ctx, resource, status))
+}
+
+// Failure records the failing step in the resource's conditions, then builds the reconciler result
+func (r *postgresDatabaseReconciliation) Failure(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase, reason string, err error) (ctrl.Result, error) {
+	status := resource.Status.DeepCopy()
+	status.Succeeded = false
+	status.ObservedGeneration = resource.Generation
+	setFailedConditions(&status.Conditions, resource.Generation, !resource.ObjectMeta.DeletionTimestamp.IsZero(), reason, err)
+
+	r.eventing.Warning(reason, EventActionReconcile, "%s", err)
+
+	if statusErr := r.updateStatus(ctx, resource, status); statusErr != nil {
+		r.logging.Error(statusErr, "failed to update status")
+	}
+
+	return r.Result(err)
+}
+
+// updateStatus updates the resource's status only if it has changed
+func (r *PostgresDatabaseReconciler) updateStatus(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase, status *managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabaseStatus) error {
+	if equality.Semantic.DeepEqual(resource.Status, *status) {
+		return nil
+	}
+
+	resource.Status = *status
+	if err := r.Client.Status().Update(ctx, resource); err != nil {
+		return fmt.Errorf("failed to update object: %s", err)
+	}
+
+	return nil
+}
+
+// recordOwnership records in the resource's status that the database has been created or adopted by the resource
+func (r *postgresDatabaseReconciliation) recordOwnership(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase, adopted bool) error {
+	// A dry run neither creates nor adopts the database
+	if resource.Status.Owned || r.plan != nil {
+		return nil
+	}
+
+	// A database found after an interrupted creation has been created by the resource, not adopted
+	if adopted && !resource.Status.Creating {
+		r.logging.Info(fmt.Sprintf("Database \"%s\" has been adopted", resource.Spec.Name))
+		r.eventing.Normal(EventReasonDatabaseAdopted, EventActionAdopt, "Database \"%s\" has been adopted", resource.Spec.Name)
+	}
+
+	status := resource.Status.DeepCopy()
+	status.Owned = true
+	status.Creating = false
+	return r.updateStatus(ctx, resource, status)
+}
+
+// recordCreation records in the resource's status that the database is about to be created by the resource,
+// so that a database created by a reconciliation which fails to record its ownership is still owned by the resource
+func (r *postgresDatabaseReconciliation) recordCreation(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase) error {
+	// A dry run doesn't create the database
+	if resource.Status.Owned || r.plan != nil {
+		return nil
+	}
+
+	status := resource.Status.DeepCopy()
+	status.Creating = true
+	return r.updateStatus(ctx, resource, status)
+}
+
+// recordManagedExtensions updates the extensions created by the operator in the resource's status
+func (r *postgresDatabaseReconciliation) recordManagedExtensions(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase, managedExtensions []string) error {
+	// A dry run doesn't create nor drop extensions
+	if r.plan != nil {
+		return nil
+	}
+
+	status := resource.Status.DeepCopy()
+	status.ManagedExtensions = slices.Sorted(slices.Values(managedExtensions))
+	return r.updateStatus(ctx, resource, status)
+}
+
 // reconcileOnDeletion performs all actions related to deleting the resource
-func (r *PostgresDatabaseReconciler) reconcileOnDeletion(resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase, existingDatabase *postgresql.Database) (err error) {
+func (r *postgresDatabaseReconciliation) reconcileOnDeletion(pgpools *postgresql.PGPools, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase, existingDatabase *postgresql.Database) (err error) {
 	if existingDatabase == nil {
 		// If the remote database doesn't exist
 		r.logging.Info("Database doesn't exist, skipping DROP DATABASE")
@@ -169,9 +481,23 @@ func (r *PostgresDatabaseReconciler) reconcileOnDeletion(resource *managedpostgr
 		return
 	}
 
+	// A template database can't be dropped
+	if existingDatabase.IsTemplate {
+		err = postgresql.AlterDatabase(pgpools.Default, existingDatabase, &postgresql.Database{
+			Name:             existingDatabase.Name,
+			ConnectionLimit:  existingDatabase.ConnectionLimit,
+			AllowConnections: existingDatabase.AllowConnections,
+			IsTemplate:       false,
+		})
+		if err != nil {
+			r.logging.Error(err, "failed to unmark template database")
+			return
+		}
+	}
+
 	// If the resource is not configured to preserve connections to the remote database on delete
 	if !resource.Spec.PreserveConnectionsOnDelete {
-		err = postgresql.DropDatabaseConnections(r.PGPools.Default, existingDatabase.Name)
+		err = postgresql.DropDatabaseConnections(pgpools.Default, existingDatabase.Name)
 		if err != nil {
 			r.logging.Error(err, "failed to drop connections")
 			return
@@ -179,105 +505,326 @@ func (r *PostgresDatabaseReconciler) reconcileOnDeletion(resource *managedpostgr
 	}
 
 	// Drop the remote database
-	err = postgresql.DropDatabase(r.PGPools.Default, existingDatabase.Name)
+	err = postgresql.DropDatabase(pgpools.Default, existingDatabase.Name)
 	if err != nil {
 		r.logging.Error(err, "failed to delete database")
 		return
 	}
 
+	r.logging.Info("Database has been deleted")
+	r.eventing.Normal(EventReasonDatabaseDropped, EventActionDrop, "Database \"%s\" has been dropped", existingDatabase.Name)
+
 	return
 }
 
 // reconcileOnCreation performs all actions related to creating the resource
-func (r *PostgresDatabaseReconciler) reconcileOnCreation(existingDatabase, desiredDatabase *postgresql.Database) (err error) {
+func (r *postgresDatabaseReconciliation) reconcileOnCreation(pgpools *postgresql.PGPools, existingDatabase, desiredDatabase *postgresql.Database) (err error) {
 	alterOwner := false
 
 	if existingDatabase == nil {
-		err = postgresql.CreateDatabase(r.PGPools.Default, desiredDatabase.Name)
+		err = postgresql.CreateDatabase(pgpools.Default, desiredDatabase)
 		if err != nil {
 			r.logging.Error(err, "failed to create database")
 			return
 		}
 		r.logging.Info("Database has been created")
+		r.eventing.Normal(EventReasonDatabaseCreated, EventActionCreate, "Database \"%s\" has been created", desiredDatabase.Name)
 		alterOwner = true
 	} else {
 		if existingDatabase.Owner != desiredDatabase.Owner {
 			alterOwner = true
 		}
+
+		err = r.reconcileDatabaseOptions(pgpools, existingDatabase, desiredDatabase)
+		if err != nil {
+			return
+		}
 	}
 
 	if alterOwner && desiredDatabase.Owner != "" {
-		err = postgresql.AlterDatabaseOwner(r.PGPools.Default, desiredDatabase.Name, desiredDatabase.Owner)
+		err = postgresql.AlterDatabaseOwner(pgpools.Default, desiredDatabase.Name, desiredDatabase.Owner)
 		if err != nil {
 			r.logging.Error(err, "failed to alter database owner")
 			return
 		}
 		r.logging.Info(fmt.Sprintf("Owner of the database \"%s\" has been updated", desiredDatabase.Name))
+		r.eventing.Normal(EventReasonDatabaseOwnerAltered, EventActionAlter, "Owner of the database \"%s\" has been changed to \"%s\"", desiredDatabase.Name, desiredDatabase.Owner)
 	}
 
 	return
 }
 
-// reconcileOnCreation performs all actions related to the database extensions management
-func (r *PostgresDatabaseReconciler) reconcileExtensions(database *postgresql.Database) (err error) {
-	err = postgresql.EnsurePGPoolExists(r.PGPools, database.Name)
+// reconcileDatabaseOptions alters the options of an existing database which can be changed after its creation
+func (r *postgresDatabaseReconciliation) reconcileDatabaseOptions(pgpools *postgresql.PGPools, existingDatabase, desiredDatabase *postgresql.Database) (err error) {
+	if existingDatabase.ConnectionLimit != desiredDatabase.ConnectionLimit ||
+		existingDatabase.AllowConnections != desiredDatabase.AllowConnections ||
+		existingDatabase.IsTemplate != desiredDatabase.IsTemplate {
+		err = postgresql.AlterDatabase(pgpools.Default, existingDatabase, desiredDatabase)
+		if err != nil {
+			r.logging.Error(err, "failed to alter database")
+			return
+		}
+		r.logging.Info(fmt.Sprintf("Database \"%s\" has been altered", desiredDatabase.Name))
+		r.eventing.Normal(EventReasonDatabaseAltered, EventActionAlter, "Database \"%s\" has been altered", desiredDatabase.Name)
+	}
+
+	// An empty tablespace keeps the database where it is
+	if desiredDatabase.Tablespace != "" && existingDatabase.Tablespace != desiredDatabase.Tablespace {
+		// Moving the database fails if a session is connected to it, including the operator's ones
+		postgresql.ClosePGPool(pgpools, desiredDatabase.Name)
+
+		err = postgresql.AlterDatabaseTablespace(pgpools.Default, desiredDatabase.Name, desiredDatabase.Tablespace)
+		if err != nil {
+			r.logging.Error(err, "failed to alter database tablespace")
+			return
+		}
+		r.logging.Info(fmt.Sprintf("Database \"%s\" has been moved to tablespace \"%s\"", desiredDatabase.Name, desiredDatabase.Tablespace))
+		r.eventing.Normal(EventReasonDatabaseAltered, EventActionAlter, "Database \"%s\" has been moved to tablespace \"%s\"", desiredDatabase.Name, desiredDatabase.Tablespace)
+	}
+
+	return
+}
+
+// reconcileParameters sets the desired configuration parameters of the database and resets the other ones
+func (r *postgresDatabaseReconciliation) reconcileParameters(pgpools *postgresql.PGPools, database string, desiredConfig []postgresql.DatabaseConfigParameter) (err error) {
+	existingConfig, err := postgresql.GetDatabaseConfig(pgpools.Default, database)
 	if err != nil {
-		r.logging.Error(err, "failed to open pg pool")
+		r.logging.Error(err, "failed to retrieve database's configuration")
 		return err
 	}
 
-	existingExtensions, err := postgresql.GetExtensions(r.PGPools.Databases[database.Name])
+	// Resetting parameters
+	for _, existingParameter := range existingConfig {
+		found := slices.ContainsFunc(desiredConfig, func(desiredParameter postgresql.DatabaseConfigParameter) bool {
+			return desiredParameter.Name == existingParameter.Name
+		})
+
+		if !found {
+			err = postgresql.ResetDatabaseConfigParameter(pgpools.Default, database, existingParameter.Name)
+			if err != nil {
+				r.logging.Error(err, "failed to reset database's configuration parameter")
+				return err
+			}
+			r.logging.Info(fmt.Sprintf("Parameter \"%s\" of database \"%s\" has been reset", existingParameter.Name, database))
+			r.eventing.Normal(EventReasonDatabaseConfigReset, EventActionAlter, "Parameter \"%s\" of database \"%s\" has been reset", existingParameter.Name, database)
+		}
+	}
+
+	// Setting parameters
+	for _, desiredParameter := range desiredConfig {
+		found := slices.ContainsFunc(existingConfig, func(existingParameter postgresql.DatabaseConfigParameter) bool {
+			return existingParameter.Name == desiredParameter.Name &&
+				postgresql.ConfigParameterValuesEqual(desiredParameter.Name, existingParameter.Value, desiredParameter.Value)
+		})
+
+		if !found {
+			err = postgresql.SetDatabaseConfigParameter(pgpools.Default, database, desiredParameter.Name, desiredParameter.Value)
+			if err != nil {
+				r.logging.Error(err, "failed to set database's configuration parameter")
+				return err
+			}
+			r.logging.Info(fmt.Sprintf("Parameter \"%s\" of database \"%s\" has been set to \"%s\"", desiredParameter.Name, database, desiredParameter.Value))
+			r.eventing.Normal(EventReasonDatabaseConfigSet, EventActionAlter, "Parameter \"%s\" of database \"%s\" has been set to \"%s\"", desiredParameter.Name, database, desiredParameter.Value)
+		}
+	}
+
+	return nil
+}
+
+// observeExtensions returns the extensions installed on the existing database, without changing them
+func (r *PostgresDatabaseReconciler) observeExtensions(pgpools *postgresql.PGPools, existingDatabase *postgresql.Database) ([]postgresql.Extension, error) {
+	// The operator can't connect to a database which doesn't accept connections
+	if !existingDatabase.AllowConnections {
+		return nil, nil
+	}
+
+	err := postgresql.EnsurePGPoolExists(pgpools, existingDatabase.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return postgresql.GetExtensions(pgpools.Database(existingDatabase.Name))
+}
+
+// reconcileExtensions performs all actions related to the database extensions management.
+// It returns the installed extensions, and the extensions created by the operator, updated from the given ones.
+func (r *postgresDatabaseReconciliation) reconcileExtensions(pgpools *postgresql.PGPools, database *postgresql.Database, policy string, managedExtensions []string, seedManagedExtensions bool) (installedExtensions []postgresql.Extension, updatedManagedExtensions []string, err error) {
+	err = postgresql.EnsurePGPoolExists(pgpools, database.Name)
+	if err != nil {
+		r.logging.Error(err, "failed to open pg pool")
+		return nil, managedExtensions, err
+	}
+
+	existingExtensions, err := postgresql.GetExtensions(pgpools.Database(database.Name))
 	if err != nil {
 		r.logging.Error(err, "failed to retrieve extensions")
-		return err
+		return nil, managedExtensions, err
 	}
 
+	// The previous versions of the operator created every declared extension without recording it,
+	// so the declared extensions already installed are considered created by the operator
+	if seedManagedExtensions {
+		for _, desiredExt := range database.Extensions {
+			if slices.Contains(postgresql.BuiltinExtensions, desiredExt.Name) {
+				continue
+			}
+			if slices.ContainsFunc(existingExtensions, func(existingExt postgresql.Extension) bool { return existingExt.Name == desiredExt.Name }) {
+				managedExtensions = append(managedExtensions, desiredExt.Name)
+			}
+		}
+	}
+
+	// Extensions dropped by someone else aren't managed anymore
+	for _, name := range managedExtensions {
+		if slices.ContainsFunc(existingExtensions, func(existingExt postgresql.Extension) bool { return existingExt.Name == name }) {
+			updatedManagedExtensions = append(updatedManagedExtensions, name)
+		}
+	}
+
+	changed := false
+
 	// Listing extensions to drop
+	extensionsToDrop := []string{}
 	for _, existingExt := range existingExtensions {
-		found := false
-		for _, desiredExt := range database.Extensions {
-			if desiredExt == existingExt {
-				found = true
-				break
+		found := slices.ContainsFunc(database.Extensions, func(desiredExt postgresql.Extension) bool {
+			return desiredExt.Name == existingExt.Name
+		})
+		if found || slices.Contains(postgresql.BuiltinExtensions, existingExt.Name) {
+			continue
+		}
+
+		// With the Additive policy, the extensions not created by the operator are left untouched
+		managed := slices.Contains(updatedManagedExtensions, existingExt.Name)
+		if policy != managedpostgresoperatorhoppscalecomv1alpha1.ExtensionPolicyExclusive && !managed {
+			continue
+		}
+
+		extensionsToDrop = append(extensionsToDrop, existingExt.Name)
+	}
+
+	if len(extensionsToDrop) > 0 {
+		dependencies, err := postgresql.GetExtensionDependencies(pgpools.Database(database.Name))
+		if err != nil {
+			r.logging.Error(err, "failed to retrieve extension dependencies")
+			return nil, updatedManagedExtensions, err
+		}
+
+		orderedExtensionsToDrop := orderExtensionsToDrop(extensionsToDrop, existingExtensions, dependencies)
+		for _, name := range extensionsToDrop {
+			if !slices.Contains(orderedExtensionsToDrop, name) {
+				r.logging.Info(fmt.Sprintf("Extension \"%s\" is required by a kept extension, skipping DROP EXTENSION", name))
 			}
 		}
 
-		if !found {
-			err = postgresql.DropExtension(r.PGPools.Databases[database.Name], existingExt)
+		for _, name := range orderedExtensionsToDrop {
+			err = postgresql.DropExtension(pgpools.Database(database.Name), name)
 			if err != nil {
 				r.logging.Error(err, "failed to drop extension")
-				return err
+				return nil, updatedManagedExtensions, err
 			}
-			r.logging.Info(fmt.Sprintf("Extension \"%s\" has been dropped from database \"%s\"", existingExt, database.Name))
+			updatedManagedExtensions = slices.DeleteFunc(updatedManagedExtensions, func(managedName string) bool { return managedName == name })
+			changed = true
+			r.logging.Info(fmt.Sprintf("Extension \"%s\" has been dropped from database \"%s\"", name, database.Name))
+			r.eventing.Normal(EventReasonExtensionDropped, EventActionDrop, "Extension \"%s\" has been dropped from database \"%s\"", name, database.Name)
 		}
 	}
 
-	// Listing extensions to create
+	// Listing extensions to create or update
 	for _, desiredExt := range database.Extensions {
-		found := false
-		for _, existingExt := range existingExtensions {
-			if existingExt == desiredExt {
-				found = true
-				break
+		existingIndex := slices.IndexFunc(existingExtensions, func(existingExt postgresql.Extension) bool {
+			return existingExt.Name == desiredExt.Name
+		})
+
+		if existingIndex == -1 {
+			err = postgresql.CreateExtension(pgpools.Database(database.Name), desiredExt)
+			if err != nil {
+				r.logging.Error(err, "failed to create extension")
+				return nil, updatedManagedExtensions, err
 			}
+			updatedManagedExtensions = append(updatedManagedExtensions, desiredExt.Name)
+			changed = true
+			r.logging.Info(fmt.Sprintf("Extension \"%s\" has been created in database \"%s\"", desiredExt.Name, database.Name))
+			r.eventing.Normal(EventReasonExtensionCreated, EventActionCreate, "Extension \"%s\" has been created in database \"%s\"", desiredExt.Name, database.Name)
+			continue
 		}
 
-		if !found {
-			err = postgresql.CreateExtension(r.PGPools.Databases[database.Name], desiredExt)
+		existingExt := existingExtensions[existingIndex]
+
+		if desiredExt.Version != "" && desiredExt.Version != existingExt.Version {
+			err = postgresql.UpdateExtension(pgpools.Database(database.Name), desiredExt.Name, desiredExt.Version)
 			if err != nil {
-				r.logging.Error(err, "failed to create extension")
-				return err
+				r.logging.Error(err, "failed to update extension")
+				return nil, updatedManagedExtensions, err
 			}
-			r.logging.Info(fmt.Sprintf("Extension \"%s\" has been created in database \"%s\"", desiredExt, database.Name))
+			changed = true
+			r.logging.Info(fmt.Sprintf("Extension \"%s\" of database \"%s\" has been updated from version \"%s\" to \"%s\"", desiredExt.Name, database.Name, existingExt.Version, desiredExt.Version))
+			r.eventing.Normal(EventReasonExtensionUpdated, EventActionUpdate, "Extension \"%s\" of database \"%s\" has been updated from version \"%s\" to \"%s\"", desiredExt.Name, database.Name, existingExt.Version, desiredExt.Version)
+		}
+
+		if desiredExt.Schema != "" && desiredExt.Schema != existingExt.Schema {
+			err = postgresql.AlterExtensionSchema(pgpools.Database(database.Name), desiredExt.Name, desiredExt.Schema)
+			if err != nil {
+				r.logging.Error(err, "failed to alter extension schema")
+				return nil, updatedManagedExtensions, err
+			}
+			changed = true
+			r.logging.Info(fmt.Sprintf("Extension \"%s\" of database \"%s\" has been moved to schema \"%s\"", desiredExt.Name, database.Name, desiredExt.Schema))
+			r.eventing.Normal(EventReasonExtensionAltered, EventActionAlter, "Extension \"%s\" of database \"%s\" has been moved to schema \"%s\"", desiredExt.Name, database.Name, desiredExt.Schema)
 		}
 	}
-	return err
+
+	if !changed {
+		return existingExtensions, updatedManagedExtensions, nil
+	}
+
+	// Creations with CASCADE and updates change the installed extensions and their available upgrades
+	installedExtensions, err = postgresql.GetExtensions(pgpools.Database(database.Name))
+	if err != nil {
+		r.logging.Error(err, "failed to retrieve extensions")
+		return nil, updatedManagedExtensions, err
+	}
+	return installedExtensions, updatedManagedExtensions, nil
+}
+
+// orderExtensionsToDrop returns the extensions to drop, each one before the extensions it requires,
+// without the extensions required by a kept one, even indirectly, as PostgreSQL refuses to drop them
+func orderExtensionsToDrop(extensionsToDrop []string, installedExtensions []postgresql.Extension, dependencies map[string][]string) []string {
+	remaining := slices.Clone(extensionsToDrop)
+
+	kept := []string{}
+	for _, installedExt := range installedExtensions {
+		if !slices.Contains(remaining, installedExt.Name) {
+			kept = append(kept, installedExt.Name)
+		}
+	}
+	for i := 0; i < len(kept); i++ {
+		for _, required := range dependencies[kept[i]] {
+			if slices.Contains(remaining, required) {
+				remaining = slices.DeleteFunc(remaining, func(name string) bool { return name == required })
+				kept = append(kept, required)
+			}
+		}
+	}
+
+	ordered := []string{}
+	for len(remaining) > 0 {
+		// An extension required by another one to drop is dropped after it
+		next := slices.IndexFunc(remaining, func(name string) bool {
+			return !slices.ContainsFunc(remaining, func(other string) bool { return slices.Contains(dependencies[other], name) })
+		})
+		if next == -1 {
+			next = 0
+		}
+		ordered = append(ordered, remaining[next])
+		remaining = slices.Delete(remaining, next, next+1)
+	}
+	return ordered
 }
 
 // reconcilePrivileges performs all actions related to the database privileges for a single role
-func (r *PostgresDatabaseReconciler) reconcilePrivileges(databaseName, roleName string, desiredPrivileges []string) (err error) {
+func (r *postgresDatabaseReconciliation) reconcilePrivileges(pgpools *postgresql.PGPools, databaseName, roleName string, desiredPrivileges []string) (err error) {
 	// We retrieve the existing privileges
-	existingPrivileges, err := postgresql.GetDatabaseRolePrivileges(r.PGPools.Default, databaseName, roleName)
+	existingPrivileges, err := postgresql.GetDatabaseRolePrivileges(pgpools.Default, databaseName, roleName)
 	if err != nil {
 		r.logging.Error(err, "failed to retrieve privileges of database \"%s\" on role \"%s\": %s", databaseName, roleName, err)
 		return err
@@ -286,26 +833,28 @@ func (r *PostgresDatabaseReconciler) reconcilePrivileges(databaseName, roleName
 	// We grant the missing privileges
 	for _, desiredPrivilege := range desiredPrivileges {
 		if !slices.Contains(existingPrivileges, desiredPrivilege) {
-			err := postgresql.GrantDatabaseRolePrivilege(r.PGPools.Default, databaseName, roleName, desiredPrivilege)
+			err := postgresql.GrantDatabaseRolePrivilege(pgpools.Default, databaseName, roleName, desiredPrivilege)
 			if err != nil {
 				r.logging.Error(err, "failed to grant \"%s\" privilege on database \"%s\" to role \"%s\"", desiredPrivilege, databaseName, roleName)
 				return err
 			}
 
 			r.logging.Info(fmt.Sprintf("Privilege \"%s\" has been granted to \"%s\" on database \"%s\"", desiredPrivilege, roleName, databaseName))
+			r.eventing.Normal(EventReasonPrivilegeGranted, EventActionGrant, "Privilege \"%s\" has been granted to \"%s\" on database \"%s\"", desiredPrivilege, roleName, databaseName)
 		}
 	}
 
 	// We revoke the non-declared privileges
 	for _, existingPrivilege := range existingPrivileges {
 		if !slices.Contains(desiredPrivileges, existingPrivilege) {
-			err := postgresql.RevokeDatabaseRolePrivilege(r.PGPools.Default, databaseName, roleName, existingPrivilege)
+			err := postgresql.RevokeDatabaseRolePrivilege(pgpools.Default, databaseName, roleName, existingPrivilege)
 			if err != nil {
 				r.logging.Error(err, "failed to revoke \"%s\" privilege on database \"%s\" to role \"%s\"", existingPrivilege, databaseName, roleName)
 				return err
 			}
 
 			r.logging.Info(fmt.Sprintf("Privilege \"%s\" has been revoked from \"%s\" on database \"%s\"", existingPrivilege, roleName, databaseName))
+			r.eventing.Normal(EventReasonPrivilegeRevoked, EventActionRevoke, "Privilege \"%s\" has been revoked from \"%s\" on database \"%s\"", existingPrivilege, roleName, databaseName)
 		}
 	}
 	return err
@@ -324,3 +873,52 @@ func (r *PostgresDatabaseReconciler) convertPrivilegesSpecToList(privilegesSpec
 	}
 	return privileges
 }
+
+// databaseConnectionLimit returns the desired connection limit of the database, -1 meaning no limit
+func databaseConnectionLimit(resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase) int32 {
+	if resource.Spec.ConnectionLimit == nil {
+		return -1
+	}
+	return *resource.Spec.ConnectionLimit
+}
+
+// databaseAllowConnections returns whether the database accepts connections, true by default
+func databaseAllowConnections(resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase) bool {
+	if resource.Spec.AllowConnections == nil {
+		return true
+	}
+	return *resource.Spec.AllowConnections
+}
+
+// databaseExtensions returns the desired extensions of the database, the options declared in ExtensionsWithOptions taking precedence
+func databaseExtensions(resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase) []postgresql.Extension {
+	extensions := []postgresql.Extension{}
+	for _, extension := range resource.Spec.ExtensionsWithOptions {
+		extensions = append(extensions, postgresql.Extension{
+			Name:    extension.Name,
+			Version: extension.Version,
+			Schema:  extension.Schema,
+			Cascade: extension.Cascade,
+		})
+	}
+	for _, name := range resource.Spec.Extensions {
+		if !slices.ContainsFunc(extensions, func(extension postgresql.Extension) bool { return extension.Name == name }) {
+			extensions = append(extensions, postgresql.Extension{Name: name})
+		}
+	}
+	return extensions
+}
+
+// databaseConfigParameters returns the desired configuration parameters of the database, sorted by name
+func databaseConfigParameters(resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase) []postgresql.DatabaseConfigParameter {
+	parameters := []postgresql.DatabaseConfigParameter{}
+	for name, value := range resource.Spec.Parameters {
+		parameters = append(parameters, postgresql.DatabaseConfigParameter{Name: strings.ToLower(name), Value: value})
+	}
+
+	slices.SortFunc(parameters, func(a, b postgresql.DatabaseConfigParameter) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	return parameters
+}
@@ -8,7 +8,9 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -50,6 +52,8 @@ var _ = Describe("PostgresDatabase Controller", func() {
 						Extensions: []string{
 							"plpgsql",
 						},
+						// Most tests reconcile a database which already exists
+						ManagementPolicy: managedpostgresoperatorhoppscalecomv1alpha1.ManagementPolicyAdopt,
 					},
 				}
 				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
@@ -116,13 +120,22 @@ var _ = Describe("PostgresDatabase Controller", func() {
 					WillReturnResult(pgxmock.NewResult("", 1))
 				pgpoolsMock["default"].ExpectExec(`ALTER DATABASE "foo" OWNER TO "foo_owner"`).
 					WillReturnResult(pgxmock.NewResult("", 1))
-				pgpoolsMock["foo"].ExpectQuery(`SELECT extname FROM pg_extension`).
+				pgpoolsMock["default"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetDatabaseConfigSQLStatement))).
+					WithArgs("foo").
+					WillReturnRows(pgxmock.NewRows([]string{"name", "value"}))
+				pgpoolsMock["foo"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetExtensionsSQLStatement))).
 					WillReturnRows(
 						pgxmock.NewRows([]string{
-							"extname",
+							"name",
+							"version",
+							"schema",
+							"available_upgrades",
 						}).
 							AddRow(
 								"plpgsql",
+								"1.0",
+								"pg_catalog",
+								[]string{},
 							),
 					)
 
@@ -171,10 +184,12 @@ var _ = Describe("PostgresDatabase Controller", func() {
 		When("the resource is created and no database exists", func() {
 			It("should reconcile the resource and create the database", func() {
 				By("Reconciling the created resource")
+				recorder := events.NewFakeRecorder(10)
 				controllerReconciler := &PostgresDatabaseReconciler{
-					Client:  k8sClient,
-					Scheme:  k8sClient.Scheme(),
-					PGPools: pgpools,
+					Client:   k8sClient,
+					Scheme:   k8sClient.Scheme(),
+					Recorder: recorder,
+					PGPools:  pgpools,
 				}
 
 				pgpoolsMock["default"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetDatabaseSQLStatement))).
@@ -189,25 +204,672 @@ var _ = Describe("PostgresDatabase Controller", func() {
 					WillReturnResult(pgxmock.NewResult("", 1))
 				pgpoolsMock["default"].ExpectExec(`ALTER DATABASE "foo" OWNER TO "foo_owner"`).
 					WillReturnResult(pgxmock.NewResult("", 1))
-				pgpoolsMock["foo"].ExpectQuery(`SELECT extname FROM pg_extension`).
+				pgpoolsMock["default"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetDatabaseConfigSQLStatement))).
+					WithArgs("foo").
+					WillReturnRows(pgxmock.NewRows([]string{"name", "value"}))
+				pgpoolsMock["foo"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetExtensionsSQLStatement))).
 					WillReturnRows(
 						pgxmock.NewRows([]string{
-							"extname",
+							"name",
+							"version",
+							"schema",
+							"available_upgrades",
 						}).
 							AddRow(
 								"plpgsql",
+								"1.0",
+								"pg_catalog",
+								[]string{},
+							),
+					)
+
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: typeNamespacedName,
+				})
+
+				Expect(err).NotTo(HaveOccurred())
+				for _, poolMock := range pgpoolsMock {
+					if err := poolMock.ExpectationsWereMet(); err != nil {
+						Fail(err.Error())
+					}
+				}
+				Expect(recorder.Events).To(Receive(Equal(`Normal DatabaseCreated Database "foo" has been created`)))
+				Expect(recorder.Events).To(Receive(Equal(`Normal DatabaseOwnerAltered Owner of the database "foo" has been changed to "foo_owner"`)))
+
+				resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase{}
+				Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+				Expect(resource.Status.Owned).To(BeTrue())
+				Expect(resource.Status.Creating).To(BeFalse())
+			})
+		})
+
+		When("the database's options have drifted", func() {
+			It("should alter the database", func() {
+				recorder := events.NewFakeRecorder(10)
+				controllerReconciler := &PostgresDatabaseReconciler{
+					Client:   k8sClient,
+					Scheme:   k8sClient.Scheme(),
+					Recorder: recorder,
+					PGPools:  pgpools,
+				}
+
+				pgpoolsMock["default"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetDatabaseSQLStatement))).
+					WithArgs("foo").
+					WillReturnRows(
+						pgxmock.NewRows([]string{
+							"datname",
+							"owner",
+							"datconnlimit",
+							"datallowconn",
+							"datistemplate",
+							"tablespace",
+						}).
+							AddRow(
+								"foo",
+								"foo_owner",
+								int32(5),
+								true,
+								false,
+								"pg_default",
+							),
+					)
+				pgpoolsMock["default"].ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`ALTER DATABASE "foo" WITH CONNECTION LIMIT -1`))).
+					WillReturnResult(pgxmock.NewResult("ALTER DATABASE", 0))
+				pgpoolsMock["default"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetDatabaseConfigSQLStatement))).
+					WithArgs("foo").
+					WillReturnRows(pgxmock.NewRows([]string{"name", "value"}))
+				pgpoolsMock["foo"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetExtensionsSQLStatement))).
+					WillReturnRows(
+						pgxmock.NewRows([]string{
+							"name",
+							"version",
+							"schema",
+							"available_upgrades",
+						}).
+							AddRow(
+								"plpgsql",
+								"1.0",
+								"pg_catalog",
+								[]string{},
+							),
+					)
+
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: typeNamespacedName,
+				})
+
+				Expect(err).NotTo(HaveOccurred())
+				for _, poolMock := range pgpoolsMock {
+					if err := poolMock.ExpectationsWereMet(); err != nil {
+						Fail(err.Error())
+					}
+				}
+				Expect(recorder.Events).To(Receive(Equal(`Normal DatabaseAdopted Database "foo" has been adopted`)))
+				Expect(recorder.Events).To(Receive(Equal(`Normal DatabaseAltered Database "foo" has been altered`)))
+			})
+		})
+
+		When("the database's parameters have drifted", func() {
+			It("should set the desired parameters and reset the other ones", func() {
+				resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase{}
+				Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+				resource.Spec.Parameters = map[string]string{
+					"TimeZone":          "UTC",
+					"statement_timeout": "30s",
+				}
+				Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+
+				recorder := events.NewFakeRecorder(10)
+				controllerReconciler := &PostgresDatabaseReconciler{
+					Client:   k8sClient,
+					Scheme:   k8sClient.Scheme(),
+					Recorder: recorder,
+					PGPools:  pgpools,
+				}
+
+				pgpoolsMock["default"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetDatabaseSQLStatement))).
+					WithArgs("foo").
+					WillReturnRows(
+						pgxmock.NewRows([]string{"datname", "owner", "datconnlimit", "datallowconn", "datistemplate", "tablespace"}).
+							AddRow("foo", "foo_owner", int32(-1), true, false, "pg_default"),
+					)
+				pgpoolsMock["default"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetDatabaseConfigSQLStatement))).
+					WithArgs("foo").
+					WillReturnRows(
+						pgxmock.NewRows([]string{"name", "value"}).
+							AddRow("timezone", "UTC").
+							AddRow("work_mem", "64MB"),
+					)
+				pgpoolsMock["default"].ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`ALTER DATABASE "foo" RESET "work_mem"`))).
+					WillReturnResult(pgxmock.NewResult("ALTER DATABASE", 0))
+				pgpoolsMock["default"].ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`ALTER DATABASE "foo" SET "statement_timeout" = '30s'`))).
+					WillReturnResult(pgxmock.NewResult("ALTER DATABASE", 0))
+				pgpoolsMock["foo"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetExtensionsSQLStatement))).
+					WillReturnRows(pgxmock.NewRows([]string{"name", "version", "schema", "available_upgrades"}).AddRow("plpgsql", "1.0", "pg_catalog", []string{}))
+
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: typeNamespacedName,
+				})
+
+				Expect(err).NotTo(HaveOccurred())
+				for _, poolMock := range pgpoolsMock {
+					if err := poolMock.ExpectationsWereMet(); err != nil {
+						Fail(err.Error())
+					}
+				}
+				Expect(recorder.Events).To(Receive(Equal(`Normal DatabaseAdopted Database "foo" has been adopted`)))
+				Expect(recorder.Events).To(Receive(Equal(`Normal DatabaseConfigReset Parameter "work_mem" of database "foo" has been reset`)))
+				Expect(recorder.Events).To(Receive(Equal(`Normal DatabaseConfigSet Parameter "statement_timeout" of database "foo" has been set to "30s"`)))
+			})
+		})
+
+		When("extensions are declared with options", func() {
+			It("should update the extensions and report their available upgrades", func() {
+				resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase{}
+				Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+				resource.Spec.ExtensionsWithOptions = []managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabaseExtension{
+					{Name: "plpgsql", Version: "1.0", Schema: "pg_catalog"},
+					{Name: "postgis", Version: "3.4.0"},
+				}
+				Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+
+				recorder := events.NewFakeRecorder(10)
+				controllerReconciler := &PostgresDatabaseReconciler{
+					Client:   k8sClient,
+					Scheme:   k8sClient.Scheme(),
+					Recorder: recorder,
+					PGPools:  pgpools,
+				}
+
+				pgpoolsMock["default"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetDatabaseSQLStatement))).
+					WithArgs("foo").
+					WillReturnRows(
+						pgxmock.NewRows([]string{"datname", "owner", "datconnlimit", "datallowconn", "datistemplate", "tablespace"}).
+							AddRow("foo", "foo_owner", int32(-1), true, false, "pg_default"),
+					)
+				pgpoolsMock["default"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetDatabaseConfigSQLStatement))).
+					WithArgs("foo").
+					WillReturnRows(pgxmock.NewRows([]string{"name", "value"}))
+				pgpoolsMock["foo"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetExtensionsSQLStatement))).
+					WillReturnRows(
+						pgxmock.NewRows([]string{"name", "version", "schema", "available_upgrades"}).
+							AddRow("plpgsql", "1.0", "pg_catalog", []string{}).
+							AddRow("postgis", "3.3.2", "public", []string{"3.4.0", "3.4.2"}),
+					)
+				pgpoolsMock["foo"].ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`ALTER EXTENSION "postgis" UPDATE TO '3.4.0'`))).
+					WillReturnResult(pgxmock.NewResult("ALTER EXTENSION", 0))
+				pgpoolsMock["foo"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetExtensionsSQLStatement))).
+					WillReturnRows(
+						pgxmock.NewRows([]string{"name", "version", "schema", "available_upgrades"}).
+							AddRow("plpgsql", "1.0", "pg_catalog", []string{}).
+							AddRow("postgis", "3.4.0", "public", []string{"3.4.2"}),
+					)
+
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: typeNamespacedName,
+				})
+
+				Expect(err).NotTo(HaveOccurred())
+				for _, poolMock := range pgpoolsMock {
+					if err := poolMock.ExpectationsWereMet(); err != nil {
+						Fail(err.Error())
+					}
+				}
+				Expect(recorder.Events).To(Receive(Equal(`Normal DatabaseAdopted Database "foo" has been adopted`)))
+				Expect(recorder.Events).To(Receive(Equal(`Normal ExtensionUpdated Extension "postgis" of database "foo" has been updated from version "3.3.2" to "3.4.0"`)))
+
+				Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+				Expect(resource.Status.Extensions).To(Equal([]managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabaseExtensionStatus{
+					{Name: "plpgsql", Version: "1.0", Schema: "pg_catalog"},
+					{Name: "postgis", Version: "3.4.0", Schema: "public", AvailableUpgrades: []string{"3.4.2"}},
+				}))
+			})
+		})
+
+		When("the database is observed", func() {
+			It("should only report the database's extensions", func() {
+				resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase{}
+				Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+				resource.Spec.ManagementPolicy = managedpostgresoperatorhoppscalecomv1alpha1.ManagementPolicyObserve
+				resource.Spec.Extensions = []string{"postgis"}
+				Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+
+				controllerReconciler := &PostgresDatabaseReconciler{
+					Client:  k8sClient,
+					Scheme:  k8sClient.Scheme(),
+					PGPools: pgpools,
+				}
+
+				pgpoolsMock["default"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetDatabaseSQLStatement))).
+					WithArgs("foo").
+					WillReturnRows(
+						pgxmock.NewRows([]string{"datname", "owner", "datconnlimit", "datallowconn", "datistemplate", "tablespace"}).
+							AddRow("foo", "legacy_owner", int32(-1), true, false, "pg_default"),
+					)
+				pgpoolsMock["foo"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetExtensionsSQLStatement))).
+					WillReturnRows(
+						pgxmock.NewRows([]string{"name", "version", "schema", "available_upgrades"}).
+							AddRow("plpgsql", "1.0", "pg_catalog", []string{}).
+							AddRow("hstore", "1.8", "public", []string{}),
+					)
+
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: typeNamespacedName,
+				})
+
+				Expect(err).NotTo(HaveOccurred())
+				for _, poolMock := range pgpoolsMock {
+					if err := poolMock.ExpectationsWereMet(); err != nil {
+						Fail(err.Error())
+					}
+				}
+
+				Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+				Expect(resource.Status.Owned).To(BeFalse())
+				Expect(resource.Status.Extensions).To(Equal([]managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabaseExtensionStatus{
+					{Name: "plpgsql", Version: "1.0", Schema: "pg_catalog"},
+					{Name: "hstore", Version: "1.8", Schema: "public"},
+				}))
+			})
+
+			It("should not drop the database when the resource is deleted", func() {
+				resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase{}
+				Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+				resource.Spec.ManagementPolicy = managedpostgresoperatorhoppscalecomv1alpha1.ManagementPolicyObserve
+				controllerutil.AddFinalizer(resource, PostgresDatabaseFinalizer)
+				Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+				controllerReconciler := &PostgresDatabaseReconciler{
+					Client:  k8sClient,
+					Scheme:  k8sClient.Scheme(),
+					PGPools: pgpools,
+				}
+
+				pgpoolsMock["default"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetDatabaseSQLStatement))).
+					WithArgs("foo").
+					WillReturnRows(
+						pgxmock.NewRows([]string{"datname", "owner", "datconnlimit", "datallowconn", "datistemplate", "tablespace"}).
+							AddRow("foo", "legacy_owner", int32(-1), true, false, "pg_default"),
+					)
+
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: typeNamespacedName,
+				})
+
+				Expect(err).NotTo(HaveOccurred())
+				if err := pgpoolsMock["default"].ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+			})
+		})
+
+		When("the database already exists with the default CreateOnly management policy", func() {
+			It("should not adopt the database", func() {
+				resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase{}
+				Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+				resource.Spec.ManagementPolicy = ""
+				Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+				Expect(resource.Spec.ManagementPolicy).To(Equal(managedpostgresoperatorhoppscalecomv1alpha1.ManagementPolicyCreateOnly))
+
+				controllerReconciler := &PostgresDatabaseReconciler{
+					Client:  k8sClient,
+					Scheme:  k8sClient.Scheme(),
+					PGPools: pgpools,
+				}
+
+				pgpoolsMock["default"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetDatabaseSQLStatement))).
+					WithArgs("foo").
+					WillReturnRows(
+						pgxmock.NewRows([]string{"datname", "owner", "datconnlimit", "datallowconn", "datistemplate", "tablespace"}).
+							AddRow("foo", "legacy_owner", int32(-1), true, false, "pg_default"),
+					)
+
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: typeNamespacedName,
+				})
+
+				Expect(err).To(MatchError(`database "foo" already exists and the CreateOnly management policy doesn't adopt it, set the management policy to Adopt to take it over`))
+				if err := pgpoolsMock["default"].ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+
+				Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+				readyCondition := meta.FindStatusCondition(resource.Status.Conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeReady)
+				Expect(readyCondition).NotTo(BeNil())
+				Expect(readyCondition.Reason).To(Equal(ReasonObjectAlreadyExists))
+			})
+		})
+
+		When("the database has been created by an interrupted reconciliation with the CreateOnly management policy", func() {
+			It("should own the database", func() {
+				resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase{}
+				Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+				resource.Spec.ManagementPolicy = managedpostgresoperatorhoppscalecomv1alpha1.ManagementPolicyCreateOnly
+				Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+				resource.Status.Creating = true
+				Expect(k8sClient.Status().Update(ctx, resource)).To(Succeed())
+
+				recorder := events.NewFakeRecorder(10)
+				controllerReconciler := &PostgresDatabaseReconciler{
+					Client:   k8sClient,
+					Scheme:   k8sClient.Scheme(),
+					Recorder: recorder,
+					PGPools:  pgpools,
+				}
+
+				pgpoolsMock["default"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetDatabaseSQLStatement))).
+					WithArgs("foo").
+					WillReturnRows(
+						pgxmock.NewRows([]string{"datname", "owner", "datconnlimit", "datallowconn", "datistemplate", "tablespace"}).
+							AddRow("foo", "foo_owner", int32(-1), true, false, "pg_default"),
+					)
+				pgpoolsMock["default"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetDatabaseConfigSQLStatement))).
+					WithArgs("foo").
+					WillReturnRows(pgxmock.NewRows([]string{"name", "value"}))
+				pgpoolsMock["foo"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetExtensionsSQLStatement))).
+					WillReturnRows(
+						pgxmock.NewRows([]string{"name", "version", "schema", "available_upgrades"}).
+							AddRow("plpgsql", "1.0", "pg_catalog", []string{}),
+					)
+
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: typeNamespacedName,
+				})
+
+				Expect(err).NotTo(HaveOccurred())
+				for _, poolMock := range pgpoolsMock {
+					if err := poolMock.ExpectationsWereMet(); err != nil {
+						Fail(err.Error())
+					}
+				}
+				Expect(recorder.Events).NotTo(Receive())
+
+				Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+				Expect(resource.Status.Owned).To(BeTrue())
+				Expect(resource.Status.Creating).To(BeFalse())
+			})
+		})
+
+		When("the database has been reconciled by a previous version with the CreateOnly management policy", func() {
+			It("should own the database without adopting it", func() {
+				resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase{}
+				Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+				resource.Spec.ManagementPolicy = managedpostgresoperatorhoppscalecomv1alpha1.ManagementPolicyCreateOnly
+				Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+				resource.Status.Succeeded = true
+				Expect(k8sClient.Status().Update(ctx, resource)).To(Succeed())
+
+				recorder := events.NewFakeRecorder(10)
+				controllerReconciler := &PostgresDatabaseReconciler{
+					Client:   k8sClient,
+					Scheme:   k8sClient.Scheme(),
+					Recorder: recorder,
+					PGPools:  pgpools,
+				}
+
+				pgpoolsMock["default"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetDatabaseSQLStatement))).
+					WithArgs("foo").
+					WillReturnRows(
+						pgxmock.NewRows([]string{"datname", "owner", "datconnlimit", "datallowconn", "datistemplate", "tablespace"}).
+							AddRow("foo", "foo_owner", int32(-1), true, false, "pg_default"),
+					)
+				pgpoolsMock["default"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetDatabaseConfigSQLStatement))).
+					WithArgs("foo").
+					WillReturnRows(pgxmock.NewRows([]string{"name", "value"}))
+				pgpoolsMock["foo"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetExtensionsSQLStatement))).
+					WillReturnRows(
+						pgxmock.NewRows([]string{"name", "version", "schema", "available_upgrades"}).
+							AddRow("plpgsql", "1.0", "pg_catalog", []string{}),
+					)
+
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: typeNamespacedName,
+				})
+
+				Expect(err).NotTo(HaveOccurred())
+				for _, poolMock := range pgpoolsMock {
+					if err := poolMock.ExpectationsWereMet(); err != nil {
+						Fail(err.Error())
+					}
+				}
+				Expect(recorder.Events).NotTo(Receive())
+
+				Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+				Expect(resource.Status.Owned).To(BeTrue())
+			})
+		})
+
+		When("the owner is referenced by a PostgresRole which doesn't exist", func() {
+			It("should wait for the PostgresRole without querying the server", func() {
+				resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase{}
+				Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+				resource.Spec.Owner = ""
+				resource.Spec.OwnerRef = "test-resource-owner"
+				Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+
+				controllerReconciler := &PostgresDatabaseReconciler{
+					Client:  k8sClient,
+					Scheme:  k8sClient.Scheme(),
+					PGPools: pgpools,
+				}
+
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: typeNamespacedName,
+				})
+
+				Expect(err).NotTo(HaveOccurred())
+				if err := pgpoolsMock["default"].ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+
+				Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+				Expect(resource.Spec.Owner).To(BeEmpty())
+				readyCondition := meta.FindStatusCondition(resource.Status.Conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeReady)
+				Expect(readyCondition).NotTo(BeNil())
+				Expect(readyCondition.Reason).To(Equal(ReasonDependencyNotReady))
+				Expect(readyCondition.Message).To(Equal(`PostgresRole "test-resource-owner" doesn't exist`))
+
+				By("Creating the PostgresRole as ready, the database should be reconciled again")
+				role := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-resource-owner",
+						Namespace: "default",
+					},
+					Spec: managedpostgresoperatorhoppscalecomv1alpha1.PostgresRoleSpec{
+						Name: "new_owner",
+					},
+				}
+				Expect(k8sClient.Create(ctx, role)).To(Succeed())
+				defer func() {
+					Expect(k8sClient.Delete(ctx, role)).To(Succeed())
+				}()
+				Expect(controllerReconciler.requestsForRole(ctx, role)).To(BeEmpty())
+
+				setSucceededConditions(&role.Status.Conditions, role.Generation)
+				Expect(k8sClient.Status().Update(ctx, role)).To(Succeed())
+				Expect(controllerReconciler.requestsForRole(ctx, role)).To(ConsistOf(reconcile.Request{NamespacedName: typeNamespacedName}))
+			})
+		})
+
+		When("an extension is created by the operator", func() {
+			It("should record the extension in the status", func() {
+				resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase{}
+				Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+				resource.Spec.Extensions = []string{"plpgsql", "hstore"}
+				Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+
+				controllerReconciler := &PostgresDatabaseReconciler{
+					Client:  k8sClient,
+					Scheme:  k8sClient.Scheme(),
+					PGPools: pgpools,
+				}
+
+				pgpoolsMock["default"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetDatabaseSQLStatement))).
+					WithArgs("foo").
+					WillReturnRows(
+						pgxmock.NewRows([]string{"datname", "owner", "datconnlimit", "datallowconn", "datistemplate", "tablespace"}).
+							AddRow("foo", "foo_owner", int32(-1), true, false, "pg_default"),
+					)
+				pgpoolsMock["default"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetDatabaseConfigSQLStatement))).
+					WithArgs("foo").
+					WillReturnRows(pgxmock.NewRows([]string{"name", "value"}))
+				pgpoolsMock["foo"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetExtensionsSQLStatement))).
+					WillReturnRows(
+						pgxmock.NewRows([]string{"name", "version", "schema", "available_upgrades"}).
+							AddRow("plpgsql", "1.0", "pg_catalog", []string{}),
+					)
+				pgpoolsMock["foo"].ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`CREATE EXTENSION "hstore"`))).
+					WillReturnResult(pgxmock.NewResult("CREATE EXTENSION", 0))
+				pgpoolsMock["foo"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetExtensionsSQLStatement))).
+					WillReturnRows(
+						pgxmock.NewRows([]string{"name", "version", "schema", "available_upgrades"}).
+							AddRow("hstore", "1.8", "public", []string{}).
+							AddRow("plpgsql", "1.0", "pg_catalog", []string{}),
+					)
+
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: typeNamespacedName,
+				})
+
+				Expect(err).NotTo(HaveOccurred())
+				for _, poolMock := range pgpoolsMock {
+					if err := poolMock.ExpectationsWereMet(); err != nil {
+						Fail(err.Error())
+					}
+				}
+
+				Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+				Expect(resource.Status.ManagedExtensions).To(Equal([]string{"hstore"}))
+			})
+		})
+
+		When("another resource claims the same database", func() {
+			const otherResourceName = "test-resource-other"
+
+			otherTypeNamespacedName := types.NamespacedName{
+				Name:      otherResourceName,
+				Namespace: "default",
+			}
+
+			BeforeEach(func() {
+				otherResource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      otherResourceName,
+						Namespace: "default",
+					},
+					Spec: managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabaseSpec{
+						Name: "foo",
+					},
+				}
+				Expect(k8sClient.Create(ctx, otherResource)).To(Succeed())
+			})
+
+			AfterEach(func() {
+				otherResource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase{}
+				err := k8sClient.Get(ctx, otherTypeNamespacedName, otherResource)
+				if err != nil && errors.IsNotFound(err) {
+					return
+				}
+				Expect(err).NotTo(HaveOccurred())
+
+				controllerutil.RemoveFinalizer(otherResource, PostgresDatabaseFinalizer)
+				Expect(k8sClient.Update(ctx, otherResource)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, otherResource)).To(Succeed())
+			})
+
+			It("should mark the newest resource as in conflict without reconciling it", func() {
+				controllerReconciler := &PostgresDatabaseReconciler{
+					Client:  k8sClient,
+					Scheme:  k8sClient.Scheme(),
+					PGPools: pgpools,
+				}
+
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: otherTypeNamespacedName,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				otherResource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase{}
+				Expect(k8sClient.Get(ctx, otherTypeNamespacedName, otherResource)).To(Succeed())
+				Expect(otherResource.Finalizers).To(BeEmpty())
+				conflictCondition := meta.FindStatusCondition(otherResource.Status.Conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeConflict)
+				Expect(conflictCondition).NotTo(BeNil())
+				Expect(conflictCondition.Status).To(Equal(metav1.ConditionTrue))
+				Expect(conflictCondition.Message).To(Equal(`database "foo" is already managed by the resource "default/test-resource"`))
+
+				if err := pgpoolsMock["default"].ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+			})
+
+			It("should own the database managed by the oldest resource, so that it takes it over once it is deleted", func() {
+				resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase{}
+				Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+				resource.Status.Owned = true
+				Expect(k8sClient.Status().Update(ctx, resource)).To(Succeed())
+
+				controllerReconciler := &PostgresDatabaseReconciler{
+					Client:  k8sClient,
+					Scheme:  k8sClient.Scheme(),
+					PGPools: pgpools,
+				}
+
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: otherTypeNamespacedName,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				otherResource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase{}
+				Expect(k8sClient.Get(ctx, otherTypeNamespacedName, otherResource)).To(Succeed())
+				Expect(otherResource.Spec.ManagementPolicy).To(Equal(managedpostgresoperatorhoppscalecomv1alpha1.ManagementPolicyCreateOnly))
+				Expect(otherResource.Status.Owned).To(BeTrue())
+			})
+
+			It("should leave the database to the other resource when the managing resource is deleted", func() {
+				resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase{}
+				Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+				controllerutil.AddFinalizer(resource, PostgresDatabaseFinalizer)
+				Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+				controllerReconciler := &PostgresDatabaseReconciler{
+					Client:  k8sClient,
+					Scheme:  k8sClient.Scheme(),
+					PGPools: pgpools,
+				}
+
+				pgpoolsMock["default"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetDatabaseSQLStatement))).
+					WithArgs("foo").
+					WillReturnRows(
+						pgxmock.NewRows([]string{
+							"datname",
+							"owner",
+							"datconnlimit",
+							"datallowconn",
+							"datistemplate",
+							"tablespace",
+						}).
+							AddRow(
+								"foo",
+								"foo_owner",
+								int32(-1),
+								true,
+								false,
+								"pg_default",
 							),
 					)
 
 				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
 					NamespacedName: typeNamespacedName,
 				})
-
 				Expect(err).NotTo(HaveOccurred())
-				for _, poolMock := range pgpoolsMock {
-					if err := poolMock.ExpectationsWereMet(); err != nil {
-						Fail(err.Error())
-					}
+
+				err = k8sClient.Get(ctx, typeNamespacedName, &managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+
+				if err := pgpoolsMock["default"].ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
 				}
 			})
 		})
@@ -232,21 +894,24 @@ var _ = Describe("PostgresDatabase Controller", func() {
 						pgxmock.NewRows([]string{
 							"datname",
 							"owner",
+							"datconnlimit",
+							"datallowconn",
+							"datistemplate",
+							"tablespace",
 						}).
 							AddRow(
 								"foo",
 								"foo_owner",
+								int32(-1),
+								true,
+								false,
+								"pg_default",
 							),
 					)
 
-				pgpoolsMock["default"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(`SELECT pg_terminate_backend(pid) FROM pg_stat_activity WHERE datname = $1`))).
+				pgpoolsMock["default"].ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`SELECT pg_terminate_backend(pid) FROM pg_stat_activity WHERE datname = $1`))).
 					WithArgs("foo").
-					WillReturnRows(
-						pgxmock.NewRows([]string{
-							"pg_terminate_backend",
-						}).
-							AddRow(""),
-					)
+					WillReturnResult(pgxmock.NewResult("SELECT", 1))
 				pgpoolsMock["default"].ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`DROP DATABASE "foo"`))).
 					WillReturnResult(pgxmock.NewResult("", 1))
 
@@ -264,20 +929,25 @@ var _ = Describe("PostgresDatabase Controller", func() {
 		When("reconciling on creation", func() {
 			It("should not create database if already exists", func() {
 				existingDatabase := &postgresql.Database{
-					Name:  "foo",
-					Owner: "foo_owner",
+					Name:             "foo",
+					Owner:            "foo_owner",
+					ConnectionLimit:  -1,
+					AllowConnections: true,
 				}
 				desiredDatabase := &postgresql.Database{
-					Name:  "foo",
-					Owner: "foo_owner",
+					Name:             "foo",
+					Owner:            "foo_owner",
+					ConnectionLimit:  -1,
+					AllowConnections: true,
 				}
 				controllerReconciler := &PostgresDatabaseReconciler{
 					Client:  k8sClient,
 					Scheme:  k8sClient.Scheme(),
 					PGPools: pgpools,
 				}
+				reconciliation := &postgresDatabaseReconciliation{PostgresDatabaseReconciler: controllerReconciler}
 
-				err := controllerReconciler.reconcileOnCreation(existingDatabase, desiredDatabase)
+				err := reconciliation.reconcileOnCreation(pgpools, existingDatabase, desiredDatabase)
 				Expect(err).NotTo(HaveOccurred())
 				for _, poolMock := range pgpoolsMock {
 					if err := poolMock.ExpectationsWereMet(); err != nil {
@@ -289,18 +959,21 @@ var _ = Describe("PostgresDatabase Controller", func() {
 			It("should create database if not exists", func() {
 				var existingDatabase *postgresql.Database
 				desiredDatabase := &postgresql.Database{
-					Name: "foo",
+					Name:             "foo",
+					ConnectionLimit:  -1,
+					AllowConnections: true,
 				}
 				controllerReconciler := &PostgresDatabaseReconciler{
 					Client:  k8sClient,
 					Scheme:  k8sClient.Scheme(),
 					PGPools: pgpools,
 				}
+				reconciliation := &postgresDatabaseReconciliation{PostgresDatabaseReconciler: controllerReconciler}
 
 				pgpoolsMock["default"].ExpectExec(`CREATE DATABASE "foo"`).
 					WillReturnResult(pgxmock.NewResult("", 1))
 
-				err := controllerReconciler.reconcileOnCreation(existingDatabase, desiredDatabase)
+				err := reconciliation.reconcileOnCreation(pgpools, existingDatabase, desiredDatabase)
 				Expect(err).NotTo(HaveOccurred())
 				for _, poolMock := range pgpoolsMock {
 					if err := poolMock.ExpectationsWereMet(); err != nil {
@@ -312,18 +985,21 @@ var _ = Describe("PostgresDatabase Controller", func() {
 			It("should return an error if database creation failed", func() {
 				var existingDatabase *postgresql.Database
 				desiredDatabase := &postgresql.Database{
-					Name: "foo",
+					Name:             "foo",
+					ConnectionLimit:  -1,
+					AllowConnections: true,
 				}
 				controllerReconciler := &PostgresDatabaseReconciler{
 					Client:  k8sClient,
 					Scheme:  k8sClient.Scheme(),
 					PGPools: pgpools,
 				}
+				reconciliation := &postgresDatabaseReconciliation{PostgresDatabaseReconciler: controllerReconciler}
 
 				pgpoolsMock["default"].ExpectExec(`CREATE DATABASE "foo"`).
 					WillReturnError(fmt.Errorf("fake error from PostgreSQL"))
 
-				err := controllerReconciler.reconcileOnCreation(existingDatabase, desiredDatabase)
+				err := reconciliation.reconcileOnCreation(pgpools, existingDatabase, desiredDatabase)
 				Expect(err).To(HaveOccurred())
 				for _, poolMock := range pgpoolsMock {
 					if err := poolMock.ExpectationsWereMet(); err != nil {
@@ -334,23 +1010,28 @@ var _ = Describe("PostgresDatabase Controller", func() {
 
 			It("should alter database owner if it has changed", func() {
 				existingDatabase := &postgresql.Database{
-					Name:  "foo",
-					Owner: "foo",
+					Name:             "foo",
+					Owner:            "foo",
+					ConnectionLimit:  -1,
+					AllowConnections: true,
 				}
 				desiredDatabase := &postgresql.Database{
-					Name:  "foo",
-					Owner: "foo_owner",
+					Name:             "foo",
+					Owner:            "foo_owner",
+					ConnectionLimit:  -1,
+					AllowConnections: true,
 				}
 				controllerReconciler := &PostgresDatabaseReconciler{
 					Client:  k8sClient,
 					Scheme:  k8sClient.Scheme(),
 					PGPools: pgpools,
 				}
+				reconciliation := &postgresDatabaseReconciliation{PostgresDatabaseReconciler: controllerReconciler}
 
 				pgpoolsMock["default"].ExpectExec(`ALTER DATABASE "foo" OWNER TO "foo_owner"`).
 					WillReturnResult(pgxmock.NewResult("", 1))
 
-				err := controllerReconciler.reconcileOnCreation(existingDatabase, desiredDatabase)
+				err := reconciliation.reconcileOnCreation(pgpools, existingDatabase, desiredDatabase)
 				Expect(err).NotTo(HaveOccurred())
 				for _, poolMock := range pgpoolsMock {
 					if err := poolMock.ExpectationsWereMet(); err != nil {
@@ -361,23 +1042,28 @@ var _ = Describe("PostgresDatabase Controller", func() {
 
 			It("should return an error if the alter database owner has failed", func() {
 				existingDatabase := &postgresql.Database{
-					Name:  "foo",
-					Owner: "foo",
+					Name:             "foo",
+					Owner:            "foo",
+					ConnectionLimit:  -1,
+					AllowConnections: true,
 				}
 				desiredDatabase := &postgresql.Database{
-					Name:  "foo",
-					Owner: "foo_owner",
+					Name:             "foo",
+					Owner:            "foo_owner",
+					ConnectionLimit:  -1,
+					AllowConnections: true,
 				}
 				controllerReconciler := &PostgresDatabaseReconciler{
 					Client:  k8sClient,
 					Scheme:  k8sClient.Scheme(),
 					PGPools: pgpools,
 				}
+				reconciliation := &postgresDatabaseReconciliation{PostgresDatabaseReconciler: controllerReconciler}
 
 				pgpoolsMock["default"].ExpectExec(`ALTER DATABASE "foo" OWNER TO "foo_owner"`).
 					WillReturnError(fmt.Errorf("fake error from PostgreSQL"))
 
-				err := controllerReconciler.reconcileOnCreation(existingDatabase, desiredDatabase)
+				err := reconciliation.reconcileOnCreation(pgpools, existingDatabase, desiredDatabase)
 				Expect(err).To(HaveOccurred())
 				for _, poolMock := range pgpoolsMock {
 					if err := poolMock.ExpectationsWereMet(); err != nil {
@@ -385,6 +1071,54 @@ var _ = Describe("PostgresDatabase Controller", func() {
 					}
 				}
 			})
+
+			It("should alter the database's options and tablespace if they have changed", func() {
+				existingDatabase := &postgresql.Database{
+					Name:             "foo",
+					Owner:            "foo_owner",
+					ConnectionLimit:  -1,
+					AllowConnections: true,
+					Tablespace:       "pg_default",
+				}
+				desiredDatabase := &postgresql.Database{
+					Name:             "foo",
+					Owner:            "foo_owner",
+					ConnectionLimit:  10,
+					AllowConnections: true,
+					IsTemplate:       true,
+					Tablespace:       "fast",
+				}
+
+				databasePoolMock, err := pgxmock.NewPool()
+				if err != nil {
+					Fail(err.Error())
+				}
+				databasePoolMock.ExpectClose()
+				pgpools.Databases["foo"] = databasePoolMock
+
+				controllerReconciler := &PostgresDatabaseReconciler{
+					Client:  k8sClient,
+					Scheme:  k8sClient.Scheme(),
+					PGPools: pgpools,
+				}
+				reconciliation := &postgresDatabaseReconciliation{PostgresDatabaseReconciler: controllerReconciler}
+
+				pgpoolsMock["default"].ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`ALTER DATABASE "foo" WITH CONNECTION LIMIT 10 IS_TEMPLATE true`))).
+					WillReturnResult(pgxmock.NewResult("ALTER DATABASE", 0))
+				pgpoolsMock["default"].ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`ALTER DATABASE "foo" SET TABLESPACE "fast"`))).
+					WillReturnResult(pgxmock.NewResult("ALTER DATABASE", 0))
+
+				err = reconciliation.reconcileOnCreation(pgpools, existingDatabase, desiredDatabase)
+				Expect(err).NotTo(HaveOccurred())
+				for _, poolMock := range pgpoolsMock {
+					if err := poolMock.ExpectationsWereMet(); err != nil {
+						Fail(err.Error())
+					}
+				}
+				// The operator's sessions to the database are closed before moving it
+				Expect(databasePoolMock.ExpectationsWereMet()).To(Succeed())
+				Expect(pgpools.Databases).NotTo(HaveKey("foo"))
+			})
 		})
 
 		When("reconciling on deletion", func() {
@@ -396,8 +1130,9 @@ var _ = Describe("PostgresDatabase Controller", func() {
 					Scheme:  k8sClient.Scheme(),
 					PGPools: pgpools,
 				}
+				reconciliation := &postgresDatabaseReconciliation{PostgresDatabaseReconciler: controllerReconciler}
 
-				err := controllerReconciler.reconcileOnDeletion(resource, existingDatabase)
+				err := reconciliation.reconcileOnDeletion(pgpools, resource, existingDatabase)
 				Expect(err).NotTo(HaveOccurred())
 				for _, poolMock := range pgpoolsMock {
 					if err := poolMock.ExpectationsWereMet(); err != nil {
@@ -408,7 +1143,9 @@ var _ = Describe("PostgresDatabase Controller", func() {
 
 			It("should return immediately if the option `keepOnDelete` is set", func() {
 				existingDatabase := &postgresql.Database{
-					Name: "foo",
+					Name:             "foo",
+					ConnectionLimit:  -1,
+					AllowConnections: true,
 				}
 				resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase{
 					Spec: managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabaseSpec{
@@ -420,8 +1157,42 @@ var _ = Describe("PostgresDatabase Controller", func() {
 					Scheme:  k8sClient.Scheme(),
 					PGPools: pgpools,
 				}
+				reconciliation := &postgresDatabaseReconciliation{PostgresDatabaseReconciler: controllerReconciler}
+
+				err := reconciliation.reconcileOnDeletion(pgpools, resource, existingDatabase)
+				Expect(err).NotTo(HaveOccurred())
+				for _, poolMock := range pgpoolsMock {
+					if err := poolMock.ExpectationsWereMet(); err != nil {
+						Fail(err.Error())
+					}
+				}
+			})
+
+			It("should unmark a template database before dropping it", func() {
+				existingDatabase := &postgresql.Database{
+					Name:             "foo",
+					ConnectionLimit:  -1,
+					AllowConnections: true,
+					IsTemplate:       true,
+				}
+				resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase{
+					Spec: managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabaseSpec{
+						PreserveConnectionsOnDelete: true,
+					},
+				}
+				controllerReconciler := &PostgresDatabaseReconciler{
+					Client:  k8sClient,
+					Scheme:  k8sClient.Scheme(),
+					PGPools: pgpools,
+				}
+				reconciliation := &postgresDatabaseReconciliation{PostgresDatabaseReconciler: controllerReconciler}
+
+				pgpoolsMock["default"].ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`ALTER DATABASE "foo" WITH IS_TEMPLATE false`))).
+					WillReturnResult(pgxmock.NewResult("ALTER DATABASE", 0))
+				pgpoolsMock["default"].ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`DROP DATABASE "foo"`))).
+					WillReturnResult(pgxmock.NewResult("DROP DATABASE", 0))
 
-				err := controllerReconciler.reconcileOnDeletion(resource, existingDatabase)
+				err := reconciliation.reconcileOnDeletion(pgpools, resource, existingDatabase)
 				Expect(err).NotTo(HaveOccurred())
 				for _, poolMock := range pgpoolsMock {
 					if err := poolMock.ExpectationsWereMet(); err != nil {
@@ -432,7 +1203,9 @@ var _ = Describe("PostgresDatabase Controller", func() {
 
 			It("should drop database successfully", func() {
 				existingDatabase := &postgresql.Database{
-					Name: "foo",
+					Name:             "foo",
+					ConnectionLimit:  -1,
+					AllowConnections: true,
 				}
 				resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase{}
 				controllerReconciler := &PostgresDatabaseReconciler{
@@ -440,20 +1213,16 @@ var _ = Describe("PostgresDatabase Controller", func() {
 					Scheme:  k8sClient.Scheme(),
 					PGPools: pgpools,
 				}
+				reconciliation := &postgresDatabaseReconciliation{PostgresDatabaseReconciler: controllerReconciler}
 
-				pgpoolsMock["default"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(`SELECT pg_terminate_backend(pid) FROM pg_stat_activity WHERE datname = $1`))).
+				pgpoolsMock["default"].ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`SELECT pg_terminate_backend(pid) FROM pg_stat_activity WHERE datname = $1`))).
 					WithArgs("foo").
-					WillReturnRows(
-						pgxmock.NewRows([]string{
-							"pg_terminate_backend",
-						}).
-							AddRow(""),
-					)
+					WillReturnResult(pgxmock.NewResult("SELECT", 1))
 
 				pgpoolsMock["default"].ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`DROP DATABASE "foo"`))).
 					WillReturnResult(pgxmock.NewResult("", 1))
 
-				err := controllerReconciler.reconcileOnDeletion(resource, existingDatabase)
+				err := reconciliation.reconcileOnDeletion(pgpools, resource, existingDatabase)
 				Expect(err).NotTo(HaveOccurred())
 				for _, poolMock := range pgpoolsMock {
 					if err := poolMock.ExpectationsWereMet(); err != nil {
@@ -464,7 +1233,9 @@ var _ = Describe("PostgresDatabase Controller", func() {
 
 			It("should return an error if dropping database failed", func() {
 				existingDatabase := &postgresql.Database{
-					Name: "foo",
+					Name:             "foo",
+					ConnectionLimit:  -1,
+					AllowConnections: true,
 				}
 				resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase{}
 
@@ -473,20 +1244,16 @@ var _ = Describe("PostgresDatabase Controller", func() {
 					Scheme:  k8sClient.Scheme(),
 					PGPools: pgpools,
 				}
+				reconciliation := &postgresDatabaseReconciliation{PostgresDatabaseReconciler: controllerReconciler}
 
-				pgpoolsMock["default"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(`SELECT pg_terminate_backend(pid) FROM pg_stat_activity WHERE datname = $1`))).
+				pgpoolsMock["default"].ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`SELECT pg_terminate_backend(pid) FROM pg_stat_activity WHERE datname = $1`))).
 					WithArgs("foo").
-					WillReturnRows(
-						pgxmock.NewRows([]string{
-							"pg_terminate_backend",
-						}).
-							AddRow(""),
-					)
+					WillReturnResult(pgxmock.NewResult("SELECT", 1))
 
 				pgpoolsMock["default"].ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`DROP DATABASE "foo"`))).
 					WillReturnError(fmt.Errorf("fake error from PostgreSQL"))
 
-				err := controllerReconciler.reconcileOnDeletion(resource, existingDatabase)
+				err := reconciliation.reconcileOnDeletion(pgpools, resource, existingDatabase)
 				Expect(err).To(HaveOccurred())
 				for _, poolMock := range pgpoolsMock {
 					if err := poolMock.ExpectationsWereMet(); err != nil {
@@ -497,7 +1264,9 @@ var _ = Describe("PostgresDatabase Controller", func() {
 
 			It("should return an error if dropping connections failed", func() {
 				existingDatabase := &postgresql.Database{
-					Name: "foo",
+					Name:             "foo",
+					ConnectionLimit:  -1,
+					AllowConnections: true,
 				}
 				resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase{}
 
@@ -506,12 +1275,13 @@ var _ = Describe("PostgresDatabase Controller", func() {
 					Scheme:  k8sClient.Scheme(),
 					PGPools: pgpools,
 				}
+				reconciliation := &postgresDatabaseReconciliation{PostgresDatabaseReconciler: controllerReconciler}
 
-				pgpoolsMock["default"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(`SELECT pg_terminate_backend(pid) FROM pg_stat_activity WHERE datname = $1`))).
+				pgpoolsMock["default"].ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`SELECT pg_terminate_backend(pid) FROM pg_stat_activity WHERE datname = $1`))).
 					WithArgs("foo").
 					WillReturnError(fmt.Errorf("fake error from PostgreSQL"))
 
-				err := controllerReconciler.reconcileOnDeletion(resource, existingDatabase)
+				err := reconciliation.reconcileOnDeletion(pgpools, resource, existingDatabase)
 				Expect(err).To(HaveOccurred())
 				for _, poolMock := range pgpoolsMock {
 					if err := poolMock.ExpectationsWereMet(); err != nil {
@@ -522,7 +1292,9 @@ var _ = Describe("PostgresDatabase Controller", func() {
 
 			It("should not drop connections if option PreserveConnectionsOnDelete is set", func() {
 				existingDatabase := &postgresql.Database{
-					Name: "foo",
+					Name:             "foo",
+					ConnectionLimit:  -1,
+					AllowConnections: true,
 				}
 				resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase{
 					Spec: managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabaseSpec{
@@ -534,11 +1306,12 @@ var _ = Describe("PostgresDatabase Controller", func() {
 					Scheme:  k8sClient.Scheme(),
 					PGPools: pgpools,
 				}
+				reconciliation := &postgresDatabaseReconciliation{PostgresDatabaseReconciler: controllerReconciler}
 
 				pgpoolsMock["default"].ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`DROP DATABASE "foo"`))).
 					WillReturnResult(pgxmock.NewResult("", 1))
 
-				err := controllerReconciler.reconcileOnDeletion(resource, existingDatabase)
+				err := reconciliation.reconcileOnDeletion(pgpools, resource, existingDatabase)
 				Expect(err).NotTo(HaveOccurred())
 				for _, poolMock := range pgpoolsMock {
 					if err := poolMock.ExpectationsWereMet(); err != nil {
@@ -551,11 +1324,13 @@ var _ = Describe("PostgresDatabase Controller", func() {
 		When("reconciling extensions", func() {
 			It("should create the missing extensions", func() {
 				desiredDatabase := &postgresql.Database{
-					Name:  "foo",
-					Owner: "foo_owner",
-					Extensions: []string{
-						"plpgsql",
-						"postgis",
+					Name:             "foo",
+					Owner:            "foo_owner",
+					ConnectionLimit:  -1,
+					AllowConnections: true,
+					Extensions: []postgresql.Extension{
+						{Name: "plpgsql"},
+						{Name: "postgis"},
 					},
 				}
 				controllerReconciler := &PostgresDatabaseReconciler{
@@ -563,21 +1338,51 @@ var _ = Describe("PostgresDatabase Controller", func() {
 					Scheme:  k8sClient.Scheme(),
 					PGPools: pgpools,
 				}
+				reconciliation := &postgresDatabaseReconciliation{PostgresDatabaseReconciler: controllerReconciler}
 
-				pgpoolsMock["foo"].ExpectQuery(`SELECT extname FROM pg_extension`).
+				pgpoolsMock["foo"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetExtensionsSQLStatement))).
 					WillReturnRows(
 						pgxmock.NewRows([]string{
-							"extname",
+							"name",
+							"version",
+							"schema",
+							"available_upgrades",
 						}).
 							AddRow(
 								"plpgsql",
+								"1.0",
+								"pg_catalog",
+								[]string{},
 							),
 					)
 				pgpoolsMock["foo"].ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`CREATE EXTENSION "postgis"`))).
 					WillReturnResult(pgxmock.NewResult("", 1))
+				pgpoolsMock["foo"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetExtensionsSQLStatement))).
+					WillReturnRows(
+						pgxmock.NewRows([]string{
+							"name",
+							"version",
+							"schema",
+							"available_upgrades",
+						}).
+							AddRow(
+								"plpgsql",
+								"1.0",
+								"pg_catalog",
+								[]string{},
+							).
+							AddRow(
+								"postgis",
+								"3.4.2",
+								"public",
+								[]string{},
+							),
+					)
 
-				err := controllerReconciler.reconcileExtensions(desiredDatabase)
+				installedExtensions, managedExtensions, err := reconciliation.reconcileExtensions(pgpools, desiredDatabase, "", nil, false)
 				Expect(err).NotTo(HaveOccurred())
+				Expect(installedExtensions).To(HaveLen(2))
+				Expect(managedExtensions).To(Equal([]string{"postgis"}))
 				for _, poolMock := range pgpoolsMock {
 					if err := poolMock.ExpectationsWereMet(); err != nil {
 						Fail(err.Error())
@@ -585,12 +1390,14 @@ var _ = Describe("PostgresDatabase Controller", func() {
 				}
 			})
 
-			It("should drop the extensions that are not defined", func() {
+			It("should drop the extensions created by the operator that are not defined anymore", func() {
 				desiredDatabase := &postgresql.Database{
-					Name:  "foo",
-					Owner: "foo_owner",
-					Extensions: []string{
-						"plpgsql",
+					Name:             "foo",
+					Owner:            "foo_owner",
+					ConnectionLimit:  -1,
+					AllowConnections: true,
+					Extensions: []postgresql.Extension{
+						{Name: "plpgsql"},
 					},
 				}
 				controllerReconciler := &PostgresDatabaseReconciler{
@@ -598,37 +1405,386 @@ var _ = Describe("PostgresDatabase Controller", func() {
 					Scheme:  k8sClient.Scheme(),
 					PGPools: pgpools,
 				}
+				reconciliation := &postgresDatabaseReconciliation{PostgresDatabaseReconciler: controllerReconciler}
 
-				pgpoolsMock["foo"].ExpectQuery(`SELECT extname FROM pg_extension`).
+				pgpoolsMock["foo"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetExtensionsSQLStatement))).
 					WillReturnRows(
 						pgxmock.NewRows([]string{
-							"extname",
+							"name",
+							"version",
+							"schema",
+							"available_upgrades",
 						}).
 							AddRow(
 								"plpgsql",
+								"1.0",
+								"pg_catalog",
+								[]string{},
 							).
 							AddRow(
 								"postgis",
+								"3.4.2",
+								"public",
+								[]string{},
+							),
+					)
+				pgpoolsMock["foo"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetExtensionDependenciesSQLStatement))).
+					WillReturnRows(pgxmock.NewRows([]string{"extension", "required"}))
+				pgpoolsMock["foo"].ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`DROP EXTENSION "postgis"`))).
+					WillReturnResult(pgxmock.NewResult("", 1))
+				pgpoolsMock["foo"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetExtensionsSQLStatement))).
+					WillReturnRows(
+						pgxmock.NewRows([]string{
+							"name",
+							"version",
+							"schema",
+							"available_upgrades",
+						}).
+							AddRow(
+								"plpgsql",
+								"1.0",
+								"pg_catalog",
+								[]string{},
 							),
 					)
+
+				_, managedExtensions, err := reconciliation.reconcileExtensions(pgpools, desiredDatabase, managedpostgresoperatorhoppscalecomv1alpha1.ExtensionPolicyAdditive, []string{"postgis"}, false)
+				Expect(err).NotTo(HaveOccurred())
+				for _, poolMock := range pgpoolsMock {
+					if err := poolMock.ExpectationsWereMet(); err != nil {
+						Fail(err.Error())
+					}
+				}
+				Expect(managedExtensions).To(BeEmpty())
+			})
+
+			It("should not drop the extensions not created by the operator with the Additive policy", func() {
+				desiredDatabase := &postgresql.Database{
+					Name:             "foo",
+					Owner:            "foo_owner",
+					ConnectionLimit:  -1,
+					AllowConnections: true,
+					Extensions:       []postgresql.Extension{},
+				}
+				controllerReconciler := &PostgresDatabaseReconciler{
+					Client:  k8sClient,
+					Scheme:  k8sClient.Scheme(),
+					PGPools: pgpools,
+				}
+				reconciliation := &postgresDatabaseReconciliation{PostgresDatabaseReconciler: controllerReconciler}
+
+				pgpoolsMock["foo"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetExtensionsSQLStatement))).
+					WillReturnRows(
+						pgxmock.NewRows([]string{"name", "version", "schema", "available_upgrades"}).
+							AddRow("plpgsql", "1.0", "pg_catalog", []string{}).
+							AddRow("postgis", "3.4.2", "public", []string{}),
+					)
+
+				installedExtensions, managedExtensions, err := reconciliation.reconcileExtensions(pgpools, desiredDatabase, managedpostgresoperatorhoppscalecomv1alpha1.ExtensionPolicyAdditive, []string{"hstore"}, false)
+				Expect(err).NotTo(HaveOccurred())
+				for _, poolMock := range pgpoolsMock {
+					if err := poolMock.ExpectationsWereMet(); err != nil {
+						Fail(err.Error())
+					}
+				}
+				Expect(installedExtensions).To(HaveLen(2))
+				Expect(managedExtensions).To(BeEmpty())
+			})
+
+			It("should drop every extension not defined except the built-in ones with the Exclusive policy", func() {
+				desiredDatabase := &postgresql.Database{
+					Name:             "foo",
+					Owner:            "foo_owner",
+					ConnectionLimit:  -1,
+					AllowConnections: true,
+					Extensions:       []postgresql.Extension{},
+				}
+				controllerReconciler := &PostgresDatabaseReconciler{
+					Client:  k8sClient,
+					Scheme:  k8sClient.Scheme(),
+					PGPools: pgpools,
+				}
+				reconciliation := &postgresDatabaseReconciliation{PostgresDatabaseReconciler: controllerReconciler}
+
+				pgpoolsMock["foo"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetExtensionsSQLStatement))).
+					WillReturnRows(
+						pgxmock.NewRows([]string{"name", "version", "schema", "available_upgrades"}).
+							AddRow("plpgsql", "1.0", "pg_catalog", []string{}).
+							AddRow("postgis", "3.4.2", "public", []string{}),
+					)
+				pgpoolsMock["foo"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetExtensionDependenciesSQLStatement))).
+					WillReturnRows(pgxmock.NewRows([]string{"extension", "required"}))
 				pgpoolsMock["foo"].ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`DROP EXTENSION "postgis"`))).
 					WillReturnResult(pgxmock.NewResult("", 1))
+				pgpoolsMock["foo"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetExtensionsSQLStatement))).
+					WillReturnRows(
+						pgxmock.NewRows([]string{"name", "version", "schema", "available_upgrades"}).
+							AddRow("plpgsql", "1.0", "pg_catalog", []string{}),
+					)
+
+				_, _, err := reconciliation.reconcileExtensions(pgpools, desiredDatabase, managedpostgresoperatorhoppscalecomv1alpha1.ExtensionPolicyExclusive, nil, false)
+				Expect(err).NotTo(HaveOccurred())
+				for _, poolMock := range pgpoolsMock {
+					if err := poolMock.ExpectationsWereMet(); err != nil {
+						Fail(err.Error())
+					}
+				}
+			})
+
+			It("should not drop the extensions required by a declared one with the Exclusive policy", func() {
+				desiredDatabase := &postgresql.Database{
+					Name:             "foo",
+					Owner:            "foo_owner",
+					ConnectionLimit:  -1,
+					AllowConnections: true,
+					Extensions: []postgresql.Extension{
+						{Name: "earthdistance", Cascade: true},
+					},
+				}
+				controllerReconciler := &PostgresDatabaseReconciler{
+					Client:  k8sClient,
+					Scheme:  k8sClient.Scheme(),
+					PGPools: pgpools,
+				}
+				reconciliation := &postgresDatabaseReconciliation{PostgresDatabaseReconciler: controllerReconciler}
+
+				// cube has been installed along with earthdistance, by CREATE EXTENSION ... CASCADE
+				pgpoolsMock["foo"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetExtensionsSQLStatement))).
+					WillReturnRows(
+						pgxmock.NewRows([]string{"name", "version", "schema", "available_upgrades"}).
+							AddRow("cube", "1.5", "public", []string{}).
+							AddRow("earthdistance", "1.2", "public", []string{}).
+							AddRow("plpgsql", "1.0", "pg_catalog", []string{}),
+					)
+				pgpoolsMock["foo"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetExtensionDependenciesSQLStatement))).
+					WillReturnRows(
+						pgxmock.NewRows([]string{"extension", "required"}).
+							AddRow("earthdistance", "cube"),
+					)
+
+				installedExtensions, _, err := reconciliation.reconcileExtensions(pgpools, desiredDatabase, managedpostgresoperatorhoppscalecomv1alpha1.ExtensionPolicyExclusive, nil, false)
+				Expect(err).NotTo(HaveOccurred())
+				for _, poolMock := range pgpoolsMock {
+					if err := poolMock.ExpectationsWereMet(); err != nil {
+						Fail(err.Error())
+					}
+				}
+				Expect(installedExtensions).To(HaveLen(3))
+			})
+
+			It("should drop the extensions before the ones they require", func() {
+				desiredDatabase := &postgresql.Database{
+					Name:             "foo",
+					Owner:            "foo_owner",
+					ConnectionLimit:  -1,
+					AllowConnections: true,
+					Extensions:       []postgresql.Extension{},
+				}
+				controllerReconciler := &PostgresDatabaseReconciler{
+					Client:  k8sClient,
+					Scheme:  k8sClient.Scheme(),
+					PGPools: pgpools,
+				}
+				reconciliation := &postgresDatabaseReconciliation{PostgresDatabaseReconciler: controllerReconciler}
+
+				pgpoolsMock["foo"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetExtensionsSQLStatement))).
+					WillReturnRows(
+						pgxmock.NewRows([]string{"name", "version", "schema", "available_upgrades"}).
+							AddRow("cube", "1.5", "public", []string{}).
+							AddRow("earthdistance", "1.2", "public", []string{}).
+							AddRow("plpgsql", "1.0", "pg_catalog", []string{}),
+					)
+				pgpoolsMock["foo"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetExtensionDependenciesSQLStatement))).
+					WillReturnRows(
+						pgxmock.NewRows([]string{"extension", "required"}).
+							AddRow("earthdistance", "cube"),
+					)
+				pgpoolsMock["foo"].ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`DROP EXTENSION "earthdistance"`))).
+					WillReturnResult(pgxmock.NewResult("", 1))
+				pgpoolsMock["foo"].ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`DROP EXTENSION "cube"`))).
+					WillReturnResult(pgxmock.NewResult("", 1))
+				pgpoolsMock["foo"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetExtensionsSQLStatement))).
+					WillReturnRows(
+						pgxmock.NewRows([]string{"name", "version", "schema", "available_upgrades"}).
+							AddRow("plpgsql", "1.0", "pg_catalog", []string{}),
+					)
+
+				_, managedExtensions, err := reconciliation.reconcileExtensions(pgpools, desiredDatabase, managedpostgresoperatorhoppscalecomv1alpha1.ExtensionPolicyAdditive, []string{"cube", "earthdistance"}, false)
+				Expect(err).NotTo(HaveOccurred())
+				for _, poolMock := range pgpoolsMock {
+					if err := poolMock.ExpectationsWereMet(); err != nil {
+						Fail(err.Error())
+					}
+				}
+				Expect(managedExtensions).To(BeEmpty())
+			})
+
+			It("should consider the declared extensions already installed as created by a previous version of the operator", func() {
+				desiredDatabase := &postgresql.Database{
+					Name:             "foo",
+					Owner:            "foo_owner",
+					ConnectionLimit:  -1,
+					AllowConnections: true,
+					Extensions: []postgresql.Extension{
+						{Name: "hstore"},
+					},
+				}
+				controllerReconciler := &PostgresDatabaseReconciler{
+					Client:  k8sClient,
+					Scheme:  k8sClient.Scheme(),
+					PGPools: pgpools,
+				}
+				reconciliation := &postgresDatabaseReconciliation{PostgresDatabaseReconciler: controllerReconciler}
+
+				pgpoolsMock["foo"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetExtensionsSQLStatement))).
+					WillReturnRows(
+						pgxmock.NewRows([]string{"name", "version", "schema", "available_upgrades"}).
+							AddRow("hstore", "1.8", "public", []string{}).
+							AddRow("plpgsql", "1.0", "pg_catalog", []string{}).
+							AddRow("postgis", "3.4.2", "public", []string{}),
+					)
+
+				_, managedExtensions, err := reconciliation.reconcileExtensions(pgpools, desiredDatabase, managedpostgresoperatorhoppscalecomv1alpha1.ExtensionPolicyAdditive, nil, true)
+				Expect(err).NotTo(HaveOccurred())
+				for _, poolMock := range pgpoolsMock {
+					if err := poolMock.ExpectationsWereMet(); err != nil {
+						Fail(err.Error())
+					}
+				}
+				Expect(managedExtensions).To(Equal([]string{"hstore"}))
+			})
+
+			It("should update the extensions and move them to the declared schema", func() {
+				desiredDatabase := &postgresql.Database{
+					Name:             "foo",
+					Owner:            "foo_owner",
+					ConnectionLimit:  -1,
+					AllowConnections: true,
+					Extensions: []postgresql.Extension{
+						{Name: "hstore", Schema: "extensions"},
+						{Name: "postgis", Version: "3.4.2"},
+					},
+				}
+				controllerReconciler := &PostgresDatabaseReconciler{
+					Client:  k8sClient,
+					Scheme:  k8sClient.Scheme(),
+					PGPools: pgpools,
+				}
+				reconciliation := &postgresDatabaseReconciliation{PostgresDatabaseReconciler: controllerReconciler}
+
+				pgpoolsMock["foo"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetExtensionsSQLStatement))).
+					WillReturnRows(
+						pgxmock.NewRows([]string{
+							"name",
+							"version",
+							"schema",
+							"available_upgrades",
+						}).
+							AddRow(
+								"hstore",
+								"1.8",
+								"public",
+								[]string{},
+							).
+							AddRow(
+								"postgis",
+								"3.3.2",
+								"public",
+								[]string{"3.4.0", "3.4.2"},
+							),
+					)
+				pgpoolsMock["foo"].ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`ALTER EXTENSION "hstore" SET SCHEMA "extensions"`))).
+					WillReturnResult(pgxmock.NewResult("", 1))
+				pgpoolsMock["foo"].ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`ALTER EXTENSION "postgis" UPDATE TO '3.4.2'`))).
+					WillReturnResult(pgxmock.NewResult("", 1))
+				pgpoolsMock["foo"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetExtensionsSQLStatement))).
+					WillReturnRows(
+						pgxmock.NewRows([]string{
+							"name",
+							"version",
+							"schema",
+							"available_upgrades",
+						}).
+							AddRow(
+								"hstore",
+								"1.8",
+								"extensions",
+								[]string{},
+							).
+							AddRow(
+								"postgis",
+								"3.4.2",
+								"public",
+								[]string{},
+							),
+					)
+
+				installedExtensions, _, err := reconciliation.reconcileExtensions(pgpools, desiredDatabase, "", nil, false)
+				Expect(err).NotTo(HaveOccurred())
+				for _, poolMock := range pgpoolsMock {
+					if err := poolMock.ExpectationsWereMet(); err != nil {
+						Fail(err.Error())
+					}
+				}
+				Expect(installedExtensions).To(Equal([]postgresql.Extension{
+					{Name: "hstore", Version: "1.8", Schema: "extensions", AvailableUpgrades: []string{}},
+					{Name: "postgis", Version: "3.4.2", Schema: "public", AvailableUpgrades: []string{}},
+				}))
+			})
+
+			It("should not update the extensions without a declared version", func() {
+				desiredDatabase := &postgresql.Database{
+					Name:             "foo",
+					Owner:            "foo_owner",
+					ConnectionLimit:  -1,
+					AllowConnections: true,
+					Extensions: []postgresql.Extension{
+						{Name: "postgis"},
+					},
+				}
+				controllerReconciler := &PostgresDatabaseReconciler{
+					Client:  k8sClient,
+					Scheme:  k8sClient.Scheme(),
+					PGPools: pgpools,
+				}
+				reconciliation := &postgresDatabaseReconciliation{PostgresDatabaseReconciler: controllerReconciler}
+
+				pgpoolsMock["foo"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetExtensionsSQLStatement))).
+					WillReturnRows(
+						pgxmock.NewRows([]string{
+							"name",
+							"version",
+							"schema",
+							"available_upgrades",
+						}).
+							AddRow(
+								"postgis",
+								"3.3.2",
+								"public",
+								[]string{"3.4.2"},
+							),
+					)
 
-				err := controllerReconciler.reconcileExtensions(desiredDatabase)
+				installedExtensions, _, err := reconciliation.reconcileExtensions(pgpools, desiredDatabase, "", nil, false)
 				Expect(err).NotTo(HaveOccurred())
 				for _, poolMock := range pgpoolsMock {
 					if err := poolMock.ExpectationsWereMet(); err != nil {
 						Fail(err.Error())
 					}
 				}
+				Expect(installedExtensions).To(Equal([]postgresql.Extension{
+					{Name: "postgis", Version: "3.3.2", Schema: "public", AvailableUpgrades: []string{"3.4.2"}},
+				}))
 			})
 
 			It("should return an error if listing extensions failed", func() {
 				desiredDatabase := &postgresql.Database{
-					Name:  "foo",
-					Owner: "foo_owner",
-					Extensions: []string{
-						"plpgsql",
+					Name:             "foo",
+					Owner:            "foo_owner",
+					ConnectionLimit:  -1,
+					AllowConnections: true,
+					Extensions: []postgresql.Extension{
+						{Name: "plpgsql"},
 					},
 				}
 				controllerReconciler := &PostgresDatabaseReconciler{
@@ -636,11 +1792,12 @@ var _ = Describe("PostgresDatabase Controller", func() {
 					Scheme:  k8sClient.Scheme(),
 					PGPools: pgpools,
 				}
+				reconciliation := &postgresDatabaseReconciliation{PostgresDatabaseReconciler: controllerReconciler}
 
-				pgpoolsMock["foo"].ExpectQuery(`SELECT extname FROM pg_extension`).
+				pgpoolsMock["foo"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetExtensionsSQLStatement))).
 					WillReturnError(fmt.Errorf("fake error from PostgreSQL"))
 
-				err := controllerReconciler.reconcileExtensions(desiredDatabase)
+				_, _, err := reconciliation.reconcileExtensions(pgpools, desiredDatabase, "", nil, false)
 				Expect(err).To(HaveOccurred())
 				for _, poolMock := range pgpoolsMock {
 					if err := poolMock.ExpectationsWereMet(); err != nil {
@@ -657,6 +1814,7 @@ var _ = Describe("PostgresDatabase Controller", func() {
 					Scheme:  k8sClient.Scheme(),
 					PGPools: pgpools,
 				}
+				reconciliation := &postgresDatabaseReconciliation{PostgresDatabaseReconciler: controllerReconciler}
 
 				// Loop over all privileges
 				for _, privilege := range postgresql.ListDatabaseAvailablePrivileges() {
@@ -674,7 +1832,8 @@ var _ = Describe("PostgresDatabase Controller", func() {
 				pgpoolsMock["default"].ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`GRANT CREATE ON DATABASE "mydb" TO "myrole"`))).
 					WillReturnResult(pgxmock.NewResult("", 1))
 
-				err := controllerReconciler.reconcilePrivileges(
+				err := reconciliation.reconcilePrivileges(
+					pgpools,
 					"mydb",
 					"myrole",
 					[]string{
@@ -695,6 +1854,7 @@ var _ = Describe("PostgresDatabase Controller", func() {
 					Scheme:  k8sClient.Scheme(),
 					PGPools: pgpools,
 				}
+				reconciliation := &postgresDatabaseReconciliation{PostgresDatabaseReconciler: controllerReconciler}
 
 				// Loop over all privileges
 				for _, privilege := range postgresql.ListDatabaseAvailablePrivileges() {
@@ -712,7 +1872,8 @@ var _ = Describe("PostgresDatabase Controller", func() {
 				pgpoolsMock["default"].ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`REVOKE CREATE ON DATABASE "mydb" FROM "myrole"`))).
 					WillReturnResult(pgxmock.NewResult("", 1))
 
-				err := controllerReconciler.reconcilePrivileges(
+				err := reconciliation.reconcilePrivileges(
+					pgpools,
 					"mydb",
 					"myrole",
 					[]string{
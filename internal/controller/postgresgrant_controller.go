@@ -0,0 +1,447 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/go-logr/logr"
+	managedpostgresoperatorhoppscalecomv1alpha1 "github.com/hoppscale/managed-postgres-operator/api/v1alpha1"
+	"github.com/hoppscale/managed-postgres-operator/internal/postgresql"
+	"github.com/hoppscale/managed-postgres-operator/internal/utils"
+)
+
+const PostgresGrantFinalizer = "postgresgrant.managed-postgres-operator.hoppscale.com/finalizer"
+
+// GranteeField is the field indexed in the cache to list the resources claiming the privileges of the same grantee.
+// It is also a selectable field of the CRD, only so that users can list them with kubectl on a recent API server.
+const GranteeField = "spec.grantee"
+
+// PostgresGrantReconciler reconciles a PostgresGrant object
+type PostgresGrantReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder events.EventRecorder
+
+	RequeueInterval time.Duration
+
+	PGPools              *postgresql.PGPools
+	OperatorInstanceName string
+
+	// DryRun only plans the statements changing the server, for every resource
+	DryRun bool
+}
+
+// postgresGrantReconciliation holds the state of a single reconciliation,
+// so that the concurrent reconciliations don't share it
+type postgresGrantReconciliation struct {
+	*PostgresGrantReconciler
+	logging  logr.Logger
+	eventing eventRecorder
+	plan     *postgresql.DryRunPlan
+}
+
+// +kubebuilder:rbac:groups=managed-postgres-operator.hoppscale.com,resources=postgresgrants,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=managed-postgres-operator.hoppscale.com,resources=postgresgrants/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=managed-postgres-operator.hoppscale.com,resources=postgresgrants/finalizers,verbs=update
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
+func (r *PostgresGrantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reconciliation := &postgresGrantReconciliation{
+		PostgresGrantReconciler: r,
+		logging:                 log.FromContext(ctx),
+	}
+	return reconciliation.reconcile(ctx, req)
+}
+
+// reconcile runs a single reconciliation of the resource
+func (r *postgresGrantReconciliation) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresGrant{}
+
+	if err := r.Client.Get(ctx, req.NamespacedName, resource); err != nil {
+		return r.Result(client.IgnoreNotFound(err))
+	}
+
+	r.eventing = newEventRecorder(r.Recorder, resource)
+
+	// Skip reconcile if the resource is not managed by this operator
+	if !utils.IsManagedByOperatorInstance(resource.ObjectMeta.Annotations, r.OperatorInstanceName) {
+		return r.Result(nil)
+	}
+
+	// The privileges of the grantee on the object are managed by the oldest of the resources claiming them,
+	// as each resource revokes the privileges it doesn't declare
+	claimants, err := r.listClaimants(ctx, resource)
+	if err != nil {
+		return r.Result(err)
+	}
+	if manager := conflictingClaimant(resource, claimants); manager != nil {
+		if !resource.ObjectMeta.DeletionTimestamp.IsZero() {
+			// The privileges are left to the resource managing them
+			controllerutil.RemoveFinalizer(resource, PostgresGrantFinalizer)
+			return r.Result(r.Update(ctx, resource))
+		}
+		return r.Conflict(ctx, resource, fmt.Errorf("privileges of role \"%s\" on %s %s in database \"%s\" are already managed by the resource \"%s\"", resource.Spec.Grantee, resource.Spec.ObjectType, resource.Spec.Identity, resource.Spec.Database, client.ObjectKeyFromObject(manager)))
+	}
+
+	pgpools, err := postgresql.GetServerPGPools(r.PGPools, resource.Spec.ServerRef)
+	if err != nil {
+		return r.Failure(ctx, resource, ReasonServerNotReady, err)
+	}
+
+	if utils.IsDryRun(resource.ObjectMeta.Annotations, r.DryRun) {
+		pgpools, r.plan = startDryRun(pgpools, &r.eventing)
+		r.logging = r.logging.WithValues("dryRun", true)
+	}
+
+	err = postgresql.EnsurePGPoolExists(pgpools, resource.Spec.Database)
+	if err != nil {
+		r.logging.Error(err, "failed to open pg pool")
+		return r.Failure(ctx, resource, ReasonOpenPoolFailed, err)
+	}
+
+	err = postgresql.ValidateGrantIdentity(resource.Spec.ObjectType, resource.Spec.Identity)
+	if err != nil {
+		return r.Failure(ctx, resource, ReasonInvalidSpec, err)
+	}
+
+	objectName, err := postgresql.GetGrantObjectName(pgpools.Database(resource.Spec.Database), resource.Spec.ObjectType, resource.Spec.Identity)
+	if err != nil {
+		return r.Failure(ctx, resource, ReasonGetObjectFailed, fmt.Errorf("failed to retrieve object: %s", err))
+	}
+
+	grant := postgresql.Grant{
+		ObjectType: resource.Spec.ObjectType,
+		ObjectName: objectName,
+		Columns:    resource.Spec.Columns,
+		Grantee:    resource.Spec.Grantee,
+	}
+
+	if resource.ObjectMeta.DeletionTimestamp.IsZero() {
+		if !controllerutil.ContainsFinalizer(resource, PostgresGrantFinalizer) {
+			controllerutil.AddFinalizer(resource, PostgresGrantFinalizer)
+			if err := r.Update(ctx, resource); err != nil {
+				return r.Result(err)
+			}
+		}
+	} else {
+
+		//
+		// Deletion logic
+		//
+
+		// If there is no finalizer, delete the resource immediately
+		if !controllerutil.ContainsFinalizer(resource, PostgresGrantFinalizer) {
+			return r.Result(nil)
+		}
+
+		// The privileges are left to the next resource claiming them
+		if hasSuccessor(resource, claimants) {
+			r.logging.Info(fmt.Sprintf("Privileges of role \"%s\" on %s %s are claimed by another resource, skipping REVOKE", resource.Spec.Grantee, resource.Spec.ObjectType, resource.Spec.Identity))
+		} else {
+			err = r.reconcileOnDeletion(pgpools, resource, &grant)
+			if err != nil {
+				return r.Failure(ctx, resource, ReasonReconcileOnDeletionFailed, err)
+			}
+		}
+
+		r.eventing.Planned(r.plan.Statements())
+
+		// Remove our finalizer from the list and update it.
+		controllerutil.RemoveFinalizer(resource, PostgresGrantFinalizer)
+		if err := r.Update(ctx, resource); err != nil {
+			return r.Result(err)
+		}
+
+		// Stop reconciliation as the item is being deleted
+		return r.Result(nil)
+	}
+
+	//
+	// Creation logic
+	//
+
+	desiredPrivileges, err := r.convertPrivilegesSpecToList(resource.Spec)
+	if err != nil {
+		return r.Failure(ctx, resource, ReasonInvalidSpec, err)
+	}
+
+	if objectName == "" {
+		return r.Failure(ctx, resource, ReasonObjectNotFound, fmt.Errorf("%s %s doesn't exist in database \"%s\"", resource.Spec.ObjectType, resource.Spec.Identity, resource.Spec.Database))
+	}
+
+	changes, err := r.reconcilePrivileges(pgpools, resource, &grant, desiredPrivileges)
+	if err != nil {
+		return r.Failure(ctx, resource, ReasonReconcilePrivilegesFailed, err)
+	}
+
+	// Changes made on a resource already synced at this generation are corrections of modifications done outside of the operator.
+	// The changes planned by a dry run haven't corrected anything.
+	var drift []string
+	if r.plan == nil && resource.Status.ObservedGeneration == resource.Generation && meta.IsStatusConditionTrue(resource.Status.Conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeSynced) {
+		drift = changes
+	}
+	if len(drift) > 0 {
+		r.logging.Info(fmt.Sprintf("Drift has been detected and corrected: %s", strings.Join(drift, ", ")))
+		r.eventing.Warning(EventReasonDriftDetected, EventActionReconcile, "Drift has been detected and corrected: %s", strings.Join(drift, ", "))
+	}
+
+	return r.Success(ctx, resource, drift)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *PostgresGrantReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := indexReference(mgr, &managedpostgresoperatorhoppscalecomv1alpha1.PostgresGrant{}, GranteeField, func(resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresGrant) string {
+		return resource.Spec.Grantee
+	})
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&managedpostgresoperatorhoppscalecomv1alpha1.PostgresGrant{}).
+		Named("postgresgrant").
+		WithOptions(controller.Options{
+			RateLimiter: workqueue.NewTypedMaxOfRateLimiter(
+				workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](time.Second, r.RequeueInterval),
+				&workqueue.TypedBucketRateLimiter[reconcile.Request]{Limiter: rate.NewLimiter(rate.Limit(10), 100)},
+			),
+		}).
+		Complete(r)
+}
+
+// Result builds reconciler result depending on error
+func (r *PostgresGrantReconciler) Result(err error) (ctrl.Result, error) {
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: r.RequeueInterval}, nil
+}
+
+// Success marks the resource as ready and synced or reports the statements planned by a dry run, records the corrected drift, then builds the reconciler result
+func (r *postgresGrantReconciliation) Success(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresGrant, drift []string) (ctrl.Result, error) {
+	status := resource.Status.DeepCopy()
+	status.Succeeded = true
+	status.ObservedGeneration = resource.Generation
+	// The statements planned by a dry run are reported instead of marking the resource as synced
+	status.PlannedStatements = r.plan.Statements()
+	if r.plan != nil {
+		setPlannedConditions(&status.Conditions, resource.Generation, status.PlannedStatements)
+		r.eventing.Planned(status.PlannedStatements)
+	} else {
+		setSucceededConditions(&status.Conditions, resource.Generation)
+	}
+
+	status.Drift = drift
+	if len(drift) > 0 {
+		now := metav1.Now()
+		status.LastDriftTime = &now
+	}
+
+	return r.Result(r.updateStatus(ctx, resource, status))
+}
+
+// Failure records the failing step in the resource's conditions, then builds the reconciler result
+func (r *postgresGrantReconciliation) Failure(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresGrant, reason string, err error) (ctrl.Result, error) {
+	status := resource.Status.DeepCopy()
+	status.Succeeded = false
+	status.ObservedGeneration = resource.Generation
+	setFailedConditions(&status.Conditions, resource.Generation, !resource.ObjectMeta.DeletionTimestamp.IsZero(), reason, err)
+
+	r.eventing.Warning(reason, EventActionReconcile, "%s", err)
+
+	if statusErr := r.updateStatus(ctx, resource, status); statusErr != nil {
+		r.logging.Error(statusErr, "failed to update status")
+	}
+
+	return r.Result(err)
+}
+
+// Conflict marks the resource as in conflict with the resource managing the same privileges, then builds the reconciler result
+func (r *postgresGrantReconciliation) Conflict(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresGrant, err error) (ctrl.Result, error) {
+	status := resource.Status.DeepCopy()
+	status.Succeeded = false
+	status.ObservedGeneration = resource.Generation
+	setConflictConditions(&status.Conditions, resource.Generation, err)
+
+	r.eventing.Warning(ReasonConflict, EventActionReconcile, "%s", err)
+
+	return r.Result(r.updateStatus(ctx, resource, status))
+}
+
+// listClaimants returns the resources managed by this operator's instance claiming the privileges of the same grantee on the same object
+func (r *PostgresGrantReconciler) listClaimants(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresGrant) ([]client.Object, error) {
+	resources := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresGrantList{}
+	if err := r.List(ctx, resources, client.MatchingFields{GranteeField: resource.Spec.Grantee}); err != nil {
+		return nil, fmt.Errorf("failed to list the resources claiming the privileges: %s", err)
+	}
+
+	claimants := []client.Object{}
+	for i := range resources.Items {
+		claimant := &resources.Items[i]
+		if claimant.Spec.ServerRef == resource.Spec.ServerRef && claimant.Spec.Database == resource.Spec.Database &&
+			claimant.Spec.ObjectType == resource.Spec.ObjectType && claimant.Spec.Identity == resource.Spec.Identity &&
+			utils.IsManagedByOperatorInstance(claimant.ObjectMeta.Annotations, r.OperatorInstanceName) {
+			claimants = append(claimants, claimant)
+		}
+	}
+	return claimants, nil
+}
+
+// updateStatus updates the resource's status only if it has changed
+func (r *PostgresGrantReconciler) updateStatus(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresGrant, status *managedpostgresoperatorhoppscalecomv1alpha1.PostgresGrantStatus) error {
+	if equality.Semantic.DeepEqual(resource.Status, *status) {
+		return nil
+	}
+
+	resource.Status = *status
+	if err := r.Client.Status().Update(ctx, resource); err != nil {
+		return fmt.Errorf("failed to update object: %s", err)
+	}
+
+	return nil
+}
+
+// reconcileOnDeletion revokes the declared privileges which are granted on the object
+func (r *postgresGrantReconciliation) reconcileOnDeletion(pgpools *postgresql.PGPools, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresGrant, grant *postgresql.Grant) (err error) {
+	if grant.ObjectName == "" {
+		r.logging.Info(fmt.Sprintf("%s %s doesn't exist in database \"%s\", skipping REVOKE", resource.Spec.ObjectType, resource.Spec.Identity, resource.Spec.Database))
+		return nil
+	}
+
+	existingPrivileges, err := postgresql.GetGrantPrivileges(pgpools.Database(resource.Spec.Database), resource.Spec.ObjectType, resource.Spec.Identity, resource.Spec.Columns, resource.Spec.Grantee)
+	if err != nil {
+		r.logging.Error(err, fmt.Sprintf("failed to retrieve privileges of role \"%s\" on %s %s in database \"%s\"", resource.Spec.Grantee, resource.Spec.ObjectType, resource.Spec.Identity, resource.Spec.Database))
+		return err
+	}
+
+	for _, existingPrivilege := range existingPrivileges {
+		if !slices.Contains(resource.Spec.Privileges, managedpostgresoperatorhoppscalecomv1alpha1.PostgresGrantPrivilege(existingPrivilege.Privilege)) {
+			continue
+		}
+
+		err := postgresql.RevokeObjectPrivilege(pgpools.Database(resource.Spec.Database), grant, existingPrivilege.Privilege)
+		if err != nil {
+			r.logging.Error(err, fmt.Sprintf("failed to revoke privilege \"%s\" on %s %s in database \"%s\" from role \"%s\"", existingPrivilege.Privilege, resource.Spec.ObjectType, resource.Spec.Identity, resource.Spec.Database, resource.Spec.Grantee))
+			return err
+		}
+
+		r.logging.Info(fmt.Sprintf("Privilege \"%s\" on %s %s in database \"%s\" has been revoked from \"%s\"", existingPrivilege.Privilege, resource.Spec.ObjectType, resource.Spec.Identity, resource.Spec.Database, resource.Spec.Grantee))
+		r.eventing.Normal(EventReasonPrivilegeRevoked, EventActionRevoke, "Privilege \"%s\" on %s %s in database \"%s\" has been revoked from \"%s\"", existingPrivilege.Privilege, resource.Spec.ObjectType, resource.Spec.Identity, resource.Spec.Database, resource.Spec.Grantee)
+	}
+
+	return nil
+}
+
+// reconcilePrivileges grants the missing privileges and revokes the non-declared ones, then returns the list of changes made
+func (r *postgresGrantReconciliation) reconcilePrivileges(pgpools *postgresql.PGPools, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresGrant, grant *postgresql.Grant, desiredPrivileges []string) (changes []string, err error) {
+	pgpool := pgpools.Database(resource.Spec.Database)
+
+	// We retrieve the existing privileges
+	existingPrivileges, err := postgresql.GetGrantPrivileges(pgpool, resource.Spec.ObjectType, resource.Spec.Identity, resource.Spec.Columns, resource.Spec.Grantee)
+	if err != nil {
+		r.logging.Error(err, fmt.Sprintf("failed to retrieve privileges of role \"%s\" on %s %s in database \"%s\"", resource.Spec.Grantee, resource.Spec.ObjectType, resource.Spec.Identity, resource.Spec.Database))
+		return changes, err
+	}
+
+	existingPrivilegesByName := map[string]postgresql.GrantPrivilege{}
+	for _, existingPrivilege := range existingPrivileges {
+		existingPrivilegesByName[existingPrivilege.Privilege] = existingPrivilege
+	}
+
+	// We grant the missing privileges and fix their grant option
+	for _, desiredPrivilege := range desiredPrivileges {
+		existingPrivilege, exists := existingPrivilegesByName[desiredPrivilege]
+
+		switch {
+		case !exists || !existingPrivilege.GrantedOnAll:
+			changes = append(changes, fmt.Sprintf("privilege \"%s\" was missing", desiredPrivilege))
+		case resource.Spec.WithGrantOption && !existingPrivilege.IsGrantable:
+			changes = append(changes, fmt.Sprintf("grant option of privilege \"%s\" was missing", desiredPrivilege))
+		case !resource.Spec.WithGrantOption && existingPrivilege.IsGrantable:
+			err := postgresql.RevokeObjectPrivilegeGrantOption(pgpool, grant, desiredPrivilege)
+			if err != nil {
+				r.logging.Error(err, fmt.Sprintf("failed to revoke grant option for privilege \"%s\" on %s %s in database \"%s\" from role \"%s\"", desiredPrivilege, resource.Spec.ObjectType, resource.Spec.Identity, resource.Spec.Database, resource.Spec.Grantee))
+				return changes, err
+			}
+
+			r.logging.Info(fmt.Sprintf("Grant option for privilege \"%s\" on %s %s in database \"%s\" has been revoked from \"%s\"", desiredPrivilege, resource.Spec.ObjectType, resource.Spec.Identity, resource.Spec.Database, resource.Spec.Grantee))
+			r.eventing.Normal(EventReasonGrantOptionRevoked, EventActionRevoke, "Grant option for privilege \"%s\" on %s %s in database \"%s\" has been revoked from \"%s\"", desiredPrivilege, resource.Spec.ObjectType, resource.Spec.Identity, resource.Spec.Database, resource.Spec.Grantee)
+			changes = append(changes, fmt.Sprintf("grant option of privilege \"%s\" was not declared", desiredPrivilege))
+			continue
+		default:
+			continue
+		}
+
+		err := postgresql.GrantObjectPrivilege(pgpool, grant, desiredPrivilege, resource.Spec.WithGrantOption)
+		if err != nil {
+			r.logging.Error(err, fmt.Sprintf("failed to grant privilege \"%s\" on %s %s in database \"%s\" to role \"%s\"", desiredPrivilege, resource.Spec.ObjectType, resource.Spec.Identity, resource.Spec.Database, resource.Spec.Grantee))
+			return changes, err
+		}
+
+		r.logging.Info(fmt.Sprintf("Privilege \"%s\" on %s %s in database \"%s\" has been granted to \"%s\"", desiredPrivilege, resource.Spec.ObjectType, resource.Spec.Identity, resource.Spec.Database, resource.Spec.Grantee))
+		r.eventing.Normal(EventReasonPrivilegeGranted, EventActionGrant, "Privilege \"%s\" on %s %s in database \"%s\" has been granted to \"%s\"", desiredPrivilege, resource.Spec.ObjectType, resource.Spec.Identity, resource.Spec.Database, resource.Spec.Grantee)
+	}
+
+	// We revoke the non-declared privileges
+	for _, existingPrivilege := range existingPrivileges {
+		if !slices.Contains(desiredPrivileges, existingPrivilege.Privilege) {
+			err := postgresql.RevokeObjectPrivilege(pgpool, grant, existingPrivilege.Privilege)
+			if err != nil {
+				r.logging.Error(err, fmt.Sprintf("failed to revoke privilege \"%s\" on %s %s in database \"%s\" from role \"%s\"", existingPrivilege.Privilege, resource.Spec.ObjectType, resource.Spec.Identity, resource.Spec.Database, resource.Spec.Grantee))
+				return changes, err
+			}
+
+			r.logging.Info(fmt.Sprintf("Privilege \"%s\" on %s %s in database \"%s\" has been revoked from \"%s\"", existingPrivilege.Privilege, resource.Spec.ObjectType, resource.Spec.Identity, resource.Spec.Database, resource.Spec.Grantee))
+			r.eventing.Normal(EventReasonPrivilegeRevoked, EventActionRevoke, "Privilege \"%s\" on %s %s in database \"%s\" has been revoked from \"%s\"", existingPrivilege.Privilege, resource.Spec.ObjectType, resource.Spec.Identity, resource.Spec.Database, resource.Spec.Grantee)
+			changes = append(changes, fmt.Sprintf("privilege \"%s\" was not declared", existingPrivilege.Privilege))
+		}
+	}
+
+	return changes, nil
+}
+
+// convertPrivilegesSpecToList returns the desired privileges, or an error if one of them can't be granted on the object type
+func (r *PostgresGrantReconciler) convertPrivilegesSpecToList(spec managedpostgresoperatorhoppscalecomv1alpha1.PostgresGrantSpec) ([]string, error) {
+	availablePrivileges := postgresql.ListGrantObjectTypeAvailablePrivileges(spec.ObjectType)
+
+	privileges := []string{}
+	for _, privilege := range spec.Privileges {
+		if !slices.Contains(availablePrivileges, string(privilege)) {
+			return []string{}, fmt.Errorf("privilege \"%s\" can't be granted on %s, available privileges are: %s", privilege, spec.ObjectType, strings.Join(availablePrivileges, ", "))
+		}
+		privileges = append(privileges, string(privilege))
+	}
+	return privileges, nil
+}
@@ -0,0 +1,476 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	pgxmock "github.com/pashagolub/pgxmock/v4"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	managedpostgresoperatorhoppscalecomv1alpha1 "github.com/hoppscale/managed-postgres-operator/api/v1alpha1"
+	"github.com/hoppscale/managed-postgres-operator/internal/postgresql"
+	"github.com/hoppscale/managed-postgres-operator/internal/utils"
+)
+
+var _ = Describe("PostgresGrant Controller", func() {
+	Context("When reconciling a resource", func() {
+		const resourceName = "test-resource"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+		postgresgrant := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresGrant{}
+
+		var pgpoolsMock map[string]pgxmock.PgxPoolIface
+		var pgpools *postgresql.PGPools
+
+		objectName := `myschema."MyTable"`
+
+		BeforeEach(func() {
+			By("creating the custom resource for the Kind PostgresGrant")
+			err := k8sClient.Get(ctx, typeNamespacedName, postgresgrant)
+			if err != nil && errors.IsNotFound(err) {
+				resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresGrant{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      resourceName,
+						Namespace: "default",
+						Annotations: map[string]string{
+							utils.OperatorInstanceAnnotationName: "foo",
+						},
+					},
+					Spec: managedpostgresoperatorhoppscalecomv1alpha1.PostgresGrantSpec{
+						Database:   "mydb",
+						ObjectType: postgresql.GrantObjectTypeTable,
+						Identity:   `myschema."MyTable"`,
+						Grantee:    "myrole",
+						Privileges: []managedpostgresoperatorhoppscalecomv1alpha1.PostgresGrantPrivilege{
+							"SELECT",
+							"INSERT",
+						},
+					},
+				}
+				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			}
+
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				Fail(err.Error())
+			}
+			pgpoolsMock = map[string]pgxmock.PgxPoolIface{
+				"default": mock,
+				"mydb":    mock,
+			}
+			pgpools = &postgresql.PGPools{
+				Default: mock,
+				Databases: map[string]postgresql.PGPoolInterface{
+					"mydb": mock,
+				},
+			}
+		})
+
+		AfterEach(func() {
+			resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresGrant{}
+			err := k8sClient.Get(ctx, typeNamespacedName, resource)
+			if err != nil && errors.IsNotFound(err) {
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Cleanup the specific resource instance PostgresGrant")
+			controllerutil.RemoveFinalizer(resource, PostgresGrantFinalizer)
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			for _, pool := range pgpoolsMock {
+				pool.Close()
+			}
+		})
+
+		When("privileges are missing or not declared", func() {
+			It("should grant the missing privileges and revoke the others", func() {
+				recorder := events.NewFakeRecorder(10)
+				controllerReconciler := &PostgresGrantReconciler{
+					Client:               k8sClient,
+					Scheme:               k8sClient.Scheme(),
+					Recorder:             recorder,
+					PGPools:              pgpools,
+					OperatorInstanceName: "foo",
+				}
+
+				pgpoolsMock["mydb"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetRelationNameSQLStatement))).
+					WithArgs(`myschema."MyTable"`).
+					WillReturnRows(pgxmock.NewRows([]string{"to_regclass"}).AddRow(&objectName))
+				pgpoolsMock["mydb"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetRelationPrivilegesSQLStatement))).
+					WithArgs(`myschema."MyTable"`, "myrole").
+					WillReturnRows(
+						pgxmock.NewRows([]string{
+							"privilege_type",
+							"is_grantable",
+							"granted_on_all",
+						}).
+							AddRow("SELECT", false, true).
+							AddRow("DELETE", false, true),
+					)
+				pgpoolsMock["mydb"].ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`GRANT INSERT ON TABLE myschema."MyTable" TO "myrole"`))).
+					WillReturnResult(pgxmock.NewResult("GRANT", 0))
+				pgpoolsMock["mydb"].ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`REVOKE DELETE ON TABLE myschema."MyTable" FROM "myrole"`))).
+					WillReturnResult(pgxmock.NewResult("REVOKE", 0))
+
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: typeNamespacedName,
+				})
+
+				Expect(err).NotTo(HaveOccurred())
+				for _, poolMock := range pgpoolsMock {
+					if err := poolMock.ExpectationsWereMet(); err != nil {
+						Fail(err.Error())
+					}
+				}
+
+				Expect(recorder.Events).To(Receive(Equal(`Normal PrivilegeGranted Privilege "INSERT" on TABLE myschema."MyTable" in database "mydb" has been granted to "myrole"`)))
+				Expect(recorder.Events).To(Receive(Equal(`Normal PrivilegeRevoked Privilege "DELETE" on TABLE myschema."MyTable" in database "mydb" has been revoked from "myrole"`)))
+
+				By("Not reporting the first reconciliation's changes as drift")
+				resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresGrant{}
+				Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+				Expect(controllerutil.ContainsFinalizer(resource, PostgresGrantFinalizer)).To(BeTrue())
+				Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeReady)).To(BeTrue())
+				Expect(resource.Status.Drift).To(BeEmpty())
+				Expect(resource.Status.LastDriftTime).To(BeNil())
+			})
+		})
+
+		When("privileges have been modified outside of the operator", func() {
+			It("should correct them and report the drift in the status", func() {
+				resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresGrant{}
+				Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+				resource.Status.ObservedGeneration = resource.Generation
+				setSucceededConditions(&resource.Status.Conditions, resource.Generation)
+				Expect(k8sClient.Status().Update(ctx, resource)).To(Succeed())
+
+				recorder := events.NewFakeRecorder(10)
+				controllerReconciler := &PostgresGrantReconciler{
+					Client:               k8sClient,
+					Scheme:               k8sClient.Scheme(),
+					Recorder:             recorder,
+					PGPools:              pgpools,
+					OperatorInstanceName: "foo",
+				}
+
+				pgpoolsMock["mydb"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetRelationNameSQLStatement))).
+					WithArgs(`myschema."MyTable"`).
+					WillReturnRows(pgxmock.NewRows([]string{"to_regclass"}).AddRow(&objectName))
+				pgpoolsMock["mydb"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetRelationPrivilegesSQLStatement))).
+					WithArgs(`myschema."MyTable"`, "myrole").
+					WillReturnRows(
+						pgxmock.NewRows([]string{
+							"privilege_type",
+							"is_grantable",
+							"granted_on_all",
+						}).
+							AddRow("SELECT", true, true).
+							AddRow("INSERT", false, true),
+					)
+				pgpoolsMock["mydb"].ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`REVOKE GRANT OPTION FOR SELECT ON TABLE myschema."MyTable" FROM "myrole"`))).
+					WillReturnResult(pgxmock.NewResult("REVOKE", 0))
+
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: typeNamespacedName,
+				})
+
+				Expect(err).NotTo(HaveOccurred())
+				for _, poolMock := range pgpoolsMock {
+					if err := poolMock.ExpectationsWereMet(); err != nil {
+						Fail(err.Error())
+					}
+				}
+
+				Expect(recorder.Events).To(Receive(HavePrefix("Normal GrantOptionRevoked ")))
+				Expect(recorder.Events).To(Receive(Equal(`Warning DriftDetected Drift has been detected and corrected: grant option of privilege "SELECT" was not declared`)))
+
+				Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+				Expect(resource.Status.Drift).To(Equal([]string{`grant option of privilege "SELECT" was not declared`}))
+				Expect(resource.Status.LastDriftTime).NotTo(BeNil())
+			})
+		})
+
+		When("a privilege can't be granted on the object type", func() {
+			It("should fail without granting anything", func() {
+				resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresGrant{}
+				Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+				resource.Spec.Privileges = append(resource.Spec.Privileges, "EXECUTE")
+				Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+
+				controllerReconciler := &PostgresGrantReconciler{
+					Client:               k8sClient,
+					Scheme:               k8sClient.Scheme(),
+					PGPools:              pgpools,
+					OperatorInstanceName: "foo",
+				}
+
+				pgpoolsMock["mydb"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetRelationNameSQLStatement))).
+					WithArgs(`myschema."MyTable"`).
+					WillReturnRows(pgxmock.NewRows([]string{"to_regclass"}).AddRow(&objectName))
+
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: typeNamespacedName,
+				})
+
+				Expect(err).To(HaveOccurred())
+				for _, poolMock := range pgpoolsMock {
+					if err := poolMock.ExpectationsWereMet(); err != nil {
+						Fail(err.Error())
+					}
+				}
+
+				Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+				readyCondition := meta.FindStatusCondition(resource.Status.Conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeReady)
+				Expect(readyCondition).NotTo(BeNil())
+				Expect(readyCondition.Reason).To(Equal(ReasonInvalidSpec))
+			})
+		})
+
+		When("the object doesn't exist", func() {
+			It("should fail with the ObjectNotFound reason", func() {
+				controllerReconciler := &PostgresGrantReconciler{
+					Client:               k8sClient,
+					Scheme:               k8sClient.Scheme(),
+					PGPools:              pgpools,
+					OperatorInstanceName: "foo",
+				}
+
+				pgpoolsMock["mydb"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetRelationNameSQLStatement))).
+					WithArgs(`myschema."MyTable"`).
+					WillReturnRows(pgxmock.NewRows([]string{"to_regclass"}).AddRow(nil))
+
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: typeNamespacedName,
+				})
+
+				Expect(err).To(HaveOccurred())
+				for _, poolMock := range pgpoolsMock {
+					if err := poolMock.ExpectationsWereMet(); err != nil {
+						Fail(err.Error())
+					}
+				}
+
+				resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresGrant{}
+				Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+				readyCondition := meta.FindStatusCondition(resource.Status.Conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeReady)
+				Expect(readyCondition).NotTo(BeNil())
+				Expect(readyCondition.Reason).To(Equal(ReasonObjectNotFound))
+			})
+		})
+
+		When("the resource is deleted", func() {
+			It("should revoke the declared privileges only", func() {
+				resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresGrant{}
+				Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+				controllerutil.AddFinalizer(resource, PostgresGrantFinalizer)
+				Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+				controllerReconciler := &PostgresGrantReconciler{
+					Client:               k8sClient,
+					Scheme:               k8sClient.Scheme(),
+					PGPools:              pgpools,
+					OperatorInstanceName: "foo",
+				}
+
+				pgpoolsMock["mydb"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetRelationNameSQLStatement))).
+					WithArgs(`myschema."MyTable"`).
+					WillReturnRows(pgxmock.NewRows([]string{"to_regclass"}).AddRow(&objectName))
+				pgpoolsMock["mydb"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetRelationPrivilegesSQLStatement))).
+					WithArgs(`myschema."MyTable"`, "myrole").
+					WillReturnRows(
+						pgxmock.NewRows([]string{
+							"privilege_type",
+							"is_grantable",
+							"granted_on_all",
+						}).
+							AddRow("SELECT", false, true).
+							AddRow("DELETE", false, true),
+					)
+				pgpoolsMock["mydb"].ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`REVOKE SELECT ON TABLE myschema."MyTable" FROM "myrole"`))).
+					WillReturnResult(pgxmock.NewResult("REVOKE", 0))
+
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: typeNamespacedName,
+				})
+
+				Expect(err).NotTo(HaveOccurred())
+				for _, poolMock := range pgpoolsMock {
+					if err := poolMock.ExpectationsWereMet(); err != nil {
+						Fail(err.Error())
+					}
+				}
+
+				Expect(errors.IsNotFound(k8sClient.Get(ctx, typeNamespacedName, resource))).To(BeTrue())
+			})
+		})
+
+		When("the resource is deleted but the object doesn't exist", func() {
+			It("should delete the resource but skip the REVOKE", func() {
+				resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresGrant{}
+				Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+				controllerutil.AddFinalizer(resource, PostgresGrantFinalizer)
+				Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+				controllerReconciler := &PostgresGrantReconciler{
+					Client:               k8sClient,
+					Scheme:               k8sClient.Scheme(),
+					PGPools:              pgpools,
+					OperatorInstanceName: "foo",
+				}
+
+				pgpoolsMock["mydb"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetRelationNameSQLStatement))).
+					WithArgs(`myschema."MyTable"`).
+					WillReturnRows(pgxmock.NewRows([]string{"to_regclass"}).AddRow(nil))
+
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: typeNamespacedName,
+				})
+
+				Expect(err).NotTo(HaveOccurred())
+				for _, poolMock := range pgpoolsMock {
+					if err := poolMock.ExpectationsWereMet(); err != nil {
+						Fail(err.Error())
+					}
+				}
+			})
+		})
+
+		When("another resource claims the privileges of the same grantee on the same object", func() {
+			const otherResourceName = "test-resource-other"
+
+			otherTypeNamespacedName := types.NamespacedName{
+				Name:      otherResourceName,
+				Namespace: "default",
+			}
+
+			BeforeEach(func() {
+				otherResource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresGrant{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      otherResourceName,
+						Namespace: "default",
+						Annotations: map[string]string{
+							utils.OperatorInstanceAnnotationName: "foo",
+						},
+					},
+					Spec: managedpostgresoperatorhoppscalecomv1alpha1.PostgresGrantSpec{
+						Database:   "mydb",
+						ObjectType: postgresql.GrantObjectTypeTable,
+						Identity:   `myschema."MyTable"`,
+						Grantee:    "myrole",
+						Privileges: []managedpostgresoperatorhoppscalecomv1alpha1.PostgresGrantPrivilege{
+							"SELECT",
+						},
+					},
+				}
+				Expect(k8sClient.Create(ctx, otherResource)).To(Succeed())
+			})
+
+			AfterEach(func() {
+				otherResource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresGrant{}
+				err := k8sClient.Get(ctx, otherTypeNamespacedName, otherResource)
+				if err != nil && errors.IsNotFound(err) {
+					return
+				}
+				Expect(err).NotTo(HaveOccurred())
+
+				controllerutil.RemoveFinalizer(otherResource, PostgresGrantFinalizer)
+				Expect(k8sClient.Update(ctx, otherResource)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, otherResource)).To(Succeed())
+			})
+
+			It("should mark the newest resource as in conflict without revoking anything", func() {
+				controllerReconciler := &PostgresGrantReconciler{
+					Client:               k8sClient,
+					Scheme:               k8sClient.Scheme(),
+					PGPools:              pgpools,
+					OperatorInstanceName: "foo",
+				}
+
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: otherTypeNamespacedName,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				otherResource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresGrant{}
+				Expect(k8sClient.Get(ctx, otherTypeNamespacedName, otherResource)).To(Succeed())
+				Expect(otherResource.Finalizers).To(BeEmpty())
+				conflictCondition := meta.FindStatusCondition(otherResource.Status.Conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeConflict)
+				Expect(conflictCondition).NotTo(BeNil())
+				Expect(conflictCondition.Status).To(Equal(metav1.ConditionTrue))
+				Expect(conflictCondition.Message).To(Equal(`privileges of role "myrole" on TABLE myschema."MyTable" in database "mydb" are already managed by the resource "default/test-resource"`))
+
+				for _, poolMock := range pgpoolsMock {
+					if err := poolMock.ExpectationsWereMet(); err != nil {
+						Fail(err.Error())
+					}
+				}
+			})
+
+			It("should leave the privileges to the other resource when the managing resource is deleted", func() {
+				resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresGrant{}
+				Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+				controllerutil.AddFinalizer(resource, PostgresGrantFinalizer)
+				Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+				controllerReconciler := &PostgresGrantReconciler{
+					Client:               k8sClient,
+					Scheme:               k8sClient.Scheme(),
+					PGPools:              pgpools,
+					OperatorInstanceName: "foo",
+				}
+
+				pgpoolsMock["mydb"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetRelationNameSQLStatement))).
+					WithArgs(`myschema."MyTable"`).
+					WillReturnRows(pgxmock.NewRows([]string{"to_regclass"}).AddRow(&objectName))
+
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: typeNamespacedName,
+				})
+
+				Expect(err).NotTo(HaveOccurred())
+				for _, poolMock := range pgpoolsMock {
+					if err := poolMock.ExpectationsWereMet(); err != nil {
+						Fail(err.Error())
+					}
+				}
+
+				Expect(errors.IsNotFound(k8sClient.Get(ctx, typeNamespacedName, resource))).To(BeTrue())
+			})
+		})
+	})
+})
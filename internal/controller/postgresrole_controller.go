@@ -17,24 +17,30 @@ limitations under the License.
 package controller
 
 import (
-	"bytes"
+	"cmp"
 	"context"
+	"crypto/rand"
 	"fmt"
-	"math/rand/v2"
-	"text/template"
+	"math/big"
+	"slices"
+	"strings"
 	"time"
 
 	"golang.org/x/time/rate"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -43,45 +49,105 @@ import (
 	"github.com/hoppscale/managed-postgres-operator/internal/postgresql"
 	"github.com/hoppscale/managed-postgres-operator/internal/utils"
 	"github.com/jackc/pgx/v5"
+	"github.com/robfig/cron/v3"
 )
 
 const PostgresRoleFinalizer = "postgresrole.managed-postgres-operator.hoppscale.com/finalizer"
 
+// defaultLoginRoleGracePeriod is the duration during which the previous login role can still log in after a DualRole rotation
+const defaultLoginRoleGracePeriod = time.Hour
+
 // PostgresRoleReconciler reconciles a PostgresRole object
 type PostgresRoleReconciler struct {
 	client.Client
-	Scheme  *runtime.Scheme
-	logging logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder events.EventRecorder
 
 	RequeueInterval time.Duration
 
 	PGPools              *postgresql.PGPools
 	OperatorInstanceName string
 
-	CacheRolePasswords map[string]string
+	// DryRun only plans the statements changing the server, for every resource
+	DryRun bool
+
+	// passwordHashes avoids verifying the hash of the same password on each reconciliation
+	passwordHashes utils.PasswordHashCache
+}
+
+// postgresRoleReconciliation holds the state of a single reconciliation,
+// so that the concurrent reconciliations don't share it
+type postgresRoleReconciliation struct {
+	*PostgresRoleReconciler
+	logging  logr.Logger
+	eventing eventRecorder
+	plan     *postgresql.DryRunPlan
 }
 
 // +kubebuilder:rbac:groups=managed-postgres-operator.hoppscale.com,resources=postgresroles,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=managed-postgres-operator.hoppscale.com,resources=postgresroles/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=managed-postgres-operator.hoppscale.com,resources=postgresroles/finalizers,verbs=update
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
 func (r *PostgresRoleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	r.logging = log.FromContext(ctx)
+	reconciliation := &postgresRoleReconciliation{
+		PostgresRoleReconciler: r,
+		logging:                log.FromContext(ctx),
+	}
+	return reconciliation.reconcile(ctx, req)
+}
 
+// reconcile runs a single reconciliation of the resource
+func (r *postgresRoleReconciliation) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole{}
 
 	if err := r.Client.Get(ctx, req.NamespacedName, resource); err != nil {
 		return r.Result(client.IgnoreNotFound(err))
 	}
 
+	r.eventing = newEventRecorder(r.Recorder, resource)
+
 	// Skip reconcile if the resource is not managed by this operator
 	if !utils.IsManagedByOperatorInstance(resource.ObjectMeta.Annotations, r.OperatorInstanceName) {
 		return r.Result(nil)
 	}
 
-	rolePassword, err := r.retrieveRolePassword(resource)
+	// The role is managed by the oldest of the resources claiming it
+	claimants, err := r.listClaimants(ctx, resource)
 	if err != nil {
 		return r.Result(err)
 	}
+	if owner := conflictingClaimant(resource, claimants); owner != nil {
+		if !resource.ObjectMeta.DeletionTimestamp.IsZero() {
+			// The role is left to the resource managing it
+			controllerutil.RemoveFinalizer(resource, PostgresRoleFinalizer)
+			return r.Result(r.Update(ctx, resource))
+		}
+		// The resource takes the role over once the resource owning it is deleted
+		managerStatus := owner.(*managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole).Status
+		return r.Conflict(ctx, resource, managerStatus.Owned || managerStatus.Creating, conflictError("role", resource.Spec.Name, owner))
+	}
+
+	// A resource reconciled by a previous version has already taken the role over
+	if !resource.Status.Owned && managedByPreviousVersion(resource.Status.Succeeded, resource.Status.Conditions) {
+		if err := r.recordOwnership(ctx, resource, false); err != nil {
+			return r.Result(err)
+		}
+	}
+
+	pgpools, err := postgresql.GetServerPGPools(r.PGPools, resource.Spec.ServerRef)
+	if err != nil {
+		return r.Failure(ctx, resource, ReasonServerNotReady, err)
+	}
+
+	if utils.IsDryRun(resource.ObjectMeta.Annotations, r.DryRun) {
+		pgpools, r.plan = startDryRun(pgpools, &r.eventing)
+		r.logging = r.logging.WithValues("dryRun", true)
+	}
+
+	rolePassword, err := r.retrieveRolePassword(resource)
+	if err != nil {
+		return r.Failure(ctx, resource, ReasonRetrieveRolePasswordFailed, err)
+	}
 
 	desiredRole := postgresql.Role{
 		Name:        resource.Spec.Name,
@@ -93,16 +159,21 @@ func (r *PostgresRoleReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		Replication: resource.Spec.Replication,
 		BypassRLS:   resource.Spec.BypassRLS,
 		Password:    rolePassword,
+
+		ConnectionLimit: roleConnectionLimit(resource),
+		ValidUntil:      roleValidUntil(resource),
+
+		PasswordEncryption: passwordEncryptionOptions(resource),
 	}
 
-	existingRole, err := postgresql.GetRole(r.PGPools.Default, resource.Spec.Name)
+	existingRole, err := postgresql.GetRole(pgpools.Default, resource.Spec.Name)
 	if err != nil {
-		return r.Result(fmt.Errorf("failed to get role: %s", err))
+		return r.Failure(ctx, resource, ReasonGetRoleFailed, fmt.Errorf("failed to get role: %s", err))
 	}
 
-	operatorRole, err := postgresql.GetRole(r.PGPools.Default, r.PGPools.Default.Config().ConnConfig.User)
+	operatorRole, err := postgresql.GetRole(pgpools.Default, pgpools.Default.Config().ConnConfig.User)
 	if err != nil {
-		return r.Result(fmt.Errorf("failed to get operator's role: %s", err))
+		return r.Failure(ctx, resource, ReasonGetRoleFailed, fmt.Errorf("failed to get operator's role: %s", err))
 	}
 
 	if resource.ObjectMeta.DeletionTimestamp.IsZero() {
@@ -123,11 +194,42 @@ func (r *PostgresRoleReconciler) Reconcile(ctx context.Context, req ctrl.Request
 			return r.Result(nil)
 		}
 
-		err = r.reconcileOnDeletion(existingRole, resource.Spec.KeepOnDelete, resource.Spec.OnDelete)
+		// The role is left to the next resource claiming it, or kept if the resource has never managed it
+		keepOnDelete := resource.Spec.KeepOnDelete || keepsObjectOnDelete(resource.Spec.ManagementPolicy, resource.Status.Owned || resource.Status.Creating)
+		if hasSuccessor(resource, claimants) {
+			r.logging.Info(fmt.Sprintf("Role \"%s\" is claimed by another resource, skipping DROP ROLE", resource.Spec.Name))
+			keepOnDelete = true
+		}
+
+		// The login roles of the DualRole rotation mode are dropped before their group role
+		if r.isDualRolePasswordRotation(resource) && !keepOnDelete {
+			for _, loginRole := range dualRoleLoginRoles(resource.Spec.Name) {
+				existingLoginRole, err := postgresql.GetRole(pgpools.Default, loginRole)
+				if err != nil {
+					return r.Failure(ctx, resource, ReasonGetRoleFailed, fmt.Errorf("failed to get login role: %s", err))
+				}
+
+				err = r.reconcileOnDeletion(pgpools, existingLoginRole, false, resource.Spec.OnDelete)
+				if err != nil {
+					return r.Failure(ctx, resource, ReasonReconcileOnDeletionFailed, err)
+				}
+			}
+		}
+
+		err = r.reconcileOnDeletion(pgpools, existingRole, keepOnDelete, resource.Spec.OnDelete)
 		if err != nil {
-			return r.Result(err)
+			return r.Failure(ctx, resource, ReasonReconcileOnDeletionFailed, err)
+		}
+
+		// A retained Secret isn't deleted along with the resource
+		if resource.Spec.SecretDeletionPolicy == managedpostgresoperatorhoppscalecomv1alpha1.SecretDeletionPolicyRetain {
+			if err := r.releaseRoleSecret(ctx, resource); err != nil {
+				return r.Failure(ctx, resource, ReasonReconcileRoleSecretFailed, err)
+			}
 		}
 
+		r.eventing.Planned(r.plan.Statements())
+
 		// Remove our finalizer from the list and update it.
 		controllerutil.RemoveFinalizer(resource, PostgresRoleFinalizer)
 		if err := r.Update(ctx, resource); err != nil {
@@ -138,45 +240,177 @@ func (r *PostgresRoleReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return r.Result(nil)
 	}
 
+	reason, err := checkManagementPolicy(resource.Spec.ManagementPolicy, "role", resource.Spec.Name, existingRole != nil, resource.Status.Owned || resource.Status.Creating)
+	if err != nil {
+		return r.Failure(ctx, resource, reason, err)
+	}
+
+	// An observed role is only reported as existing
+	if resource.Spec.ManagementPolicy == managedpostgresoperatorhoppscalecomv1alpha1.ManagementPolicyObserve {
+		return r.Success(ctx, resource, false, resource.Status.ActiveLoginRole)
+	}
+
 	//
 	// Creation logic
 	//
 
-	err = r.reconcileOnCreation(operatorRole, existingRole, &desiredRole)
+	// The login roles are named after the role, and would be truncated by PostgreSQL if the role's name is too long
+	if r.isDualRolePasswordRotation(resource) {
+		for _, loginRole := range dualRoleLoginRoles(resource.Spec.Name) {
+			if err := postgresql.ValidateIdentifier(loginRole); err != nil {
+				return r.Failure(ctx, resource, ReasonInvalidSpec, fmt.Errorf("invalid login role of the DualRole password rotation mode: %s", err))
+			}
+		}
+	}
+
+	rotatePassword, err := r.isPasswordRotationDue(resource, time.Now())
 	if err != nil {
+		return r.Failure(ctx, resource, ReasonInvalidSpec, err)
+	}
+	if rotatePassword {
+		desiredRole.Password = r.generatePassword(64)
+	}
+
+	// The Secret points to the role, or to the active login role with the DualRole rotation mode
+	secretRole := desiredRole
+	if r.isDualRolePasswordRotation(resource) {
+		desiredRole.Password = ""
+		secretRole.Name, _ = nextLoginRoles(resource, rotatePassword)
+	}
+
+	passwordHash := cmp.Or(resource.Status.PasswordHash, resource.ObjectMeta.Annotations[utils.PasswordHashAnnotationName])
+
+	// An existing role is adopted before being altered, and the creation of a new one is recorded beforehand
+	if existingRole != nil {
+		if err := r.recordOwnership(ctx, resource, true); err != nil {
+			return r.Result(err)
+		}
+	} else if err := r.recordCreation(ctx, resource); err != nil {
 		return r.Result(err)
 	}
 
-	err = r.reconcileRoleMembership(desiredRole.Name, resource.Spec.MemberOfRoles)
+	// The Secret's data is rendered before the role is created or its password changes, so that an invalid template
+	// can't leave the role and its Secret out of sync. The Secret of a role, or a login role, which doesn't exist yet
+	// is rendered again once it's created, as the templates may query its privileges.
+	var secretData map[string][]byte
+	if resource.Spec.SecretName != "" && r.plan == nil {
+		secretData, err = r.roleSecretData(resource, resource.Spec.SecretTemplate, &secretRole, pgpools.Default, pgpools.Default.Config().ConnConfig)
+		if err != nil {
+			return r.Failure(ctx, resource, ReasonReconcileRoleSecretFailed, err)
+		}
+		if existingRole == nil || r.isDualRolePasswordRotation(resource) {
+			secretData = nil
+		}
+	}
+
+	// A rotated password is stored in the Secret before being set on the role, and the rotation is recorded,
+	// so that a failing step can't lose it: the next reconciliations read it back from the Secret instead of generating another one.
+	// With the DualRole mode, the Secret is only switched to the new login role once its password is set,
+	// as the previous login role stays valid until then.
+	rotationRecorded := false
+	if rotatePassword && secretData != nil && !r.isDualRolePasswordRotation(resource) {
+		if err := r.writeRoleSecret(resource, resource.ObjectMeta.Namespace, resource.Spec.SecretName, secretData); err != nil {
+			return r.Failure(ctx, resource, ReasonReconcileRoleSecretFailed, err)
+		}
+		if err := r.recordPasswordRotation(ctx, resource); err != nil {
+			return r.Result(err)
+		}
+		rotationRecorded = true
+	}
+
+	passwordSynced, err := r.reconcileOnCreation(pgpools, operatorRole, existingRole, &desiredRole, passwordHash)
 	if err != nil {
+		return r.Failure(ctx, resource, ReasonReconcileOnCreationFailed, err)
+	}
+
+	if err := r.recordOwnership(ctx, resource, false); err != nil {
 		return r.Result(err)
 	}
 
-	err = r.reconcileRoleSecret(
-		resource.ObjectMeta.Namespace,
-		resource.Spec.SecretName,
-		resource.Spec.SecretTemplate,
-		&desiredRole,
-		r.PGPools.Default.Config().ConnConfig,
-	)
+	// The next steps of a dry run would read the role, which hasn't been created
+	if r.plan != nil && existingRole == nil {
+		return r.Success(ctx, resource, false, "")
+	}
+
+	err = r.reconcileRoleConfig(pgpools, desiredRole.Name, roleConfigParameters(resource))
 	if err != nil {
-		return r.Result(err)
+		return r.Failure(ctx, resource, ReasonReconcileRoleConfigFailed, err)
+	}
+
+	err = r.reconcileRoleMembership(pgpools, desiredRole.Name, roleMemberships(resource))
+	if err != nil {
+		return r.Failure(ctx, resource, ReasonReconcileRoleMembershipFailed, err)
+	}
+
+	activeLoginRole := ""
+	if r.isDualRolePasswordRotation(resource) {
+		activeLoginRole, passwordSynced, err = r.reconcileLoginRoles(pgpools, operatorRole, resource, secretRole.Password, passwordHash, rotatePassword)
+		if err != nil {
+			return r.Failure(ctx, resource, ReasonReconcileLoginRolesFailed, err)
+		}
+		secretRole.Name = activeLoginRole
 	}
 
-	if !resource.Status.Succeeded {
-		resource.Status.Succeeded = true
-		if err = r.Client.Status().Update(context.Background(), resource); err != nil {
-			return r.Result(fmt.Errorf("failed to update object: %s", err))
+	// A dry run doesn't change the password, nor the Secret containing it.
+	// A hash stored in an annotation by a previous version of the operator is moved to the status.
+	_, legacyPasswordHash := resource.ObjectMeta.Annotations[utils.PasswordHashAnnotationName]
+	if (passwordSynced || legacyPasswordHash) && r.plan == nil {
+		if err := r.updatePasswordHash(ctx, resource, secretRole.Name, secretRole.Password); err != nil {
+			return r.Result(err)
 		}
 	}
 
-	return r.Result(nil)
+	if r.plan == nil {
+		if secretData != nil {
+			err = r.writeRoleSecret(resource, resource.ObjectMeta.Namespace, resource.Spec.SecretName, secretData)
+		} else {
+			err = r.reconcileRoleSecret(
+				resource,
+				resource.ObjectMeta.Namespace,
+				resource.Spec.SecretName,
+				resource.Spec.SecretTemplate,
+				&secretRole,
+				pgpools.Default,
+				pgpools.Default.Config().ConnConfig,
+			)
+		}
+		if err != nil {
+			return r.Failure(ctx, resource, ReasonReconcileRoleSecretFailed, err)
+		}
+	}
+
+	if rotatePassword && r.plan == nil {
+		r.logging.Info("Role's password has been rotated")
+		r.eventing.Normal(EventReasonPasswordRotated, EventActionUpdate, "Password of role \"%s\" has been rotated", secretRole.Name)
+	}
+
+	return r.Success(ctx, resource, rotatePassword && !rotationRecorded, activeLoginRole)
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *PostgresRoleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := indexSpecName(mgr, &managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole{}, func(resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole) string {
+		return resource.Spec.Name
+	})
+	if err != nil {
+		return err
+	}
+
+	err = indexReference(mgr, &managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole{}, PasswordFromSecretField, func(resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole) string {
+		if resource.Spec.PasswordFromSecret == nil {
+			return ""
+		}
+		return resource.Spec.PasswordFromSecret.Name
+	})
+	if err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole{}).
+		// Only the metadata of the Secrets is cached, as their data is read from the API server
+		Owns(&corev1.Secret{}, builder.OnlyMetadata).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.requestsForSecret), builder.OnlyMetadata).
 		Named("postgresrole").
 		WithOptions(controller.Options{
 			RateLimiter: workqueue.NewTypedMaxOfRateLimiter(
@@ -195,8 +429,143 @@ func (r *PostgresRoleReconciler) Result(err error) (ctrl.Result, error) {
 	return ctrl.Result{RequeueAfter: r.RequeueInterval}, nil
 }
 
+// Success marks the resource as ready and synced or reports the statements planned by a dry run, records the password's rotation, then builds the reconciler result
+func (r *postgresRoleReconciliation) Success(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole, passwordRotated bool, activeLoginRole string) (ctrl.Result, error) {
+	status := resource.Status.DeepCopy()
+	status.Succeeded = true
+	status.ObservedGeneration = resource.Generation
+	// The statements planned by a dry run are reported instead of marking the resource as synced
+	status.PlannedStatements = r.plan.Statements()
+	if r.plan != nil {
+		setPlannedConditions(&status.Conditions, resource.Generation, status.PlannedStatements)
+		r.eventing.Planned(status.PlannedStatements)
+	} else {
+		setSucceededConditions(&status.Conditions, resource.Generation)
+	}
+
+	// A dry run doesn't change the password nor the active login role
+	if r.plan == nil {
+		if passwordRotated {
+			now := metav1.Now()
+			status.LastRotationTime = &now
+		}
+		status.ActiveLoginRole = activeLoginRole
+	}
+
+	return r.Result(r.updateStatus(ctx, resource, status))
+}
+
+// Conflict marks the resource as in conflict with the resource managing the same role, then builds the reconciler result
+func (r *postgresRoleReconciliation) Conflict(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole, inheritsOwnership bool, err error) (ctrl.Result, error) {
+	status := resource.Status.DeepCopy()
+	status.Succeeded = false
+	// The role owned by the resource managing it is owned by its successor, which doesn't adopt it
+	if inheritsOwnership {
+		status.Owned = true
+	}
+	status.ObservedGeneration = resource.Generation
+	setConflictConditions(&status.Conditions, resource.Generation, err)
+
+	r.eventing.Warning(ReasonConflict, EventActionReconcile, "%s", err)
+
+	return r.Result(r.updateStatus(ctx, resource, status))
+}
+
+// requestsForSecret returns the requests reconciling the roles whose password is read from the Secret,
+// so that a new password is applied as soon as the Secret is updated
+func (r *PostgresRoleReconciler) requestsForSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	return referencingRequests(ctx, r.Client, &managedpostgresoperatorhoppscalecomv1alpha1.PostgresRoleList{}, PasswordFromSecretField, secret)
+}
+
+// listClaimants returns the resources managed by this operator's instance claiming the same role as the resource
+func (r *PostgresRoleReconciler) listClaimants(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole) ([]client.Object, error) {
+	resources := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresRoleList{}
+	if err := r.List(ctx, resources, client.MatchingFields{SpecNameField: resource.Spec.Name}); err != nil {
+		return nil, fmt.Errorf("failed to list the resources claiming the role: %s", err)
+	}
+
+	claimants := []client.Object{}
+	for i := range resources.Items {
+		claimant := &resources.Items[i]
+		if claimant.Spec.ServerRef == resource.Spec.ServerRef && utils.IsManagedByOperatorInstance(claimant.ObjectMeta.Annotations, r.OperatorInstanceName) {
+			claimants = append(claimants, claimant)
+		}
+	}
+	return claimants, nil
+}
+
+// Failure records the failing step in the resource's conditions, then builds the reconciler result
+func (r *postgresRoleReconciliation) Failure(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole, reason string, err error) (ctrl.Result, error) {
+	status := resource.Status.DeepCopy()
+	status.Succeeded = false
+	status.ObservedGeneration = resource.Generation
+	setFailedConditions(&status.Conditions, resource.Generation, !resource.ObjectMeta.DeletionTimestamp.IsZero(), reason, err)
+
+	r.eventing.Warning(reason, EventActionReconcile, "%s", err)
+
+	if statusErr := r.updateStatus(ctx, resource, status); statusErr != nil {
+		r.logging.Error(statusErr, "failed to update status")
+	}
+
+	return r.Result(err)
+}
+
+// recordPasswordRotation records the time of the password's rotation in the resource's status
+func (r *PostgresRoleReconciler) recordPasswordRotation(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole) error {
+	now := metav1.Now()
+	status := resource.Status.DeepCopy()
+	status.LastRotationTime = &now
+	return r.updateStatus(ctx, resource, status)
+}
+
+// recordOwnership records in the resource's status that the role has been created or adopted by the resource
+func (r *postgresRoleReconciliation) recordOwnership(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole, adopted bool) error {
+	// A dry run neither creates nor adopts the role
+	if resource.Status.Owned || r.plan != nil {
+		return nil
+	}
+
+	// A role found after an interrupted creation has been created by the resource, not adopted
+	if adopted && !resource.Status.Creating {
+		r.logging.Info(fmt.Sprintf("Role \"%s\" has been adopted", resource.Spec.Name))
+		r.eventing.Normal(EventReasonRoleAdopted, EventActionAdopt, "Role \"%s\" has been adopted", resource.Spec.Name)
+	}
+
+	status := resource.Status.DeepCopy()
+	status.Owned = true
+	status.Creating = false
+	return r.updateStatus(ctx, resource, status)
+}
+
+// recordCreation records in the resource's status that the role is about to be created by the resource,
+// so that a role created by a reconciliation which fails to record its ownership is still owned by the resource
+func (r *postgresRoleReconciliation) recordCreation(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole) error {
+	// A dry run doesn't create the role
+	if resource.Status.Owned || r.plan != nil {
+		return nil
+	}
+
+	status := resource.Status.DeepCopy()
+	status.Creating = true
+	return r.updateStatus(ctx, resource, status)
+}
+
+// updateStatus updates the resource's status only if it has changed
+func (r *PostgresRoleReconciler) updateStatus(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole, status *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRoleStatus) error {
+	if equality.Semantic.DeepEqual(resource.Status, *status) {
+		return nil
+	}
+
+	resource.Status = *status
+	if err := r.Client.Status().Update(ctx, resource); err != nil {
+		return fmt.Errorf("failed to update object: %s", err)
+	}
+
+	return nil
+}
+
 // reconcileOnDeletion performs all actions related to deleting the resource
-func (r *PostgresRoleReconciler) reconcileOnDeletion(existingRole *postgresql.Role, keepOnDelete bool, onDeleteOptions *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRoleOnDeleteSpec) (err error) {
+func (r *postgresRoleReconciliation) reconcileOnDeletion(pgpools *postgresql.PGPools, existingRole *postgresql.Role, keepOnDelete bool, onDeleteOptions *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRoleOnDeleteSpec) (err error) {
 	if existingRole == nil {
 		r.logging.Info("Role doesn't exist, skipping DROP ROLE")
 		return nil
@@ -210,61 +579,80 @@ func (r *PostgresRoleReconciler) reconcileOnDeletion(existingRole *postgresql.Ro
 
 	if onDeleteOptions != nil {
 		if onDeleteOptions.ReassignOwnedTo != "" {
-			databases, err := postgresql.ListDatabases(r.PGPools.Default)
+			databases, err := postgresql.ListDatabases(pgpools.Default)
 			if err != nil {
 				return fmt.Errorf("failed to list databases: %s", err)
 			}
 
 			for _, database := range databases {
-				err := postgresql.EnsurePGPoolExists(r.PGPools, database)
+				err := postgresql.EnsurePGPoolExists(pgpools, database)
 				if err != nil {
 					return fmt.Errorf("failed to open pg pool: %s", err)
 				}
 
-				err = postgresql.ReassignOwnedToRole(r.PGPools.Databases[database], existingRole.Name, onDeleteOptions.ReassignOwnedTo)
+				err = postgresql.ReassignOwnedToRole(pgpools.Database(database), existingRole.Name, onDeleteOptions.ReassignOwnedTo)
 				if err != nil {
 					return fmt.Errorf("failed to reassign owned objects in database before deletion: %s", err)
 				}
 			}
 			r.logging.Info(fmt.Sprintf("Objects owned by '%s' have been reassigned to '%s'", existingRole.Name, onDeleteOptions.ReassignOwnedTo))
+			r.eventing.Normal(EventReasonOwnedObjectsReassigned, EventActionReassign, "Objects owned by \"%s\" have been reassigned to \"%s\"", existingRole.Name, onDeleteOptions.ReassignOwnedTo)
 		}
 	}
 
-	err = postgresql.DropRole(r.PGPools.Default, existingRole.Name)
+	err = postgresql.DropRole(pgpools.Default, existingRole.Name)
 	if err != nil {
 		return fmt.Errorf("failed to delete role: %s", err)
 	}
 
 	r.logging.Info("Role has been deleted")
+	r.eventing.Normal(EventReasonRoleDropped, EventActionDrop, "Role \"%s\" has been dropped", existingRole.Name)
 
 	return nil
 }
 
-// reconcileOnCreation performs all actions related to creating the resource
-func (r *PostgresRoleReconciler) reconcileOnCreation(operatorRole, existingRole, desiredRole *postgresql.Role) (err error) {
+// reconcileOnCreation performs all actions related to creating the resource, then returns whether the role's password has been set or verified.
+// The password is only set if it doesn't match the hash of the last password applied by the operator, nor the verifier stored by PostgreSQL.
+func (r *postgresRoleReconciliation) reconcileOnCreation(pgpools *postgresql.PGPools, operatorRole, existingRole, desiredRole *postgresql.Role, passwordHash string) (passwordSynced bool, err error) {
 	if existingRole == nil {
-		err = postgresql.CreateRole(r.PGPools.Default, operatorRole, desiredRole)
+		err = postgresql.CreateRole(pgpools.Default, operatorRole, desiredRole)
 		if err != nil {
 			r.logging.Error(err, "failed to create role")
-			return err
+			return false, err
 		}
 		r.logging.Info("Role has been created")
+		r.eventing.Normal(EventReasonRoleCreated, EventActionCreate, "Role \"%s\" has been created", desiredRole.Name)
 
-		r.CacheRolePasswords[desiredRole.Name] = desiredRole.Password
-
-		return err
+		return desiredRole.Password != "", err
 	}
 
 	needUpdate := false
 
-	// Update the role if the desired role password is different than the one in cache
-	if desiredRole.Password != r.CacheRolePasswords[desiredRole.Name] {
-		needUpdate = true
-		r.logging.Info("Desired role's password and the cached password are different, an update is needed")
+	alteredRole := *desiredRole
+
+	// Update the role if the desired role password is different than the last applied one
+	if desiredRole.Password != "" && !r.passwordHashes.Verify(desiredRole.Name, desiredRole.Password, passwordHash) {
+		passwordSynced = true
+
+		matches, err := r.verifyRolePassword(pgpools, operatorRole, desiredRole)
+		if err != nil {
+			return false, err
+		}
+
+		if matches {
+			alteredRole.Password = ""
+			r.logging.Info("Desired role's password matches the password stored by PostgreSQL, no password update is needed")
+		} else {
+			needUpdate = true
+			r.logging.Info("Desired role's password doesn't match the last applied password, an update is needed")
+		}
+	} else {
+		alteredRole.Password = ""
 	}
 
 	copyDesiredRole := *desiredRole
 	copyDesiredRole.Password = ""
+	copyDesiredRole.PasswordEncryption = postgresql.ScramOptions{}
 
 	// Update the role if the the existing role is different than the desired role
 	if *existingRole != copyDesiredRole {
@@ -273,114 +661,393 @@ func (r *PostgresRoleReconciler) reconcileOnCreation(operatorRole, existingRole,
 	}
 
 	if needUpdate {
-		err = postgresql.AlterRole(r.PGPools.Default, operatorRole, existingRole, desiredRole)
+		err = postgresql.AlterRole(pgpools.Default, operatorRole, existingRole, &alteredRole)
 		if err != nil {
 			r.logging.Error(err, "failed to alter role")
-			return err
+			return false, err
 		}
 		r.logging.Info("Role has been updated")
+		r.eventing.Normal(EventReasonRoleAltered, EventActionAlter, "Role \"%s\" has been altered", desiredRole.Name)
+	}
 
-		r.CacheRolePasswords[desiredRole.Name] = desiredRole.Password
+	return passwordSynced, err
+}
+
+// verifyRolePassword returns whether the desired password matches the verifier stored by PostgreSQL.
+// The verifier can only be read with the SUPERUSER option, otherwise the password is considered different.
+func (r *postgresRoleReconciliation) verifyRolePassword(pgpools *postgresql.PGPools, operatorRole, desiredRole *postgresql.Role) (matches bool, err error) {
+	if !operatorRole.SuperUser {
+		return false, nil
 	}
 
-	return err
+	matches, err = postgresql.VerifyRolePassword(pgpools.Default, desiredRole.Name, desiredRole.Password)
+	if err != nil {
+		r.logging.Error(err, "failed to verify role's password")
+		return false, err
+	}
+
+	return matches, nil
+}
+
+// updatePasswordHash stores the hash of the password applied to the role in the resource's status,
+// so that the password is only set again once it changes, even after a restart of the operator.
+// The hash stored in an annotation by a previous version of the operator is removed.
+func (r *PostgresRoleReconciler) updatePasswordHash(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole, role, password string) error {
+	status := resource.Status.DeepCopy()
+	if password != "" {
+		hash, err := utils.HashPassword(role, password)
+		if err != nil {
+			return fmt.Errorf("failed to hash password: %s", err)
+		}
+		status.PasswordHash = hash
+		r.passwordHashes.Store(role, password, hash)
+	} else if status.PasswordHash == "" {
+		// The generated password of a role without Secret isn't known anymore, only the fact that it has been set
+		status.PasswordHash = resource.ObjectMeta.Annotations[utils.PasswordHashAnnotationName]
+	}
+
+	if err := r.updateStatus(ctx, resource, status); err != nil {
+		return err
+	}
+
+	if _, ok := resource.ObjectMeta.Annotations[utils.PasswordHashAnnotationName]; !ok {
+		return nil
+	}
+	delete(resource.ObjectMeta.Annotations, utils.PasswordHashAnnotationName)
+	return r.Update(ctx, resource)
 }
 
-func (r *PostgresRoleReconciler) reconcileRoleMembership(role string, desiredMembership []string) (err error) {
+// reconcileRoleConfig sets the desired configuration parameters on the role and resets the other ones
+func (r *postgresRoleReconciliation) reconcileRoleConfig(pgpools *postgresql.PGPools, role string, desiredConfig []postgresql.RoleConfigParameter) (err error) {
+	existingConfig, err := postgresql.GetRoleConfig(pgpools.Default, role)
+	if err != nil {
+		r.logging.Error(err, "failed to retrieve role's configuration")
+		return err
+	}
+
+	// Resetting parameters
+	for _, existingParameter := range existingConfig {
+		found := slices.ContainsFunc(desiredConfig, func(desiredParameter postgresql.RoleConfigParameter) bool {
+			return desiredParameter.Database == existingParameter.Database && desiredParameter.Name == existingParameter.Name
+		})
+
+		if !found {
+			err = postgresql.ResetRoleConfigParameter(pgpools.Default, role, existingParameter.Database, existingParameter.Name)
+			if err != nil {
+				r.logging.Error(err, "failed to reset role's configuration parameter")
+				return err
+			}
+			r.logging.Info(fmt.Sprintf("Parameter \"%s\" of role \"%s\" has been reset%s", existingParameter.Name, role, inDatabaseMessage(existingParameter.Database)))
+			r.eventing.Normal(EventReasonRoleConfigReset, EventActionAlter, "Parameter \"%s\" of role \"%s\" has been reset%s", existingParameter.Name, role, inDatabaseMessage(existingParameter.Database))
+		}
+	}
+
+	// Setting parameters
+	for _, desiredParameter := range desiredConfig {
+		found := slices.ContainsFunc(existingConfig, func(existingParameter postgresql.RoleConfigParameter) bool {
+			return existingParameter.Database == desiredParameter.Database &&
+				existingParameter.Name == desiredParameter.Name &&
+				postgresql.ConfigParameterValuesEqual(desiredParameter.Name, existingParameter.Value, desiredParameter.Value)
+		})
+
+		if !found {
+			err = postgresql.SetRoleConfigParameter(pgpools.Default, role, desiredParameter.Database, desiredParameter.Name, desiredParameter.Value)
+			if err != nil {
+				r.logging.Error(err, "failed to set role's configuration parameter")
+				return err
+			}
+			r.logging.Info(fmt.Sprintf("Parameter \"%s\" of role \"%s\" has been set to \"%s\"%s", desiredParameter.Name, role, desiredParameter.Value, inDatabaseMessage(desiredParameter.Database)))
+			r.eventing.Normal(EventReasonRoleConfigSet, EventActionAlter, "Parameter \"%s\" of role \"%s\" has been set to \"%s\"%s", desiredParameter.Name, role, desiredParameter.Value, inDatabaseMessage(desiredParameter.Database))
+		}
+	}
+
+	return nil
+}
+
+func (r *postgresRoleReconciliation) reconcileRoleMembership(pgpools *postgresql.PGPools, role string, desiredMembership []postgresql.RoleMembership) (err error) {
+	serverVersion, err := postgresql.GetServerVersionNum(pgpools.Default)
+	if err != nil {
+		r.logging.Error(err, "failed to retrieve server's version")
+		return err
+	}
+
 	// Listing current membership
-	existingRoleMembership, err := postgresql.GetRoleMembership(r.PGPools.Default, role)
+	existingRoleMembership, err := postgresql.GetRoleMembership(pgpools.Default, role, serverVersion)
 	if err != nil {
 		r.logging.Error(err, "failed to retrieve role's membership")
 		return err
 	}
 
 	// Revoking membership
-	for _, existingGroupRole := range existingRoleMembership {
-		found := false
-		for _, desiredGroupRole := range desiredMembership {
-			if desiredGroupRole == existingGroupRole {
-				found = true
-				break
-			}
-		}
+	for _, existingMembership := range existingRoleMembership {
+		found := slices.ContainsFunc(desiredMembership, func(membership postgresql.RoleMembership) bool {
+			return membership.GroupRole == existingMembership.GroupRole
+		})
 
 		if !found {
-			err = postgresql.RevokeRoleMembership(r.PGPools.Default, existingGroupRole, role)
+			err = postgresql.RevokeRoleMembership(pgpools.Default, existingMembership.GroupRole, role)
 			if err != nil {
 				r.logging.Error(err, "failed to revoke role membership")
 				return err
 			}
-			r.logging.Info(fmt.Sprintf("Role \"%s\" has been revoked from the group \"%s\"", role, existingGroupRole))
+			r.logging.Info(fmt.Sprintf("Role \"%s\" has been revoked from the group \"%s\"", role, existingMembership.GroupRole))
+			r.eventing.Normal(EventReasonRoleMembershipRevoked, EventActionRevoke, "Role \"%s\" has been revoked from the group \"%s\"", role, existingMembership.GroupRole)
 		}
 	}
 
 	// Granting membership
-	for _, desiredGroupRole := range desiredMembership {
-		found := false
-		for _, existingGroupRole := range existingRoleMembership {
-			if desiredGroupRole == existingGroupRole {
-				found = true
-				break
-			}
-		}
+	for _, membership := range desiredMembership {
+		index := slices.IndexFunc(existingRoleMembership, func(existingMembership postgresql.RoleMembership) bool {
+			return existingMembership.GroupRole == membership.GroupRole
+		})
 
-		if !found {
-			err = postgresql.GrantRoleMembership(r.PGPools.Default, desiredGroupRole, role)
+		if index == -1 {
+			err = postgresql.GrantRoleMembership(pgpools.Default, membership, role, serverVersion)
 			if err != nil {
 				r.logging.Error(err, "failed to grant role membership")
 				return err
 			}
-			r.logging.Info(fmt.Sprintf("Role \"%s\" has been granted to the group \"%s\"", role, desiredGroupRole))
+			r.logging.Info(fmt.Sprintf("Role \"%s\" has been granted to the group \"%s\"", role, membership.GroupRole))
+			r.eventing.Normal(EventReasonRoleMembershipGranted, EventActionGrant, "Role \"%s\" has been granted to the group \"%s\"", role, membership.GroupRole)
+			continue
+		}
+
+		existingMembership := existingRoleMembership[index]
+
+		// Below PostgreSQL 16, INHERIT and SET aren't options of the membership, so only ADMIN can drift
+		if serverVersion < postgresql.MembershipOptionsMinServerVersion {
+			if existingMembership.Admin == membership.Admin {
+				continue
+			}
+			if membership.Admin {
+				err = postgresql.GrantRoleMembership(pgpools.Default, membership, role, serverVersion)
+			} else {
+				err = postgresql.RevokeRoleMembershipAdminOption(pgpools.Default, membership.GroupRole, role)
+			}
+		} else {
+			if existingMembership == membership {
+				continue
+			}
+			err = postgresql.GrantRoleMembership(pgpools.Default, membership, role, serverVersion)
 		}
+		if err != nil {
+			r.logging.Error(err, "failed to update role membership's options")
+			return err
+		}
+		r.logging.Info(fmt.Sprintf("Options of the membership of role \"%s\" in the group \"%s\" have been updated", role, membership.GroupRole))
+		r.eventing.Normal(EventReasonRoleMembershipUpdated, EventActionGrant, "Options of the membership of role \"%s\" in the group \"%s\" have been updated", role, membership.GroupRole)
 	}
 
 	return err
 }
 
-func (r *PostgresRoleReconciler) reconcileRoleSecret(secretNamespace, secretName string, secretTemplate map[string]string, role *postgresql.Role, pgConfig *pgx.ConnConfig) (err error) {
+// roleMemberships returns the group roles the role must be a member of, with the options of each membership.
+// The INHERIT option defaults to the role's one, as PostgreSQL does.
+func roleMemberships(resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole) []postgresql.RoleMembership {
+	memberships := []postgresql.RoleMembership{}
+
+	for _, groupRole := range resource.Spec.MemberOfRoles {
+		hasOptions := slices.ContainsFunc(resource.Spec.Memberships, func(membership managedpostgresoperatorhoppscalecomv1alpha1.PostgresRoleMembership) bool {
+			return membership.Role == groupRole
+		})
+		if hasOptions {
+			continue
+		}
+
+		memberships = append(memberships, postgresql.RoleMembership{
+			GroupRole: groupRole,
+			Inherit:   resource.Spec.Inherit,
+			Set:       true,
+		})
+	}
+
+	for _, membership := range resource.Spec.Memberships {
+		desiredMembership := postgresql.RoleMembership{
+			GroupRole: membership.Role,
+			Admin:     membership.Admin,
+			Inherit:   resource.Spec.Inherit,
+			Set:       true,
+		}
+		if membership.Inherit != nil {
+			desiredMembership.Inherit = *membership.Inherit
+		}
+		if membership.Set != nil {
+			desiredMembership.Set = *membership.Set
+		}
+		memberships = append(memberships, desiredMembership)
+	}
+
+	return memberships
+}
+
+// reconcileLoginRoles performs all actions related to the login roles of the DualRole password rotation mode,
+// then returns the active one and whether its password has been set or verified.
+// On rotation, the inactive login role receives the new password and becomes active, while the previous one expires after the grace period.
+func (r *postgresRoleReconciliation) reconcileLoginRoles(pgpools *postgresql.PGPools, operatorRole *postgresql.Role, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole, password, passwordHash string, rotatePassword bool) (activeLoginRole string, passwordSynced bool, err error) {
+	loginRoles := dualRoleLoginRoles(resource.Spec.Name)
+	activeLoginRole, previousLoginRole := nextLoginRoles(resource, rotatePassword)
+
+	for _, loginRole := range loginRoles {
+		existingLoginRole, err := postgresql.GetRole(pgpools.Default, loginRole)
+		if err != nil {
+			return "", false, fmt.Errorf("failed to get login role: %s", err)
+		}
+
+		desiredLoginRole := postgresql.Role{
+			Name:    loginRole,
+			Inherit: true,
+			Login:   true,
+
+			ConnectionLimit: roleConnectionLimit(resource),
+
+			PasswordEncryption: passwordEncryptionOptions(resource),
+		}
+
+		// Only the active login role receives the password, the other one keeps its own until it expires
+		if loginRole == activeLoginRole {
+			desiredLoginRole.Password = password
+			passwordSynced, err = r.reconcileOnCreation(pgpools, operatorRole, existingLoginRole, &desiredLoginRole, passwordHash)
+		} else if existingLoginRole == nil {
+			_, err = r.reconcileOnCreation(pgpools, operatorRole, nil, &desiredLoginRole, "")
+		}
+		if err != nil {
+			return "", false, err
+		}
+
+		err = r.reconcileRoleConfig(pgpools, loginRole, roleConfigParameters(resource))
+		if err != nil {
+			return "", false, err
+		}
+
+		err = r.reconcileRoleMembership(pgpools, loginRole, []postgresql.RoleMembership{
+			{GroupRole: resource.Spec.Name, Inherit: desiredLoginRole.Inherit, Set: true},
+		})
+		if err != nil {
+			return "", false, err
+		}
+	}
+
+	if activeLoginRole == resource.Status.ActiveLoginRole {
+		return activeLoginRole, passwordSynced, nil
+	}
+
+	err = postgresql.SetRoleValidUntil(pgpools.Default, activeLoginRole, nil)
+	if err != nil {
+		r.logging.Error(err, "failed to remove the login role's expiration")
+		return "", false, err
+	}
+
+	if previousLoginRole != "" {
+		gracePeriod := defaultLoginRoleGracePeriod
+		if resource.Spec.PasswordRotation.GracePeriod != nil {
+			gracePeriod = resource.Spec.PasswordRotation.GracePeriod.Duration
+		}
+		expiration := time.Now().Add(gracePeriod)
+
+		err = postgresql.SetRoleValidUntil(pgpools.Default, previousLoginRole, &expiration)
+		if err != nil {
+			r.logging.Error(err, "failed to expire the previous login role")
+			return "", false, err
+		}
+		r.logging.Info(fmt.Sprintf("Login role \"%s\" is now active, \"%s\" expires at %s", activeLoginRole, previousLoginRole, expiration.UTC().Format(time.RFC3339)))
+		r.eventing.Normal(EventReasonLoginRoleSwitched, EventActionAlter, "Login role \"%s\" is now active, \"%s\" expires at %s", activeLoginRole, previousLoginRole, expiration.UTC().Format(time.RFC3339))
+	}
+
+	return activeLoginRole, passwordSynced, nil
+}
+
+// nextLoginRoles returns the login role which is active with the DualRole rotation mode,
+// and the previously active one if the password is rotated
+func nextLoginRoles(resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole, rotatePassword bool) (activeLoginRole, previousLoginRole string) {
+	loginRoles := dualRoleLoginRoles(resource.Spec.Name)
+
+	activeLoginRole = resource.Status.ActiveLoginRole
+	switch {
+	case !slices.Contains(loginRoles, activeLoginRole):
+		activeLoginRole = loginRoles[0]
+	case rotatePassword:
+		previousLoginRole = activeLoginRole
+		activeLoginRole = loginRoles[0]
+		if previousLoginRole == loginRoles[0] {
+			activeLoginRole = loginRoles[1]
+		}
+	}
+
+	return activeLoginRole, previousLoginRole
+}
+
+// reconcileRoleSecret creates or restores the Secret containing the role's connection information.
+// A Secret created by the operator is controlled by the resource, so that its changes are reconciled and it is deleted along with the resource.
+// An existing Secret is updated but never owned, as it may have been created by the user.
+func (r *postgresRoleReconciliation) reconcileRoleSecret(owner *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole, secretNamespace, secretName string, secretTemplate map[string]string, role *postgresql.Role, pgpool postgresql.PGPoolInterface, pgConfig *pgx.ConnConfig) (err error) {
 	// Do not create Secret if no name provided by the user
 	if secretName == "" {
 		return err
 	}
 
-	secretNamespacedName := types.NamespacedName{
-		Namespace: secretNamespace,
-		Name:      secretName,
+	desiredSecretData, err := r.roleSecretData(owner, secretTemplate, role, pgpool, pgConfig)
+	if err != nil {
+		return err
 	}
 
-	resourceSecret := &corev1.Secret{}
+	return r.writeRoleSecret(owner, secretNamespace, secretName, desiredSecretData)
+}
 
-	secretDataTemplateVars := struct {
-		Role     string
-		Password string
-		Host     string
-		Port     string
-		Database string
-	}{
-		Role:     role.Name,
-		Password: role.Password,
+// roleSecretData returns the data of the Secret containing the role's connection information, with the rendered templates
+func (r *PostgresRoleReconciler) roleSecretData(owner *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole, secretTemplate map[string]string, role *postgresql.Role, pgpool postgresql.PGPoolInterface, pgConfig *pgx.ConnConfig) (map[string][]byte, error) {
+	connectionInfo := postgresql.ConnectionInfo{
 		Host:     pgConfig.Host,
-		Port:     fmt.Sprintf("%d", pgConfig.Port),
+		Port:     pgConfig.Port,
 		Database: pgConfig.Database,
+		User:     role.Name,
+		Password: role.Password,
+		SSLMode:  postgresql.SSLMode(&pgConfig.Config),
 	}
 
 	desiredSecretData := map[string][]byte{
-		"PGUSER":     []byte(secretDataTemplateVars.Role),
-		"PGPASSWORD": []byte(secretDataTemplateVars.Password),
-		"PGHOST":     []byte(secretDataTemplateVars.Host),
-		"PGPORT":     []byte(secretDataTemplateVars.Port),
-		"PGDATABASE": []byte(secretDataTemplateVars.Database),
+		"PGUSER":     []byte(connectionInfo.User),
+		"PGPASSWORD": []byte(connectionInfo.Password),
+		"PGHOST":     []byte(connectionInfo.Host),
+		"PGPORT":     []byte(fmt.Sprintf("%d", connectionInfo.Port)),
+		"PGDATABASE": []byte(connectionInfo.Database),
 	}
 
-	for secretKey, secretValue := range secretTemplate {
-		t := template.Must(template.New("secret").Parse(secretValue))
-		var tpl bytes.Buffer
-		if err := t.Execute(&tpl, secretDataTemplateVars); err != nil {
-			return fmt.Errorf("failed to render secret template: %s", err)
-		}
-		desiredSecretData[secretKey] = tpl.Bytes()
+	for secretKey, secretValue := range connectionStringsData(owner.Spec.SecretFormats, connectionInfo) {
+		desiredSecretData[secretKey] = secretValue
+	}
+
+	renderedSecretData, err := renderSecretTemplate(secretTemplate, secretTemplateData{
+		Role:      connectionInfo.User,
+		Password:  connectionInfo.Password,
+		Host:      connectionInfo.Host,
+		Port:      fmt.Sprintf("%d", connectionInfo.Port),
+		Database:  connectionInfo.Database,
+		SSLMode:   connectionInfo.SSLMode,
+		Namespace: owner.ObjectMeta.Namespace,
+		Name:      owner.ObjectMeta.Name,
+		Labels:    owner.ObjectMeta.Labels,
+		pgpool:    pgpool,
+		pgConfig:  pgConfig,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for secretKey, secretValue := range renderedSecretData {
+		desiredSecretData[secretKey] = secretValue
+	}
+
+	return desiredSecretData, nil
+}
+
+// writeRoleSecret creates or updates the Secret with the given data
+func (r *postgresRoleReconciliation) writeRoleSecret(owner *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole, secretNamespace, secretName string, desiredSecretData map[string][]byte) (err error) {
+	secretNamespacedName := types.NamespacedName{
+		Namespace: secretNamespace,
+		Name:      secretName,
 	}
 
+	resourceSecret := &corev1.Secret{}
+
 	// Retrieve Secret
 	err = r.Client.Get(context.Background(), secretNamespacedName, resourceSecret)
 	if err != nil && !errors.IsNotFound(err) {
@@ -401,12 +1068,17 @@ func (r *PostgresRoleReconciler) reconcileRoleSecret(secretNamespace, secretName
 			Data: desiredSecretData,
 		}
 
+		if err := controllerutil.SetControllerReference(owner, resourceSecret, r.Scheme); err != nil {
+			return fmt.Errorf("failed to set secret's owner: %s", err)
+		}
+
 		err = r.Client.Create(context.Background(), resourceSecret)
 		if err != nil {
 			return fmt.Errorf("failed to create secret: %s", err)
 		}
 
 		r.logging.Info("Role's secret has been created")
+		r.eventing.Normal(EventReasonSecretCreated, EventActionCreate, "Secret \"%s\" has been created", secretName)
 
 		return err
 	}
@@ -432,24 +1104,170 @@ func (r *PostgresRoleReconciler) reconcileRoleSecret(secretNamespace, secretName
 			return fmt.Errorf("failed to update secret: %s", err)
 		}
 		r.logging.Info("Role's secret has been updated")
+		r.eventing.Normal(EventReasonSecretUpdated, EventActionUpdate, "Secret \"%s\" has been updated", secretName)
 	}
 
 	return err
 }
 
+// connectionStringsData returns the keys of the Secret containing the connection strings in the given formats
+func connectionStringsData(formats []string, connectionInfo postgresql.ConnectionInfo) map[string][]byte {
+	data := map[string][]byte{}
+	for _, format := range formats {
+		switch format {
+		case managedpostgresoperatorhoppscalecomv1alpha1.SecretFormatURI:
+			data["DATABASE_URL"] = []byte(connectionInfo.URI())
+		case managedpostgresoperatorhoppscalecomv1alpha1.SecretFormatJDBC:
+			data["JDBC_URL"] = []byte(connectionInfo.JDBCURL())
+		case managedpostgresoperatorhoppscalecomv1alpha1.SecretFormatADONET:
+			data["ADONET_CONNECTION_STRING"] = []byte(connectionInfo.ADONETConnectionString())
+		case managedpostgresoperatorhoppscalecomv1alpha1.SecretFormatPgPass:
+			data[".pgpass"] = []byte(connectionInfo.PgPassLine())
+		case managedpostgresoperatorhoppscalecomv1alpha1.SecretFormatPgService:
+			data["pg_service.conf"] = []byte(connectionInfo.PgServiceStanza(connectionInfo.User))
+		}
+	}
+	return data
+}
+
+// releaseRoleSecret removes the resource from the owners of its Secret, so that the Secret isn't deleted along with the resource
+func (r *postgresRoleReconciliation) releaseRoleSecret(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole) error {
+	if resource.Spec.SecretName == "" {
+		return nil
+	}
+
+	resourceSecret := &corev1.Secret{}
+	err := r.Client.Get(ctx, types.NamespacedName{Namespace: resource.ObjectMeta.Namespace, Name: resource.Spec.SecretName}, resourceSecret)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to retrieve secret: %s", err)
+	}
+
+	if !metav1.IsControlledBy(resourceSecret, resource) {
+		return nil
+	}
+
+	if err := controllerutil.RemoveOwnerReference(resource, resourceSecret, r.Scheme); err != nil {
+		return fmt.Errorf("failed to remove secret's owner: %s", err)
+	}
+
+	if err := r.Client.Update(ctx, resourceSecret); err != nil {
+		return fmt.Errorf("failed to update secret: %s", err)
+	}
+
+	r.logging.Info("Role's secret has been retained")
+	return nil
+}
+
+// generatePassword returns a random alphanumeric password drawn from a cryptographically secure source
 func (r *PostgresRoleReconciler) generatePassword(length int) (password string) {
-	// Generate password
 	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
-	random := rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
 
 	result := make([]byte, length)
 	for i := range result {
-		result[i] = charset[random.IntN(len(charset))]
+		// crypto/rand's reader never fails, it crashes the program instead
+		index, _ := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
+		result[i] = charset[index.Int64()]
 	}
 
 	return string(result)
 }
 
+// isDualRolePasswordRotation returns whether the role's password is rotated with the DualRole mode
+func (r *PostgresRoleReconciler) isDualRolePasswordRotation(resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole) bool {
+	return resource.Spec.PasswordRotation != nil && resource.Spec.PasswordRotation.Mode == managedpostgresoperatorhoppscalecomv1alpha1.PasswordRotationModeDualRole
+}
+
+// roleConnectionLimit returns the role's maximum number of concurrent connections, -1 if there is no limit
+func roleConnectionLimit(resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole) int32 {
+	if resource.Spec.ConnectionLimit == nil {
+		return -1
+	}
+	return *resource.Spec.ConnectionLimit
+}
+
+// roleValidUntil returns the RFC 3339 date after which the role's password is no longer valid, or an empty string if it never expires
+func roleValidUntil(resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole) string {
+	if resource.Spec.ValidUntil == nil {
+		return ""
+	}
+	return resource.Spec.ValidUntil.UTC().Format(time.RFC3339)
+}
+
+// roleConfigParameters returns the configuration parameters of the role's sessions, sorted by database and name.
+// Parameters' names are case insensitive and stored in lower case by PostgreSQL.
+func roleConfigParameters(resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole) []postgresql.RoleConfigParameter {
+	parameters := []postgresql.RoleConfigParameter{}
+	for name, value := range resource.Spec.Config {
+		parameters = append(parameters, postgresql.RoleConfigParameter{Name: strings.ToLower(name), Value: value})
+	}
+	for database, config := range resource.Spec.DatabaseConfig {
+		for name, value := range config {
+			parameters = append(parameters, postgresql.RoleConfigParameter{Database: database, Name: strings.ToLower(name), Value: value})
+		}
+	}
+
+	slices.SortFunc(parameters, func(a, b postgresql.RoleConfigParameter) int {
+		return cmp.Or(cmp.Compare(a.Database, b.Database), cmp.Compare(a.Name, b.Name))
+	})
+
+	return parameters
+}
+
+// inDatabaseMessage returns the database part of the messages related to the role's configuration parameters
+func inDatabaseMessage(database string) string {
+	if database == "" {
+		return ""
+	}
+	return fmt.Sprintf(" in database \"%s\"", database)
+}
+
+// passwordEncryptionOptions returns the parameters of the SCRAM-SHA-256 verifiers of the role's passwords
+func passwordEncryptionOptions(resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole) postgresql.ScramOptions {
+	if resource.Spec.PasswordEncryption == nil {
+		return postgresql.ScramOptions{}
+	}
+
+	return postgresql.ScramOptions{
+		Iterations: int(resource.Spec.PasswordEncryption.Iterations),
+		SaltLength: int(resource.Spec.PasswordEncryption.SaltLength),
+	}
+}
+
+// dualRoleLoginRoles returns the names of the login roles of a group role with the DualRole password rotation mode
+func dualRoleLoginRoles(groupRole string) []string {
+	return []string{groupRole + "_a", groupRole + "_b"}
+}
+
+// isPasswordRotationDue returns whether the role's password must be rotated.
+// The schedule starts from the last rotation, or from the resource's creation if the password has never been rotated.
+func (r *PostgresRoleReconciler) isPasswordRotationDue(resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole, now time.Time) (bool, error) {
+	passwordRotation := resource.Spec.PasswordRotation
+	if passwordRotation == nil {
+		return false, nil
+	}
+
+	lastRotationTime := resource.ObjectMeta.CreationTimestamp.Time
+	if resource.Status.LastRotationTime != nil {
+		lastRotationTime = resource.Status.LastRotationTime.Time
+	}
+
+	if passwordRotation.Interval != nil {
+		if passwordRotation.Interval.Duration <= 0 {
+			return false, fmt.Errorf("password rotation interval must be positive")
+		}
+		return !now.Before(lastRotationTime.Add(passwordRotation.Interval.Duration)), nil
+	}
+
+	schedule, err := cron.ParseStandard(passwordRotation.Schedule)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse password rotation schedule: %s", err)
+	}
+	return !now.Before(schedule.Next(lastRotationTime)), nil
+}
+
 func (r *PostgresRoleReconciler) retrieveRolePassword(resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole) (password string, err error) {
 	// Retrieve password from user-provided Secret
 	if resource.Spec.PasswordFromSecret != nil {
@@ -496,9 +1314,10 @@ func (r *PostgresRoleReconciler) retrieveRolePassword(resource *managedpostgreso
 		}
 	}
 
-	// Retrieve password from cache
-	if val, ok := r.CacheRolePasswords[resource.Spec.Name]; ok {
-		return val, nil
+	// Keep the password already set by the operator, as it isn't stored anywhere else
+	_, legacyPasswordHash := resource.ObjectMeta.Annotations[utils.PasswordHashAnnotationName]
+	if (resource.Status.PasswordHash != "" || legacyPasswordHash) && resource.Spec.SecretName == "" {
+		return "", nil
 	}
 
 	// Generate a new password if an existing one cannot be retrieve
@@ -17,18 +17,22 @@ limitations under the License.
 package controller
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"slices"
 	"time"
 
 	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -43,51 +47,119 @@ const PostgresSchemaFinalizer = "postgresschema.managed-postgres-operator.hoppsc
 // PostgresSchemaReconciler reconciles a PostgresSchema object
 type PostgresSchemaReconciler struct {
 	client.Client
-	Scheme  *runtime.Scheme
-	logging logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder events.EventRecorder
 
 	RequeueInterval time.Duration
 
 	PGPools              *postgresql.PGPools
 	OperatorInstanceName string
+
+	// DryRun only plans the statements changing the server, for every resource
+	DryRun bool
+}
+
+// postgresSchemaReconciliation holds the state of a single reconciliation,
+// so that the concurrent reconciliations don't share it
+type postgresSchemaReconciliation struct {
+	*PostgresSchemaReconciler
+	logging  logr.Logger
+	eventing eventRecorder
+	plan     *postgresql.DryRunPlan
 }
 
 // +kubebuilder:rbac:groups=managed-postgres-operator.hoppscale.com,resources=postgresschemas,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=managed-postgres-operator.hoppscale.com,resources=postgresschemas/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=managed-postgres-operator.hoppscale.com,resources=postgresschemas/finalizers,verbs=update
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
 func (r *PostgresSchemaReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	r.logging = log.FromContext(ctx)
+	reconciliation := &postgresSchemaReconciliation{
+		PostgresSchemaReconciler: r,
+		logging:                  log.FromContext(ctx),
+	}
+	return reconciliation.reconcile(ctx, req)
+}
 
+// reconcile runs a single reconciliation of the resource
+func (r *postgresSchemaReconciliation) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchema{}
 
 	if err := r.Client.Get(ctx, req.NamespacedName, resource); err != nil {
 		return r.Result(client.IgnoreNotFound(err))
 	}
 
+	r.eventing = newEventRecorder(r.Recorder, resource)
+
 	// Skip reconcile if the resource is not managed by this operator
 	if !utils.IsManagedByOperatorInstance(resource.ObjectMeta.Annotations, r.OperatorInstanceName) {
 		return r.Result(nil)
 	}
 
-	err := postgresql.EnsurePGPoolExists(r.PGPools, resource.Spec.Database)
+	// The references to other resources are resolved on each reconciliation, once they are ready.
+	// The spec is left as written by the user, the resolved names are recorded in the status
+	// so that the schema can be dropped from its database once the resource is being deleted.
+	database, owner := schemaDatabase(resource), resource.Status.Owner
+	if resource.ObjectMeta.DeletionTimestamp.IsZero() {
+		var err error
+		database, owner, err = r.resolveReferences(ctx, resource)
+		if err != nil {
+			if isDependencyError(err) {
+				return r.Waiting(ctx, resource, err)
+			}
+			return r.Failure(ctx, resource, ReasonResolveReferenceFailed, err)
+		}
+
+		status := resource.Status.DeepCopy()
+		status.Database = database
+		status.Owner = owner
+		if err := r.updateStatus(ctx, resource, status); err != nil {
+			return r.Result(err)
+		}
+	}
+
+	// The schema is managed by the oldest of the resources claiming it
+	claimants, err := r.listClaimants(ctx, resource, database)
 	if err != nil {
-		r.logging.Error(err, "failed to open pg pool")
 		return r.Result(err)
 	}
+	if manager := conflictingClaimant(resource, claimants); manager != nil {
+		if !resource.ObjectMeta.DeletionTimestamp.IsZero() {
+			// The schema is left to the resource managing it
+			controllerutil.RemoveFinalizer(resource, PostgresSchemaFinalizer)
+			return r.Result(r.Update(ctx, resource))
+		}
+		return r.Conflict(ctx, resource, conflictError("schema", fmt.Sprintf("%s.%s", database, resource.Spec.Name), manager))
+	}
+
+	pgpools, err := postgresql.GetServerPGPools(r.PGPools, resource.Spec.ServerRef)
+	if err != nil {
+		return r.Failure(ctx, resource, ReasonServerNotReady, err)
+	}
+
+	if utils.IsDryRun(resource.ObjectMeta.Annotations, r.DryRun) {
+		pgpools, r.plan = startDryRun(pgpools, &r.eventing)
+		r.logging = r.logging.WithValues("dryRun", true)
+	}
+
+	err = postgresql.EnsurePGPoolExists(pgpools, database)
+	if err != nil {
+		r.logging.Error(err, "failed to open pg pool")
+		return r.Failure(ctx, resource, ReasonOpenPoolFailed, err)
+	}
 
-	existingSchema, err := postgresql.GetSchema(r.PGPools.Databases[resource.Spec.Database], resource.Spec.Name)
+	existingSchema, err := postgresql.GetSchema(pgpools.Database(database), resource.Spec.Name)
 	if err != nil {
-		return r.Result(fmt.Errorf("failed to retrieve schema: %s", err))
+		return r.Failure(ctx, resource, ReasonGetSchemaFailed, fmt.Errorf("failed to retrieve schema: %s", err))
 	}
 
 	if existingSchema != nil {
-		existingSchema.Database = resource.Spec.Database
+		existingSchema.Database = database
 	}
 
 	desiredSchema := postgresql.Schema{
-		Database: resource.Spec.Database,
+		Database: database,
 		Name:     resource.Spec.Name,
-		Owner:    resource.Spec.Owner,
+		Owner:    owner,
 	}
 
 	if resource.ObjectMeta.DeletionTimestamp.IsZero() {
@@ -108,11 +180,20 @@ func (r *PostgresSchemaReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 			return r.Result(nil)
 		}
 
-		err = r.reconcileOnDeletion(existingSchema, resource.Spec.KeepOnDelete)
+		// The schema is left to the next resource claiming it
+		keepOnDelete := resource.Spec.KeepOnDelete
+		if hasSuccessor(resource, claimants) {
+			r.logging.Info(fmt.Sprintf("Schema \"%s\" is claimed by another resource, skipping DROP SCHEMA", resource.Spec.Name))
+			keepOnDelete = true
+		}
+
+		err = r.reconcileOnDeletion(pgpools, existingSchema, keepOnDelete)
 		if err != nil {
-			return r.Result(err)
+			return r.Failure(ctx, resource, ReasonReconcileOnDeletionFailed, err)
 		}
 
+		r.eventing.Planned(r.plan.Statements())
+
 		// Remove our finalizer from the list and update it.
 		controllerutil.RemoveFinalizer(resource, PostgresSchemaFinalizer)
 		if err := r.Update(ctx, resource); err != nil {
@@ -127,38 +208,97 @@ func (r *PostgresSchemaReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	// Creation logic
 	//
 
-	err = r.reconcileOnCreation(existingSchema, &desiredSchema)
+	err = r.reconcileOnCreation(pgpools, existingSchema, &desiredSchema)
 	if err != nil {
-		return r.Result(err)
+		return r.Failure(ctx, resource, ReasonReconcileOnCreationFailed, err)
 	}
 
-	if !resource.Status.Succeeded {
-		resource.Status.Succeeded = true
-		if err = r.Client.Status().Update(context.Background(), resource); err != nil {
-			return r.Result(fmt.Errorf("failed to update object: %s", err))
-		}
+	// The next steps of a dry run would read the schema, which hasn't been created
+	if r.plan != nil && existingSchema == nil {
+		return r.Success(ctx, resource)
 	}
 
 	for roleName, rolePrivileges := range resource.Spec.PrivilegesByRole {
 		err = r.reconcilePrivileges(
+			pgpools,
 			desiredSchema.Database,
 			desiredSchema.Name,
 			roleName,
 			r.convertPrivilegesSpecToList(rolePrivileges),
 		)
 		if err != nil {
-			return r.Result(err)
+			return r.Failure(ctx, resource, ReasonReconcilePrivilegesFailed, err)
+		}
+	}
+
+	for roleName, rolePrivileges := range resource.Spec.PrivilegesByRole {
+		for _, objectType := range postgresql.ListSchemaObjectTypes() {
+			desiredPrivileges, managed := r.convertObjectPrivilegesSpecToList(rolePrivileges, objectType)
+			if !managed {
+				continue
+			}
+
+			err = r.reconcileObjectPrivileges(
+				pgpools,
+				desiredSchema.Database,
+				desiredSchema.Name,
+				roleName,
+				objectType,
+				desiredPrivileges,
+			)
+			if err != nil {
+				return r.Failure(ctx, resource, ReasonReconcileObjectPrivilegesFailed, err)
+			}
 		}
 	}
 
-	return r.Result(nil)
+	for _, defaultPrivileges := range resource.Spec.DefaultPrivileges {
+		for _, objectType := range postgresql.ListDefaultPrivilegesObjectTypes() {
+			err = r.reconcileDefaultPrivileges(
+				pgpools,
+				desiredSchema.Database,
+				desiredSchema.Name,
+				defaultPrivileges.Grantor,
+				defaultPrivileges.Grantee,
+				objectType,
+				r.convertDefaultPrivilegesSpecToList(defaultPrivileges, objectType),
+			)
+			if err != nil {
+				return r.Failure(ctx, resource, ReasonReconcileDefaultPrivilegesFailed, err)
+			}
+		}
+	}
 
+	return r.Success(ctx, resource)
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *PostgresSchemaReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := indexSpecName(mgr, &managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchema{}, func(resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchema) string {
+		return resource.Spec.Name
+	})
+	if err != nil {
+		return err
+	}
+
+	err = indexReference(mgr, &managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchema{}, DatabaseRefField, func(resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchema) string {
+		return resource.Spec.DatabaseRef
+	})
+	if err != nil {
+		return err
+	}
+
+	err = indexReference(mgr, &managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchema{}, OwnerRefField, func(resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchema) string {
+		return resource.Spec.OwnerRef
+	})
+	if err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchema{}).
+		Watches(&managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase{}, handler.EnqueueRequestsFromMapFunc(r.requestsForDatabase)).
+		Watches(&managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole{}, handler.EnqueueRequestsFromMapFunc(r.requestsForRole)).
 		Named("postgresschema").
 		WithOptions(controller.Options{
 			RateLimiter: workqueue.NewTypedMaxOfRateLimiter(
@@ -177,8 +317,129 @@ func (r *PostgresSchemaReconciler) Result(err error) (ctrl.Result, error) {
 	return ctrl.Result{RequeueAfter: r.RequeueInterval}, nil
 }
 
+// Success marks the resource as ready and synced or reports the statements planned by a dry run, then builds the reconciler result
+func (r *postgresSchemaReconciliation) Success(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchema) (ctrl.Result, error) {
+	status := resource.Status.DeepCopy()
+	status.Succeeded = true
+	status.ObservedGeneration = resource.Generation
+	// The statements planned by a dry run are reported instead of marking the resource as synced
+	status.PlannedStatements = r.plan.Statements()
+	if r.plan != nil {
+		setPlannedConditions(&status.Conditions, resource.Generation, status.PlannedStatements)
+		r.eventing.Planned(status.PlannedStatements)
+	} else {
+		setSucceededConditions(&status.Conditions, resource.Generation)
+	}
+
+	return r.Result(r.updateStatus(ctx, resource, status))
+}
+
+// Conflict marks the resource as in conflict with the resource managing the same schema, then builds the reconciler result
+func (r *postgresSchemaReconciliation) Conflict(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchema, err error) (ctrl.Result, error) {
+	status := resource.Status.DeepCopy()
+	status.Succeeded = false
+	status.ObservedGeneration = resource.Generation
+	setConflictConditions(&status.Conditions, resource.Generation, err)
+
+	r.eventing.Warning(ReasonConflict, EventActionReconcile, "%s", err)
+
+	return r.Result(r.updateStatus(ctx, resource, status))
+}
+
+// listClaimants returns the resources managed by this operator's instance claiming the same schema as the resource
+func (r *PostgresSchemaReconciler) listClaimants(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchema, database string) ([]client.Object, error) {
+	resources := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchemaList{}
+	if err := r.List(ctx, resources, client.MatchingFields{SpecNameField: resource.Spec.Name}); err != nil {
+		return nil, fmt.Errorf("failed to list the resources claiming the schema: %s", err)
+	}
+
+	claimants := []client.Object{}
+	for i := range resources.Items {
+		claimant := &resources.Items[i]
+		if claimant.Spec.ServerRef == resource.Spec.ServerRef && schemaDatabase(claimant) == database && utils.IsManagedByOperatorInstance(claimant.ObjectMeta.Annotations, r.OperatorInstanceName) {
+			claimants = append(claimants, claimant)
+		}
+	}
+	return claimants, nil
+}
+
+// resolveReferences returns the schema's database and owner, from the referenced PostgresDatabase and PostgresRole if defined
+func (r *PostgresSchemaReconciler) resolveReferences(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchema) (database, owner string, err error) {
+	database, err = resolveDatabaseRef(ctx, r.Client, resource.Namespace, resource.Spec.ServerRef, resource.Spec.DatabaseRef, resource.Spec.Database)
+	if err != nil {
+		return "", "", err
+	}
+
+	owner, err = resolveOwnerRef(ctx, r.Client, resource.Namespace, resource.Spec.ServerRef, resource.Spec.OwnerRef, resource.Spec.Owner)
+	if err != nil {
+		return "", "", err
+	}
+
+	return database, owner, nil
+}
+
+// schemaDatabase returns the schema's database, or the one last resolved from its DatabaseRef
+func schemaDatabase(resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchema) string {
+	return cmp.Or(resource.Spec.Database, resource.Status.Database)
+}
+
+// requestsForDatabase returns the requests reconciling the schemas of the PostgresDatabase, once it is ready
+func (r *PostgresSchemaReconciler) requestsForDatabase(ctx context.Context, obj client.Object) []reconcile.Request {
+	database := obj.(*managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase)
+	return dependentRequests(ctx, r.Client, &managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchemaList{}, DatabaseRefField, database, database.Status.Conditions)
+}
+
+// requestsForRole returns the requests reconciling the schemas owned by the PostgresRole, once it is ready
+func (r *PostgresSchemaReconciler) requestsForRole(ctx context.Context, obj client.Object) []reconcile.Request {
+	role := obj.(*managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole)
+	return dependentRequests(ctx, r.Client, &managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchemaList{}, OwnerRefField, role, role.Status.Conditions)
+}
+
+// Waiting records the referenced resource the resource is waiting for in its conditions, then builds the reconciler result.
+// The resource is reconciled again as soon as the referenced resource is ready.
+func (r *postgresSchemaReconciliation) Waiting(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchema, err error) (ctrl.Result, error) {
+	status := resource.Status.DeepCopy()
+	status.Succeeded = false
+	status.ObservedGeneration = resource.Generation
+	setFailedConditions(&status.Conditions, resource.Generation, false, ReasonDependencyNotReady, err)
+
+	r.logging.Info("Waiting for a referenced resource", "reason", err.Error())
+
+	return r.Result(r.updateStatus(ctx, resource, status))
+}
+
+// Failure records the failing step in the resource's conditions, then builds the reconciler result
+func (r *postgresSchemaReconciliation) Failure(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchema, reason string, err error) (ctrl.Result, error) {
+	status := resource.Status.DeepCopy()
+	status.Succeeded = false
+	status.ObservedGeneration = resource.Generation
+	setFailedConditions(&status.Conditions, resource.Generation, !resource.ObjectMeta.DeletionTimestamp.IsZero(), reason, err)
+
+	r.eventing.Warning(reason, EventActionReconcile, "%s", err)
+
+	if statusErr := r.updateStatus(ctx, resource, status); statusErr != nil {
+		r.logging.Error(statusErr, "failed to update status")
+	}
+
+	return r.Result(err)
+}
+
+// updateStatus updates the resource's status only if it has changed
+func (r *PostgresSchemaReconciler) updateStatus(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchema, status *managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchemaStatus) error {
+	if equality.Semantic.DeepEqual(resource.Status, *status) {
+		return nil
+	}
+
+	resource.Status = *status
+	if err := r.Client.Status().Update(ctx, resource); err != nil {
+		return fmt.Errorf("failed to update object: %s", err)
+	}
+
+	return nil
+}
+
 // reconcileOnDeletion performs all actions related to deleting the resource
-func (r *PostgresSchemaReconciler) reconcileOnDeletion(schema *postgresql.Schema, keepOnDelete bool) (err error) {
+func (r *postgresSchemaReconciliation) reconcileOnDeletion(pgpools *postgresql.PGPools, schema *postgresql.Schema, keepOnDelete bool) (err error) {
 	if schema == nil {
 		// If the remote schema doesn't exists
 		r.logging.Info("Schema doesn't exist, skipping DROP SCHEMA")
@@ -192,28 +453,30 @@ func (r *PostgresSchemaReconciler) reconcileOnDeletion(schema *postgresql.Schema
 	}
 
 	// Drop the schema
-	err = postgresql.DropSchema(r.PGPools.Databases[schema.Database], schema.Name)
+	err = postgresql.DropSchema(pgpools.Database(schema.Database), schema.Name)
 	if err != nil {
 		r.logging.Error(err, "failed to delete schema")
 		return
 	}
 
 	r.logging.Info("Schema has been deleted")
+	r.eventing.Normal(EventReasonSchemaDropped, EventActionDrop, "Schema \"%s\" has been dropped from database \"%s\"", schema.Name, schema.Database)
 
 	return
 }
 
 // reconcileOnCreation performs all actions related to creating the resource
-func (r *PostgresSchemaReconciler) reconcileOnCreation(existingSchema, desiredSchema *postgresql.Schema) (err error) {
+func (r *postgresSchemaReconciliation) reconcileOnCreation(pgpools *postgresql.PGPools, existingSchema, desiredSchema *postgresql.Schema) (err error) {
 	alterOwner := false
 
 	if existingSchema == nil {
-		err = postgresql.CreateSchema(r.PGPools.Databases[desiredSchema.Database], desiredSchema.Name)
+		err = postgresql.CreateSchema(pgpools.Database(desiredSchema.Database), desiredSchema.Name)
 		if err != nil {
 			r.logging.Error(err, "failed to create schema")
 			return err
 		}
 		r.logging.Info("Schema has been created")
+		r.eventing.Normal(EventReasonSchemaCreated, EventActionCreate, "Schema \"%s\" has been created in database \"%s\"", desiredSchema.Name, desiredSchema.Database)
 		alterOwner = true
 	} else {
 		if existingSchema.Owner != desiredSchema.Owner {
@@ -223,7 +486,7 @@ func (r *PostgresSchemaReconciler) reconcileOnCreation(existingSchema, desiredSc
 
 	if alterOwner && desiredSchema.Owner != "" {
 		err = postgresql.AlterSchemaOwner(
-			r.PGPools.Databases[desiredSchema.Database],
+			pgpools.Database(desiredSchema.Database),
 			desiredSchema.Name,
 			desiredSchema.Owner,
 		)
@@ -232,15 +495,16 @@ func (r *PostgresSchemaReconciler) reconcileOnCreation(existingSchema, desiredSc
 			return err
 		}
 		r.logging.Info(fmt.Sprintf("Owner of the schema \"%s\" has been updated", desiredSchema.Name))
+		r.eventing.Normal(EventReasonSchemaOwnerAltered, EventActionAlter, "Owner of the schema \"%s\" has been changed to \"%s\"", desiredSchema.Name, desiredSchema.Owner)
 	}
 
 	return err
 }
 
 // reconcilePrivileges performs all actions related to the schema privileges for a single role
-func (r *PostgresSchemaReconciler) reconcilePrivileges(databaseName, schemaName, roleName string, desiredPrivileges []string) (err error) {
+func (r *postgresSchemaReconciliation) reconcilePrivileges(pgpools *postgresql.PGPools, databaseName, schemaName, roleName string, desiredPrivileges []string) (err error) {
 	// We retrieve the existing privileges
-	existingPrivileges, err := postgresql.GetSchemaRolePrivileges(r.PGPools.Databases[databaseName], schemaName, roleName)
+	existingPrivileges, err := postgresql.GetSchemaRolePrivileges(pgpools.Database(databaseName), schemaName, roleName)
 	if err != nil {
 		r.logging.Error(err, fmt.Sprintf("failed to retrieve privileges of schema \"%s\" in database \"%s\" on role \"%s\": %s", schemaName, databaseName, roleName, err))
 		return err
@@ -249,26 +513,106 @@ func (r *PostgresSchemaReconciler) reconcilePrivileges(databaseName, schemaName,
 	// We grant the missing privileges
 	for _, desiredPrivilege := range desiredPrivileges {
 		if !slices.Contains(existingPrivileges, desiredPrivilege) {
-			err := postgresql.GrantSchemaRolePrivilege(r.PGPools.Databases[databaseName], schemaName, roleName, desiredPrivilege)
+			err := postgresql.GrantSchemaRolePrivilege(pgpools.Database(databaseName), schemaName, roleName, desiredPrivilege)
 			if err != nil {
 				r.logging.Error(err, fmt.Sprintf("failed to grant \"%s\" privilege on schema \"%s\" in database \"%s\" to role \"%s\"", desiredPrivilege, schemaName, databaseName, roleName))
 				return err
 			}
 
 			r.logging.Info(fmt.Sprintf("Privilege \"%s\" has been granted to \"%s\" on schema \"%s\" in database \"%s\"", desiredPrivilege, roleName, schemaName, databaseName))
+			r.eventing.Normal(EventReasonPrivilegeGranted, EventActionGrant, "Privilege \"%s\" has been granted to \"%s\" on schema \"%s\" in database \"%s\"", desiredPrivilege, roleName, schemaName, databaseName)
 		}
 	}
 
 	// We revoke the non-declared privileges
 	for _, existingPrivilege := range existingPrivileges {
 		if !slices.Contains(desiredPrivileges, existingPrivilege) {
-			err := postgresql.RevokeSchemaRolePrivilege(r.PGPools.Databases[databaseName], schemaName, roleName, existingPrivilege)
+			err := postgresql.RevokeSchemaRolePrivilege(pgpools.Database(databaseName), schemaName, roleName, existingPrivilege)
 			if err != nil {
 				r.logging.Error(err, fmt.Sprintf("failed to revoke \"%s\" privilege on schema \"%s\" in database \"%s\" to role \"%s\"", existingPrivilege, schemaName, databaseName, roleName))
 				return err
 			}
 
 			r.logging.Info(fmt.Sprintf("Privilege \"%s\" has been revoked from \"%s\" on schema \"%s\" in database \"%s\"", existingPrivilege, roleName, schemaName, databaseName))
+			r.eventing.Normal(EventReasonPrivilegeRevoked, EventActionRevoke, "Privilege \"%s\" has been revoked from \"%s\" on schema \"%s\" in database \"%s\"", existingPrivilege, roleName, schemaName, databaseName)
+		}
+	}
+	return err
+}
+
+// reconcileObjectPrivileges performs all actions related to the privileges on all the objects of a single type in the schema for a single role
+func (r *postgresSchemaReconciliation) reconcileObjectPrivileges(pgpools *postgresql.PGPools, databaseName, schemaName, roleName, objectType string, desiredPrivileges []string) (err error) {
+	// We retrieve the privileges granted on all the objects and on at least one of them
+	grantedOnAll, grantedOnAny, err := postgresql.GetSchemaObjectsRolePrivileges(pgpools.Database(databaseName), schemaName, roleName, objectType)
+	if err != nil {
+		r.logging.Error(err, fmt.Sprintf("failed to retrieve privileges on %s of schema \"%s\" in database \"%s\" on role \"%s\"", objectType, schemaName, databaseName, roleName))
+		return err
+	}
+
+	// We grant the privileges missing on at least one object
+	for _, desiredPrivilege := range desiredPrivileges {
+		if !slices.Contains(grantedOnAll, desiredPrivilege) {
+			err := postgresql.GrantSchemaObjectsRolePrivilege(pgpools.Database(databaseName), schemaName, roleName, objectType, desiredPrivilege)
+			if err != nil {
+				r.logging.Error(err, fmt.Sprintf("failed to grant \"%s\" privilege on all %s of schema \"%s\" in database \"%s\" to role \"%s\"", desiredPrivilege, objectType, schemaName, databaseName, roleName))
+				return err
+			}
+
+			r.logging.Info(fmt.Sprintf("Privilege \"%s\" has been granted to \"%s\" on all %s of schema \"%s\" in database \"%s\"", desiredPrivilege, roleName, objectType, schemaName, databaseName))
+			r.eventing.Normal(EventReasonObjectPrivilegeGranted, EventActionGrant, "Privilege \"%s\" has been granted to \"%s\" on all %s of schema \"%s\" in database \"%s\"", desiredPrivilege, roleName, objectType, schemaName, databaseName)
+		}
+	}
+
+	// We revoke the non-declared privileges granted on at least one object
+	for _, existingPrivilege := range grantedOnAny {
+		if !slices.Contains(desiredPrivileges, existingPrivilege) {
+			err := postgresql.RevokeSchemaObjectsRolePrivilege(pgpools.Database(databaseName), schemaName, roleName, objectType, existingPrivilege)
+			if err != nil {
+				r.logging.Error(err, fmt.Sprintf("failed to revoke \"%s\" privilege on all %s of schema \"%s\" in database \"%s\" from role \"%s\"", existingPrivilege, objectType, schemaName, databaseName, roleName))
+				return err
+			}
+
+			r.logging.Info(fmt.Sprintf("Privilege \"%s\" has been revoked from \"%s\" on all %s of schema \"%s\" in database \"%s\"", existingPrivilege, roleName, objectType, schemaName, databaseName))
+			r.eventing.Normal(EventReasonObjectPrivilegeRevoked, EventActionRevoke, "Privilege \"%s\" has been revoked from \"%s\" on all %s of schema \"%s\" in database \"%s\"", existingPrivilege, roleName, objectType, schemaName, databaseName)
+		}
+	}
+	return err
+}
+
+// reconcileDefaultPrivileges performs all actions related to the default privileges on a single object type for a grantor and a grantee
+func (r *postgresSchemaReconciliation) reconcileDefaultPrivileges(pgpools *postgresql.PGPools, databaseName, schemaName, grantor, grantee, objectType string, desiredPrivileges []string) (err error) {
+	// We retrieve the existing default privileges
+	existingPrivileges, err := postgresql.GetSchemaDefaultPrivileges(pgpools.Database(databaseName), schemaName, grantor, grantee, objectType)
+	if err != nil {
+		r.logging.Error(err, fmt.Sprintf("failed to retrieve default privileges on %s of schema \"%s\" in database \"%s\" granted by \"%s\" to role \"%s\"", objectType, schemaName, databaseName, grantor, grantee))
+		return err
+	}
+
+	// We grant the missing privileges
+	for _, desiredPrivilege := range desiredPrivileges {
+		if !slices.Contains(existingPrivileges, desiredPrivilege) {
+			err := postgresql.GrantSchemaDefaultPrivilege(pgpools.Database(databaseName), schemaName, grantor, grantee, objectType, desiredPrivilege)
+			if err != nil {
+				r.logging.Error(err, fmt.Sprintf("failed to grant default privilege \"%s\" on %s of schema \"%s\" in database \"%s\" to role \"%s\"", desiredPrivilege, objectType, schemaName, databaseName, grantee))
+				return err
+			}
+
+			r.logging.Info(fmt.Sprintf("Default privilege \"%s\" on %s created by \"%s\" has been granted to \"%s\" on schema \"%s\" in database \"%s\"", desiredPrivilege, objectType, grantor, grantee, schemaName, databaseName))
+			r.eventing.Normal(EventReasonDefaultPrivilegeGranted, EventActionGrant, "Default privilege \"%s\" on %s created by \"%s\" has been granted to \"%s\" on schema \"%s\" in database \"%s\"", desiredPrivilege, objectType, grantor, grantee, schemaName, databaseName)
+		}
+	}
+
+	// We revoke the non-declared privileges
+	for _, existingPrivilege := range existingPrivileges {
+		if !slices.Contains(desiredPrivileges, existingPrivilege) {
+			err := postgresql.RevokeSchemaDefaultPrivilege(pgpools.Database(databaseName), schemaName, grantor, grantee, objectType, existingPrivilege)
+			if err != nil {
+				r.logging.Error(err, fmt.Sprintf("failed to revoke default privilege \"%s\" on %s of schema \"%s\" in database \"%s\" from role \"%s\"", existingPrivilege, objectType, schemaName, databaseName, grantee))
+				return err
+			}
+
+			r.logging.Info(fmt.Sprintf("Default privilege \"%s\" on %s created by \"%s\" has been revoked from \"%s\" on schema \"%s\" in database \"%s\"", existingPrivilege, objectType, grantor, grantee, schemaName, databaseName))
+			r.eventing.Normal(EventReasonDefaultPrivilegeRevoked, EventActionRevoke, "Default privilege \"%s\" on %s created by \"%s\" has been revoked from \"%s\" on schema \"%s\" in database \"%s\"", existingPrivilege, objectType, grantor, grantee, schemaName, databaseName)
 		}
 	}
 	return err
@@ -284,3 +628,36 @@ func (r *PostgresSchemaReconciler) convertPrivilegesSpecToList(privilegesSpec ma
 	}
 	return privileges
 }
+
+// convertObjectPrivilegesSpecToList returns the desired privileges on the objects of the given type, and whether they are managed or not
+func (r *PostgresSchemaReconciler) convertObjectPrivilegesSpecToList(privilegesSpec managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchemaPrivilegesSpec, objectType string) (privileges []string, managed bool) {
+	switch objectType {
+	case postgresql.ObjectTypeTables:
+		if privilegesSpec.Tables != nil {
+			return convertTablePrivilegesSpecToList(*privilegesSpec.Tables), true
+		}
+	case postgresql.ObjectTypeSequences:
+		if privilegesSpec.Sequences != nil {
+			return convertSequencePrivilegesSpecToList(*privilegesSpec.Sequences), true
+		}
+	case postgresql.ObjectTypeFunctions:
+		if privilegesSpec.Functions != nil {
+			return convertFunctionPrivilegesSpecToList(*privilegesSpec.Functions), true
+		}
+	}
+	return []string{}, false
+}
+
+func (r *PostgresSchemaReconciler) convertDefaultPrivilegesSpecToList(defaultPrivilegesSpec managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchemaDefaultPrivilegesSpec, objectType string) []string {
+	switch objectType {
+	case postgresql.ObjectTypeTables:
+		return convertTablePrivilegesSpecToList(defaultPrivilegesSpec.Tables)
+	case postgresql.ObjectTypeSequences:
+		return convertSequencePrivilegesSpecToList(defaultPrivilegesSpec.Sequences)
+	case postgresql.ObjectTypeFunctions:
+		return convertFunctionPrivilegesSpecToList(defaultPrivilegesSpec.Functions)
+	case postgresql.ObjectTypeTypes:
+		return convertTypePrivilegesSpecToList(defaultPrivilegesSpec.Types)
+	}
+	return []string{}
+}
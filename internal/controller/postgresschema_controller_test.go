@@ -25,7 +25,9 @@ import (
 	. "github.com/onsi/gomega"
 	pgxmock "github.com/pashagolub/pgxmock/v4"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -165,6 +167,13 @@ var _ = Describe("PostgresSchema Controller", func() {
 						Fail(err.Error())
 					}
 				}
+
+				Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+				Expect(resource.Status.Succeeded).To(BeTrue())
+				Expect(resource.Status.ObservedGeneration).To(Equal(resource.Generation))
+				Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeReady)).To(BeTrue())
+				Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeSynced)).To(BeTrue())
+				Expect(meta.IsStatusConditionFalse(resource.Status.Conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeDegraded)).To(BeTrue())
 			})
 		})
 
@@ -197,6 +206,181 @@ var _ = Describe("PostgresSchema Controller", func() {
 			})
 		})
 
+		When("the resource references a server which is not ready", func() {
+			It("should return an error without querying any server", func() {
+				serverNamespacedName := types.NamespacedName{
+					Name:      "test-resource-server",
+					Namespace: "default",
+				}
+				resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchema{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      serverNamespacedName.Name,
+						Namespace: serverNamespacedName.Namespace,
+					},
+					Spec: managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchemaSpec{
+						ServerRef: "myserver",
+						Database:  "mydb",
+						Name:      "myschema",
+					},
+				}
+				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+				defer func() {
+					Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+				}()
+
+				recorder := events.NewFakeRecorder(10)
+				controllerReconciler := &PostgresSchemaReconciler{
+					Client:   k8sClient,
+					Scheme:   k8sClient.Scheme(),
+					Recorder: recorder,
+					PGPools:  pgpools,
+				}
+
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: serverNamespacedName,
+				})
+
+				Expect(err).To(HaveOccurred())
+				for _, poolMock := range pgpoolsMock {
+					if err := poolMock.ExpectationsWereMet(); err != nil {
+						Fail(err.Error())
+					}
+				}
+
+				Expect(k8sClient.Get(ctx, serverNamespacedName, resource)).To(Succeed())
+				Expect(resource.Status.Succeeded).To(BeFalse())
+				readyCondition := meta.FindStatusCondition(resource.Status.Conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeReady)
+				Expect(readyCondition).NotTo(BeNil())
+				Expect(readyCondition.Status).To(Equal(metav1.ConditionFalse))
+				Expect(readyCondition.Reason).To(Equal(ReasonServerNotReady))
+				Expect(meta.IsStatusConditionFalse(resource.Status.Conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeSynced)).To(BeTrue())
+				Expect(recorder.Events).To(Receive(HavePrefix("Warning ServerNotReady ")))
+			})
+		})
+
+		When("the resource references a PostgresDatabase and a PostgresRole", func() {
+			It("should wait for them to be ready, then create the schema in the database", func() {
+				dependentNamespacedName := types.NamespacedName{
+					Name:      "test-resource-dependent",
+					Namespace: "default",
+				}
+				resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchema{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      dependentNamespacedName.Name,
+						Namespace: dependentNamespacedName.Namespace,
+					},
+					Spec: managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchemaSpec{
+						DatabaseRef: "test-resource-database",
+						OwnerRef:    "test-resource-role",
+						Name:        "mydependentschema",
+					},
+				}
+				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+				defer func() {
+					Expect(k8sClient.Get(ctx, dependentNamespacedName, resource)).To(Succeed())
+					controllerutil.RemoveFinalizer(resource, PostgresSchemaFinalizer)
+					Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+					Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+				}()
+
+				database := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-resource-database",
+						Namespace: "default",
+					},
+					Spec: managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabaseSpec{
+						Name: "mydb",
+					},
+				}
+				Expect(k8sClient.Create(ctx, database)).To(Succeed())
+				defer func() {
+					Expect(k8sClient.Delete(ctx, database)).To(Succeed())
+				}()
+
+				controllerReconciler := &PostgresSchemaReconciler{
+					Client:  k8sClient,
+					Scheme:  k8sClient.Scheme(),
+					PGPools: pgpools,
+				}
+
+				By("Reconciling while the PostgresDatabase isn't ready")
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: dependentNamespacedName,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, dependentNamespacedName, resource)).To(Succeed())
+				readyCondition := meta.FindStatusCondition(resource.Status.Conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeReady)
+				Expect(readyCondition).NotTo(BeNil())
+				Expect(readyCondition.Status).To(Equal(metav1.ConditionFalse))
+				Expect(readyCondition.Reason).To(Equal(ReasonDependencyNotReady))
+				Expect(readyCondition.Message).To(Equal(`PostgresDatabase "test-resource-database" isn't ready`))
+				Expect(controllerReconciler.requestsForDatabase(ctx, database)).To(BeEmpty())
+
+				By("Marking the PostgresDatabase as ready, the schema should be reconciled again")
+				setSucceededConditions(&database.Status.Conditions, database.Generation)
+				Expect(k8sClient.Status().Update(ctx, database)).To(Succeed())
+				Expect(controllerReconciler.requestsForDatabase(ctx, database)).To(ConsistOf(reconcile.Request{NamespacedName: dependentNamespacedName}))
+
+				_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: dependentNamespacedName,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, dependentNamespacedName, resource)).To(Succeed())
+				readyCondition = meta.FindStatusCondition(resource.Status.Conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeReady)
+				Expect(readyCondition.Message).To(Equal(`PostgresRole "test-resource-role" doesn't exist`))
+
+				By("Creating the PostgresRole as ready, the schema should be created")
+				role := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-resource-role",
+						Namespace: "default",
+					},
+					Spec: managedpostgresoperatorhoppscalecomv1alpha1.PostgresRoleSpec{
+						Name: "mydependentrole",
+					},
+				}
+				Expect(k8sClient.Create(ctx, role)).To(Succeed())
+				defer func() {
+					Expect(k8sClient.Delete(ctx, role)).To(Succeed())
+				}()
+				setSucceededConditions(&role.Status.Conditions, role.Generation)
+				Expect(k8sClient.Status().Update(ctx, role)).To(Succeed())
+				Expect(controllerReconciler.requestsForRole(ctx, role)).To(ConsistOf(reconcile.Request{NamespacedName: dependentNamespacedName}))
+
+				pgpoolsMock["mydb"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetSchemaSQLStatement))).
+					WithArgs("mydependentschema").
+					WillReturnRows(
+						pgxmock.NewRows([]string{
+							"name",
+							"owner",
+						}),
+					)
+				pgpoolsMock["mydb"].ExpectExec(`CREATE SCHEMA "mydependentschema"`).
+					WillReturnResult(pgxmock.NewResult("", 1))
+				pgpoolsMock["mydb"].ExpectExec(`ALTER SCHEMA "mydependentschema" OWNER TO "mydependentrole"`).
+					WillReturnResult(pgxmock.NewResult("", 1))
+
+				_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: dependentNamespacedName,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				for _, poolMock := range pgpoolsMock {
+					if err := poolMock.ExpectationsWereMet(); err != nil {
+						Fail(err.Error())
+					}
+				}
+
+				Expect(k8sClient.Get(ctx, dependentNamespacedName, resource)).To(Succeed())
+				Expect(resource.Spec.Database).To(BeEmpty())
+				Expect(resource.Spec.Owner).To(BeEmpty())
+				Expect(resource.Status.Database).To(Equal("mydb"))
+				Expect(resource.Status.Owner).To(Equal("mydependentrole"))
+				Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, managedpostgresoperatorhoppscalecomv1alpha1.ConditionTypeReady)).To(BeTrue())
+			})
+		})
+
 		When("the schema is owned by another role", func() {
 			It("should change the owner of the schema", func() {
 				resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchema{}
@@ -336,6 +520,203 @@ var _ = Describe("PostgresSchema Controller", func() {
 			})
 		})
 
+		When("object privileges are updated", func() {
+			It("should grant privileges missing on some objects and revoke the others", func() {
+				resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchema{}
+				Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+				resource.ObjectMeta.Annotations = map[string]string{
+					utils.OperatorInstanceAnnotationName: "foo",
+				}
+				resource.Spec.PrivilegesByRole = map[string]managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchemaPrivilegesSpec{
+					"fakerole": {
+						Usage: true,
+						Tables: &managedpostgresoperatorhoppscalecomv1alpha1.PostgresTablePrivilegesSpec{
+							Select: true,
+						},
+						Functions: &managedpostgresoperatorhoppscalecomv1alpha1.PostgresFunctionPrivilegesSpec{},
+					},
+				}
+				Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+
+				controllerReconciler := &PostgresSchemaReconciler{
+					Client:               k8sClient,
+					Scheme:               k8sClient.Scheme(),
+					PGPools:              pgpools,
+					OperatorInstanceName: "foo",
+				}
+
+				pgpoolsMock["mydb"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetSchemaSQLStatement))).
+					WithArgs("myschema").
+					WillReturnRows(
+						pgxmock.NewRows([]string{
+							"name",
+							"owner",
+						}).
+							AddRow(
+								"myschema",
+								"myrole",
+							),
+					)
+				existingPrivileges := map[string]bool{
+					"CREATE": false,
+					"USAGE":  true,
+				}
+				for _, privilege := range postgresql.ListSchemaAvailablePrivileges() {
+					pgpoolsMock["mydb"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta("SELECT has_schema_privilege($1, $2, $3)"))).
+						WithArgs(
+							"fakerole",
+							"myschema",
+							privilege,
+						).
+						WillReturnRows(
+							pgxmock.NewRows([]string{
+								"has_schema_privilege",
+							}).
+								AddRow(
+									existingPrivileges[privilege],
+								),
+						)
+				}
+
+				// SELECT is only granted on some tables, and TRUNCATE has been granted by hand on one of them
+				pgpoolsMock["mydb"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetSchemaTablesPrivilegesSQLStatement))).
+					WithArgs("myschema", "fakerole", postgresql.ListObjectTypeAvailablePrivileges(postgresql.ObjectTypeTables)).
+					WillReturnRows(
+						pgxmock.NewRows([]string{
+							"privilege",
+							"granted_on_all",
+							"granted_on_any",
+						}).
+							AddRow("SELECT", false, true).
+							AddRow("TRUNCATE", false, true),
+					)
+				pgpoolsMock["mydb"].ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`GRANT SELECT ON ALL TABLES IN SCHEMA "myschema" TO "fakerole"`))).
+					WillReturnResult(pgxmock.NewResult("GRANT", 0))
+				pgpoolsMock["mydb"].ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`REVOKE TRUNCATE ON ALL TABLES IN SCHEMA "myschema" FROM "fakerole"`))).
+					WillReturnResult(pgxmock.NewResult("REVOKE", 0))
+
+				// Sequences are not managed, functions are managed without any privilege
+				pgpoolsMock["mydb"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetSchemaFunctionsPrivilegesSQLStatement))).
+					WithArgs("myschema", "fakerole", postgresql.ListObjectTypeAvailablePrivileges(postgresql.ObjectTypeFunctions)).
+					WillReturnRows(
+						pgxmock.NewRows([]string{
+							"privilege",
+							"granted_on_all",
+							"granted_on_any",
+						}).
+							AddRow("EXECUTE", true, true),
+					)
+				pgpoolsMock["mydb"].ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`REVOKE EXECUTE ON ALL FUNCTIONS IN SCHEMA "myschema" FROM "fakerole"`))).
+					WillReturnResult(pgxmock.NewResult("REVOKE", 0))
+
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: typeNamespacedName,
+				})
+
+				Expect(err).NotTo(HaveOccurred())
+				for _, poolMock := range pgpoolsMock {
+					if err := poolMock.ExpectationsWereMet(); err != nil {
+						Fail(err.Error())
+					}
+				}
+			})
+		})
+
+		When("default privileges are updated", func() {
+			It("should grant missing default privileges and revoke the others", func() {
+				resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchema{}
+				Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+				resource.ObjectMeta.Annotations = map[string]string{
+					utils.OperatorInstanceAnnotationName: "foo",
+				}
+				resource.Spec.PrivilegesByRole = nil
+				resource.Spec.DefaultPrivileges = []managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchemaDefaultPrivilegesSpec{
+					{
+						Grantor: "myrole",
+						Grantee: "reader",
+						Tables: managedpostgresoperatorhoppscalecomv1alpha1.PostgresTablePrivilegesSpec{
+							Select: true,
+							Insert: true,
+						},
+						Sequences: managedpostgresoperatorhoppscalecomv1alpha1.PostgresSequencePrivilegesSpec{
+							Usage: true,
+						},
+					},
+				}
+				Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+
+				controllerReconciler := &PostgresSchemaReconciler{
+					Client:               k8sClient,
+					Scheme:               k8sClient.Scheme(),
+					PGPools:              pgpools,
+					OperatorInstanceName: "foo",
+				}
+
+				pgpoolsMock["mydb"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetSchemaSQLStatement))).
+					WithArgs("myschema").
+					WillReturnRows(
+						pgxmock.NewRows([]string{
+							"name",
+							"owner",
+						}).
+							AddRow(
+								"myschema",
+								"myrole",
+							),
+					)
+
+				defaclObjectTypes := map[string]string{
+					postgresql.ObjectTypeTables:    "r",
+					postgresql.ObjectTypeSequences: "S",
+					postgresql.ObjectTypeFunctions: "f",
+					postgresql.ObjectTypeTypes:     "T",
+				}
+				existingDefaultPrivileges := map[string][]string{
+					postgresql.ObjectTypeTables:    {"SELECT", "UPDATE"},
+					postgresql.ObjectTypeFunctions: {"EXECUTE"},
+				}
+				expectedStatements := map[string][]string{
+					postgresql.ObjectTypeTables: {
+						`ALTER DEFAULT PRIVILEGES FOR ROLE "myrole" IN SCHEMA "myschema" GRANT INSERT ON TABLES TO "reader"`,
+						`ALTER DEFAULT PRIVILEGES FOR ROLE "myrole" IN SCHEMA "myschema" REVOKE UPDATE ON TABLES FROM "reader"`,
+					},
+					postgresql.ObjectTypeSequences: {
+						`ALTER DEFAULT PRIVILEGES FOR ROLE "myrole" IN SCHEMA "myschema" GRANT USAGE ON SEQUENCES TO "reader"`,
+					},
+					postgresql.ObjectTypeFunctions: {
+						`ALTER DEFAULT PRIVILEGES FOR ROLE "myrole" IN SCHEMA "myschema" REVOKE EXECUTE ON FUNCTIONS FROM "reader"`,
+					},
+				}
+
+				for _, objectType := range postgresql.ListDefaultPrivilegesObjectTypes() {
+					rows := pgxmock.NewRows([]string{
+						"privilege_type",
+					})
+					for _, privilege := range existingDefaultPrivileges[objectType] {
+						rows.AddRow(privilege)
+					}
+					pgpoolsMock["mydb"].ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(postgresql.GetSchemaDefaultPrivilegesSQLStatement))).
+						WithArgs("myschema", "myrole", "reader", defaclObjectTypes[objectType]).
+						WillReturnRows(rows)
+					for _, statement := range expectedStatements[objectType] {
+						pgpoolsMock["mydb"].ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(statement))).
+							WillReturnResult(pgxmock.NewResult("ALTER DEFAULT PRIVILEGES", 0))
+					}
+				}
+
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: typeNamespacedName,
+				})
+
+				Expect(err).NotTo(HaveOccurred())
+				for _, poolMock := range pgpoolsMock {
+					if err := poolMock.ExpectationsWereMet(); err != nil {
+						Fail(err.Error())
+					}
+				}
+			})
+		})
+
 		When("the resource is deleted", func() {
 			It("should successfully reconcile the resource on deletion", func() {
 				resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchema{}
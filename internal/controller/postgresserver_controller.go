@@ -0,0 +1,386 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"time"
+
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	managedpostgresoperatorhoppscalecomv1alpha1 "github.com/hoppscale/managed-postgres-operator/api/v1alpha1"
+	"github.com/hoppscale/managed-postgres-operator/internal/postgresql"
+	"github.com/hoppscale/managed-postgres-operator/internal/utils"
+)
+
+const PostgresServerFinalizer = "postgresserver.managed-postgres-operator.hoppscale.com/finalizer"
+
+// CredentialsFromSecretField is the field indexed to list the PostgresServers reading their credentials from a Secret,
+// whose value is the Secret's namespace and name
+const CredentialsFromSecretField = "spec.credentialsFromSecret"
+
+// CAFromSecretField is the field indexed to list the PostgresServers reading their certificate authority from a Secret,
+// whose value is the Secret's namespace and name
+const CAFromSecretField = "spec.caFromSecret"
+
+// PostgresServerReconciler reconciles a PostgresServer object
+type PostgresServerReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	RequeueInterval time.Duration
+
+	PGPools              *postgresql.PGPools
+	OperatorInstanceName string
+
+	// OperatorNamespace is the only namespace the servers' Secrets are read from.
+	// If empty, the Secrets are read from the namespace set by the resources.
+	OperatorNamespace string
+}
+
+// +kubebuilder:rbac:groups=managed-postgres-operator.hoppscale.com,resources=postgresservers,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=managed-postgres-operator.hoppscale.com,resources=postgresservers/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=managed-postgres-operator.hoppscale.com,resources=postgresservers/finalizers,verbs=update
+func (r *PostgresServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresServer{}
+
+	if err := r.Client.Get(ctx, req.NamespacedName, resource); err != nil {
+		if errors.IsNotFound(err) {
+			// Close the server's connections if the resource has been deleted without the finalizer
+			r.closeServer(ctx, req.Name)
+		}
+		return r.Result(client.IgnoreNotFound(err))
+	}
+
+	// Skip reconcile if the resource is not managed by this operator
+	if !utils.IsManagedByOperatorInstance(resource.ObjectMeta.Annotations, r.OperatorInstanceName) {
+		return r.Result(nil)
+	}
+
+	if resource.ObjectMeta.DeletionTimestamp.IsZero() {
+		if !controllerutil.ContainsFinalizer(resource, PostgresServerFinalizer) {
+			controllerutil.AddFinalizer(resource, PostgresServerFinalizer)
+			if err := r.Update(ctx, resource); err != nil {
+				return r.Result(err)
+			}
+		}
+	} else {
+		// If there is no finalizer, delete the resource immediately
+		if !controllerutil.ContainsFinalizer(resource, PostgresServerFinalizer) {
+			return r.Result(nil)
+		}
+
+		r.closeServer(ctx, resource.Name)
+
+		// Remove our finalizer from the list and update it.
+		controllerutil.RemoveFinalizer(resource, PostgresServerFinalizer)
+		if err := r.Update(ctx, resource); err != nil {
+			return r.Result(err)
+		}
+
+		// Stop reconciliation as the item is being deleted
+		return r.Result(nil)
+	}
+
+	if err := r.checkSecretsNamespace(resource); err != nil {
+		return r.Failure(ctx, resource, ReasonInvalidSpec, err)
+	}
+
+	caPath, err := r.writeServerCA(resource)
+	if err != nil {
+		return r.Failure(ctx, resource, ReasonRetrieveServerCAFailed, err)
+	}
+
+	connString, err := r.buildConnString(resource, caPath)
+	if err != nil {
+		return r.Failure(ctx, resource, ReasonRetrieveCredentialsFailed, err)
+	}
+
+	err = postgresql.EnsurePGServerPoolsExist(r.PGPools, resource.Name, connString)
+	if err != nil {
+		return r.Failure(ctx, resource, ReasonOpenPoolFailed, err)
+	}
+
+	// The files of the previous certificate authorities are only removed once the pools verifying the server with them are replaced
+	if err = postgresql.RemoveServerCAs(resource.Name, caPath); err != nil {
+		log.FromContext(ctx).Error(err, "failed to remove the previous CA files of the server")
+	}
+
+	return r.Success(ctx, resource)
+}
+
+// closeServer closes the server's connections and removes its CA files
+func (r *PostgresServerReconciler) closeServer(ctx context.Context, server string) {
+	postgresql.ClosePGServerPools(r.PGPools, server)
+	if err := postgresql.RemoveServerCAs(server, ""); err != nil {
+		log.FromContext(ctx).Error(err, "failed to remove the CA files of the server")
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *PostgresServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := indexReference(mgr, &managedpostgresoperatorhoppscalecomv1alpha1.PostgresServer{}, CredentialsFromSecretField, func(resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresServer) string {
+		return r.credentialsSecretKey(resource).String()
+	})
+	if err != nil {
+		return err
+	}
+
+	err = indexReference(mgr, &managedpostgresoperatorhoppscalecomv1alpha1.PostgresServer{}, CAFromSecretField, func(resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresServer) string {
+		if resource.Spec.CAFromSecret == nil {
+			return ""
+		}
+		return r.caSecretKey(resource).String()
+	})
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&managedpostgresoperatorhoppscalecomv1alpha1.PostgresServer{}).
+		// Only the metadata of the Secrets is cached, as their data is read from the API server
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.requestsForSecret), builder.OnlyMetadata).
+		Named("postgresserver").
+		WithOptions(controller.Options{
+			RateLimiter: workqueue.NewTypedMaxOfRateLimiter(
+				workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](time.Second, r.RequeueInterval),
+				&workqueue.TypedBucketRateLimiter[reconcile.Request]{Limiter: rate.NewLimiter(rate.Limit(10), 100)},
+			),
+		}).
+		Complete(r)
+}
+
+// requestsForSecret returns the PostgresServers reading their credentials or their certificate authority from the Secret,
+// so that their pools are replaced as soon as the Secret changes
+func (r *PostgresServerReconciler) requestsForSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	requests := []reconcile.Request{}
+	for _, field := range []string{CredentialsFromSecretField, CAFromSecretField} {
+		resources := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresServerList{}
+		if err := r.List(ctx, resources, client.MatchingFields{field: client.ObjectKeyFromObject(secret).String()}); err != nil {
+			log.FromContext(ctx).Error(err, "failed to list the referencing resources", "field", field)
+			continue
+		}
+
+		for _, resource := range resources.Items {
+			request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&resource)}
+			if !slices.Contains(requests, request) {
+				requests = append(requests, request)
+			}
+		}
+	}
+	return requests
+}
+
+// credentialsSecretKey returns the namespace and the name of the Secret containing the server's credentials.
+// If the resource doesn't set the Secret's namespace, it is read from the operator's namespace.
+func (r *PostgresServerReconciler) credentialsSecretKey(resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresServer) types.NamespacedName {
+	namespace := resource.Spec.CredentialsFromSecret.Namespace
+	if namespace == "" {
+		namespace = r.OperatorNamespace
+	}
+
+	return types.NamespacedName{
+		Namespace: namespace,
+		Name:      resource.Spec.CredentialsFromSecret.Name,
+	}
+}
+
+// caSecretKey returns the namespace and the name of the Secret containing the server's certificate authority,
+// which is read from the namespace of the credentials Secret
+func (r *PostgresServerReconciler) caSecretKey(resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresServer) types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: r.credentialsSecretKey(resource).Namespace,
+		Name:      resource.Spec.CAFromSecret.Name,
+	}
+}
+
+// checkSecretsNamespace ensures that the server's Secrets are read from the operator's namespace.
+// PostgresServer being cluster-scoped, the operator would otherwise send the credentials of any namespace
+// to the host chosen by whoever can create a PostgresServer.
+func (r *PostgresServerReconciler) checkSecretsNamespace(resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresServer) error {
+	if r.OperatorNamespace == "" {
+		if resource.Spec.CredentialsFromSecret.Namespace == "" {
+			return fmt.Errorf("the namespace of the credentials Secret is required, as the operator's namespace is unknown")
+		}
+		return nil
+	}
+
+	if namespace := resource.Spec.CredentialsFromSecret.Namespace; namespace != "" && namespace != r.OperatorNamespace {
+		return fmt.Errorf("the credentials Secret must be in the operator's namespace \"%s\", not \"%s\"", r.OperatorNamespace, namespace)
+	}
+	return nil
+}
+
+// Result builds reconciler result depending on error
+func (r *PostgresServerReconciler) Result(err error) (ctrl.Result, error) {
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: r.RequeueInterval}, nil
+}
+
+// Success marks the resource as ready and synced, then builds the reconciler result
+func (r *PostgresServerReconciler) Success(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresServer) (ctrl.Result, error) {
+	status := resource.Status.DeepCopy()
+	status.Succeeded = true
+	status.ObservedGeneration = resource.Generation
+	setSucceededConditions(&status.Conditions, resource.Generation)
+
+	return r.Result(r.updateStatus(ctx, resource, status))
+}
+
+// Failure records the failing step in the resource's conditions, then builds the reconciler result
+func (r *PostgresServerReconciler) Failure(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresServer, reason string, err error) (ctrl.Result, error) {
+	status := resource.Status.DeepCopy()
+	status.Succeeded = false
+	status.ObservedGeneration = resource.Generation
+	setFailedConditions(&status.Conditions, resource.Generation, !resource.ObjectMeta.DeletionTimestamp.IsZero(), reason, err)
+
+	if statusErr := r.updateStatus(ctx, resource, status); statusErr != nil {
+		log.FromContext(ctx).Error(statusErr, "failed to update status")
+	}
+
+	return r.Result(err)
+}
+
+// updateStatus updates the resource's status only if it has changed
+func (r *PostgresServerReconciler) updateStatus(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresServer, status *managedpostgresoperatorhoppscalecomv1alpha1.PostgresServerStatus) error {
+	if equality.Semantic.DeepEqual(resource.Status, *status) {
+		return nil
+	}
+
+	resource.Status = *status
+	if err := r.Client.Status().Update(ctx, resource); err != nil {
+		return fmt.Errorf("failed to update object: %s", err)
+	}
+
+	return nil
+}
+
+// writeServerCA writes the certificate authority read from the resource's Secret to a file, and returns its path.
+// If the resource doesn't reference a certificate authority, an empty path is returned.
+func (r *PostgresServerReconciler) writeServerCA(resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresServer) (path string, err error) {
+	if resource.Spec.CAFromSecret == nil {
+		return "", nil
+	}
+
+	secretNamespacedName := r.caSecretKey(resource)
+
+	resourceSecret := &corev1.Secret{}
+
+	err = r.Client.Get(context.Background(), secretNamespacedName, resourceSecret)
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve CA from secret `%s`: %s", secretNamespacedName, err)
+	}
+
+	ca, ok := resourceSecret.Data[resource.Spec.CAFromSecret.Key]
+	if !ok {
+		return "", fmt.Errorf("failed to retrieve CA from secret `%s`: key `%s` doesn't exist", secretNamespacedName, resource.Spec.CAFromSecret.Key)
+	}
+
+	return postgresql.WriteServerCA(resource.Name, ca)
+}
+
+// buildConnString generates the server's connection string from the resource and its credentials Secret.
+// If a CA path is given, it is used as sslrootcert to verify the server.
+func (r *PostgresServerReconciler) buildConnString(resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresServer, caPath string) (connString string, err error) {
+	username, password, err := r.readCredentials(context.Background(), resource)
+	if err != nil {
+		return "", err
+	}
+
+	connURL := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(string(username), string(password)),
+		Host:   net.JoinHostPort(resource.Spec.Host, fmt.Sprintf("%d", resource.Spec.Port)),
+		Path:   "/" + resource.Spec.Database,
+	}
+
+	query := url.Values{}
+	if resource.Spec.SSLMode != "" {
+		query.Set("sslmode", resource.Spec.SSLMode)
+	}
+	if caPath != "" {
+		query.Set("sslrootcert", caPath)
+	}
+	connURL.RawQuery = query.Encode()
+
+	return connURL.String(), nil
+}
+
+// readCredentials returns the username and the password read from the server's credentials Secret
+func (r *PostgresServerReconciler) readCredentials(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresServer) (username, password []byte, err error) {
+	credentials := resource.Spec.CredentialsFromSecret
+	secretNamespacedName := r.credentialsSecretKey(resource)
+
+	resourceSecret := &corev1.Secret{}
+
+	err = r.Client.Get(ctx, secretNamespacedName, resourceSecret)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to retrieve credentials from secret `%s`: %s", secretNamespacedName, err)
+	}
+
+	username, ok := resourceSecret.Data[credentials.UsernameKey]
+	if !ok {
+		return nil, nil, fmt.Errorf("failed to retrieve credentials from secret `%s`: key `%s` doesn't exist", secretNamespacedName, credentials.UsernameKey)
+	}
+
+	password, ok = resourceSecret.Data[credentials.PasswordKey]
+	if !ok {
+		return nil, nil, fmt.Errorf("failed to retrieve credentials from secret `%s`: key `%s` doesn't exist", secretNamespacedName, credentials.PasswordKey)
+	}
+
+	return username, password, nil
+}
+
+// ServerConnection returns the role and the database used by the operator to connect to the PostgresServer,
+// so that the validating webhooks reject the resources which would alter them
+func (r *PostgresServerReconciler) ServerConnection(ctx context.Context, server string) (role, database string, err error) {
+	resource := &managedpostgresoperatorhoppscalecomv1alpha1.PostgresServer{}
+	if err = r.Client.Get(ctx, types.NamespacedName{Name: server}, resource); err != nil {
+		return "", "", err
+	}
+
+	if err = r.checkSecretsNamespace(resource); err != nil {
+		return "", "", err
+	}
+
+	username, _, err := r.readCredentials(ctx, resource)
+	if err != nil {
+		return "", "", err
+	}
+
+	return string(username), resource.Spec.Database, nil
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	managedpostgresoperatorhoppscalecomv1alpha1 "github.com/hoppscale/managed-postgres-operator/api/v1alpha1"
+)
+
+func convertTablePrivilegesSpecToList(privilegesSpec managedpostgresoperatorhoppscalecomv1alpha1.PostgresTablePrivilegesSpec) []string {
+	privileges := []string{}
+	if privilegesSpec.Select {
+		privileges = append(privileges, "SELECT")
+	}
+	if privilegesSpec.Insert {
+		privileges = append(privileges, "INSERT")
+	}
+	if privilegesSpec.Update {
+		privileges = append(privileges, "UPDATE")
+	}
+	if privilegesSpec.Delete {
+		privileges = append(privileges, "DELETE")
+	}
+	if privilegesSpec.Truncate {
+		privileges = append(privileges, "TRUNCATE")
+	}
+	if privilegesSpec.References {
+		privileges = append(privileges, "REFERENCES")
+	}
+	if privilegesSpec.Trigger {
+		privileges = append(privileges, "TRIGGER")
+	}
+	return privileges
+}
+
+func convertSequencePrivilegesSpecToList(privilegesSpec managedpostgresoperatorhoppscalecomv1alpha1.PostgresSequencePrivilegesSpec) []string {
+	privileges := []string{}
+	if privilegesSpec.Usage {
+		privileges = append(privileges, "USAGE")
+	}
+	if privilegesSpec.Select {
+		privileges = append(privileges, "SELECT")
+	}
+	if privilegesSpec.Update {
+		privileges = append(privileges, "UPDATE")
+	}
+	return privileges
+}
+
+func convertFunctionPrivilegesSpecToList(privilegesSpec managedpostgresoperatorhoppscalecomv1alpha1.PostgresFunctionPrivilegesSpec) []string {
+	privileges := []string{}
+	if privilegesSpec.Execute {
+		privileges = append(privileges, "EXECUTE")
+	}
+	return privileges
+}
+
+func convertTypePrivilegesSpecToList(privilegesSpec managedpostgresoperatorhoppscalecomv1alpha1.PostgresTypePrivilegesSpec) []string {
+	privileges := []string{}
+	if privilegesSpec.Usage {
+		privileges = append(privileges, "USAGE")
+	}
+	return privileges
+}
@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/hoppscale/managed-postgres-operator/internal/postgresql"
+)
+
+// secretTemplateData holds the variables available in the templates of the role's Secret.
+// Its methods are only called when a template uses them, with the operator's access: anyone allowed to create
+// a PostgresRole must be trusted like a cluster administrator.
+type secretTemplateData struct {
+	Role     string
+	Password string
+	Host     string
+	Port     string
+	Database string
+	SSLMode  string
+
+	// Namespace, Name and Labels are the PostgresRole's
+	Namespace string
+	Name      string
+	Labels    map[string]string
+
+	pgpool   postgresql.PGPoolInterface
+	pgConfig *pgx.ConnConfig
+}
+
+// Databases returns the databases the role can connect to, i.e. on which it has the CONNECT privilege if it can log in
+func (d secretTemplateData) Databases() ([]string, error) {
+	return postgresql.ListConnectableDatabases(d.pgpool, d.Role)
+}
+
+// ServerCA returns the certificates used by the operator to verify the server, if any.
+// The file named by sslrootcert must only contain public certificates.
+func (d secretTemplateData) ServerCA() (string, error) {
+	return postgresql.ServerCA(d.pgConfig)
+}
+
+// secretTemplateFuncs returns the functions available in the templates of the role's Secret,
+// in addition to the builtin ones such as urlquery
+func secretTemplateFuncs(data secretTemplateData) template.FuncMap {
+	return template.FuncMap{
+		"b64enc": func(value string) string {
+			return base64.StdEncoding.EncodeToString([]byte(value))
+		},
+		"quote": strconv.Quote,
+		"default": func(defaultValue, value string) string {
+			if value == "" {
+				return defaultValue
+			}
+			return value
+		},
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		// sslmode is also available where the dot isn't the template's data, e.g. in a range
+		"sslmode": func() string {
+			return data.SSLMode
+		},
+	}
+}
+
+// renderSecretTemplate renders the templates of the role's Secret, returning the data of each key
+func renderSecretTemplate(secretTemplate map[string]string, data secretTemplateData) (map[string][]byte, error) {
+	secretData := map[string][]byte{}
+
+	keys := make([]string, 0, len(secretTemplate))
+	for key := range secretTemplate {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		t, err := template.New(key).Funcs(secretTemplateFuncs(data)).Parse(secretTemplate[key])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse secret template \"%s\": %s", key, err)
+		}
+
+		var rendered bytes.Buffer
+		if err := t.Execute(&rendered, data); err != nil {
+			return nil, fmt.Errorf("failed to render secret template \"%s\": %s", key, err)
+		}
+		secretData[key] = rendered.Bytes()
+	}
+
+	return secretData, nil
+}
@@ -3,6 +3,7 @@ package postgresql
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/jackc/pgx/v5"
 )
@@ -10,11 +11,27 @@ import (
 type Database struct {
 	Name  string `db:"datname"`
 	Owner string `db:"owner"`
+	// ConnectionLimit is the maximum number of concurrent connections to the database, -1 means no limit
+	ConnectionLimit  int32  `db:"datconnlimit"`
+	AllowConnections bool   `db:"datallowconn"`
+	IsTemplate       bool   `db:"datistemplate"`
+	Tablespace       string `db:"tablespace"`
 
-	Extensions []string `db:"-"`
+	// Options only applied on creation, empty means the server's default
+	Template       string `db:"-"`
+	Encoding       string `db:"-"`
+	LcCollate      string `db:"-"`
+	LcCtype        string `db:"-"`
+	LocaleProvider string `db:"-"`
+	IcuLocale      string `db:"-"`
+
+	Extensions []Extension `db:"-"`
 }
 
-const GetDatabaseSQLStatement = "SELECT d.datname, pg_catalog.pg_get_userbyid(d.datdba) as owner FROM pg_catalog.pg_database d WHERE d.datname = $1"
+const GetDatabaseSQLStatement = `SELECT d.datname, pg_catalog.pg_get_userbyid(d.datdba) as owner, d.datconnlimit, d.datallowconn, d.datistemplate, t.spcname AS tablespace
+FROM pg_catalog.pg_database d
+JOIN pg_catalog.pg_tablespace t ON t.oid = d.dattablespace
+WHERE d.datname = $1`
 
 func GetDatabase(pgpool PGPoolInterface, name string) (database *Database, err error) {
 	rows, err := pgpool.Query(context.Background(), GetDatabaseSQLStatement, name)
@@ -43,73 +60,111 @@ func GetDatabase(pgpool PGPoolInterface, name string) (database *Database, err e
 	return
 }
 
-func CreateDatabase(pgpool PGPoolInterface, database string) (err error) {
-	sanitizedName := pgx.Identifier{database}.Sanitize()
-	_, err = pgpool.Exec(context.Background(), fmt.Sprintf("CREATE DATABASE %s", sanitizedName))
+func CreateDatabase(pgpool PGPoolInterface, database *Database) (err error) {
+	sanitizedName := pgx.Identifier{database.Name}.Sanitize()
+
+	options := []string{}
+	if database.Template != "" {
+		options = append(options, fmt.Sprintf("TEMPLATE %s", pgx.Identifier{database.Template}.Sanitize()))
+	}
+	if database.Encoding != "" {
+		options = append(options, fmt.Sprintf("ENCODING %s", quoteLiteral(database.Encoding)))
+	}
+	if database.LcCollate != "" {
+		options = append(options, fmt.Sprintf("LC_COLLATE %s", quoteLiteral(database.LcCollate)))
+	}
+	if database.LcCtype != "" {
+		options = append(options, fmt.Sprintf("LC_CTYPE %s", quoteLiteral(database.LcCtype)))
+	}
+	if database.LocaleProvider != "" {
+		options = append(options, fmt.Sprintf("LOCALE_PROVIDER %s", quoteLiteral(database.LocaleProvider)))
+	}
+	if database.IcuLocale != "" {
+		options = append(options, fmt.Sprintf("ICU_LOCALE %s", quoteLiteral(database.IcuLocale)))
+	}
+	if database.Tablespace != "" {
+		options = append(options, fmt.Sprintf("TABLESPACE %s", pgx.Identifier{database.Tablespace}.Sanitize()))
+	}
+
+	// A new database accepts connections without limit and isn't a template
+	options = append(options, generateDatabaseOptions(&Database{ConnectionLimit: -1, AllowConnections: true}, database)...)
+
+	statement := fmt.Sprintf("CREATE DATABASE %s", sanitizedName)
+	if len(options) > 0 {
+		statement += " WITH " + strings.Join(options, " ")
+	}
+
+	_, err = pgpool.Exec(context.Background(), statement)
 	if err != nil {
 		return fmt.Errorf("failed to create database: %s", err)
 	}
 	return
 }
 
-func DropDatabase(pgpool PGPoolInterface, database string) (err error) {
-	sanitizedName := pgx.Identifier{database}.Sanitize()
-	_, err = pgpool.Exec(context.Background(), fmt.Sprintf("DROP DATABASE %s", sanitizedName))
+// AlterDatabase changes the connection options of the database that differ from the existing ones
+func AlterDatabase(pgpool PGPoolInterface, existingDatabase, desiredDatabase *Database) (err error) {
+	options := generateDatabaseOptions(existingDatabase, desiredDatabase)
+	if len(options) == 0 {
+		return
+	}
+
+	sanitizedName := pgx.Identifier{desiredDatabase.Name}.Sanitize()
+	_, err = pgpool.Exec(context.Background(), fmt.Sprintf("ALTER DATABASE %s WITH %s", sanitizedName, strings.Join(options, " ")))
 	if err != nil {
-		return fmt.Errorf("failed to drop database: %s", err)
+		return fmt.Errorf("failed to alter database: %s", err)
 	}
 	return
 }
 
-func AlterDatabaseOwner(pgpool PGPoolInterface, database, owner string) (err error) {
+// AlterDatabaseTablespace moves the database to the tablespace, which fails if other sessions are connected to it
+func AlterDatabaseTablespace(pgpool PGPoolInterface, database, tablespace string) (err error) {
 	sanitizedDatabaseName := pgx.Identifier{database}.Sanitize()
-	sanitizedOwnerName := pgx.Identifier{owner}.Sanitize()
-	_, err = pgpool.Exec(context.Background(), fmt.Sprintf("ALTER DATABASE %s OWNER TO %s", sanitizedDatabaseName, sanitizedOwnerName))
+	sanitizedTablespaceName := pgx.Identifier{tablespace}.Sanitize()
+	_, err = pgpool.Exec(context.Background(), fmt.Sprintf("ALTER DATABASE %s SET TABLESPACE %s", sanitizedDatabaseName, sanitizedTablespaceName))
 	if err != nil {
-		return fmt.Errorf("failed to alter database owner: %s", err)
+		return fmt.Errorf("failed to alter database tablespace: %s", err)
 	}
 	return
 }
 
-func GetExtensions(pgpool PGPoolInterface) (extensions []string, err error) {
-	rows, err := pgpool.Query(context.Background(), "SELECT extname FROM pg_extension")
-	if err != nil {
-		err = fmt.Errorf("pg query failed: %s", err)
-		return
+func generateDatabaseOptions(existingDatabase, desiredDatabase *Database) (options []string) {
+	if existingDatabase.AllowConnections != desiredDatabase.AllowConnections {
+		options = append(options, fmt.Sprintf("ALLOW_CONNECTIONS %t", desiredDatabase.AllowConnections))
 	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var extension string
-		err := rows.Scan(&extension)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read rows: %s", err)
-		}
-		extensions = append(extensions, extension)
+	if existingDatabase.ConnectionLimit != desiredDatabase.ConnectionLimit {
+		options = append(options, fmt.Sprintf("CONNECTION LIMIT %d", desiredDatabase.ConnectionLimit))
 	}
-	return
+	if existingDatabase.IsTemplate != desiredDatabase.IsTemplate {
+		options = append(options, fmt.Sprintf("IS_TEMPLATE %t", desiredDatabase.IsTemplate))
+	}
+	return options
+}
+
+func quoteLiteral(value string) string {
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(value, "'", "''"))
 }
 
-func CreateExtension(pgpool PGPoolInterface, name string) (err error) {
-	sanitizedName := pgx.Identifier{name}.Sanitize()
-	_, err = pgpool.Exec(context.Background(), fmt.Sprintf("CREATE EXTENSION %s", sanitizedName))
+func DropDatabase(pgpool PGPoolInterface, database string) (err error) {
+	sanitizedName := pgx.Identifier{database}.Sanitize()
+	_, err = pgpool.Exec(context.Background(), fmt.Sprintf("DROP DATABASE %s", sanitizedName))
 	if err != nil {
-		return fmt.Errorf("failed to create extension: %s", err)
+		return fmt.Errorf("failed to drop database: %s", err)
 	}
 	return
 }
 
-func DropExtension(pgpool PGPoolInterface, name string) (err error) {
-	sanitizedName := pgx.Identifier{name}.Sanitize()
-	_, err = pgpool.Exec(context.Background(), fmt.Sprintf("DROP EXTENSION %s", sanitizedName))
+func AlterDatabaseOwner(pgpool PGPoolInterface, database, owner string) (err error) {
+	sanitizedDatabaseName := pgx.Identifier{database}.Sanitize()
+	sanitizedOwnerName := pgx.Identifier{owner}.Sanitize()
+	_, err = pgpool.Exec(context.Background(), fmt.Sprintf("ALTER DATABASE %s OWNER TO %s", sanitizedDatabaseName, sanitizedOwnerName))
 	if err != nil {
-		return fmt.Errorf("failed to drop extension: %s", err)
+		return fmt.Errorf("failed to alter database owner: %s", err)
 	}
 	return
 }
 
 func DropDatabaseConnections(pgpool PGPoolInterface, name string) (err error) {
-	_, err = pgpool.Query(context.Background(), "SELECT pg_terminate_backend(pid) FROM pg_stat_activity WHERE datname = $1", name)
+	_, err = pgpool.Exec(context.Background(), "SELECT pg_terminate_backend(pid) FROM pg_stat_activity WHERE datname = $1", name)
 	if err != nil {
 		return fmt.Errorf("failed to drop database connections: %s", err)
 	}
@@ -188,3 +243,24 @@ func ListDatabases(pgpool PGPoolInterface) (databases []string, err error) {
 
 	return
 }
+
+const ListConnectableDatabasesSQLStatement = `SELECT d.datname FROM pg_database d JOIN pg_roles r ON r.rolname = $1 WHERE r.rolcanlogin AND d.datallowconn AND NOT d.datistemplate AND has_database_privilege(r.oid, d.oid, 'CONNECT') ORDER BY d.datname`
+
+// ListConnectableDatabases returns the databases accepting connections on which the role has the CONNECT privilege.
+// It returns none if the role doesn't exist or can't log in, as it can't connect to any database.
+func ListConnectableDatabases(pgpool PGPoolInterface, role string) (databases []string, err error) {
+	rows, err := pgpool.Query(context.Background(), ListConnectableDatabasesSQLStatement, role)
+	if err != nil {
+		err = fmt.Errorf("pg query failed: %s", err)
+		return
+	}
+	defer rows.Close()
+
+	databases, err = pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		err = fmt.Errorf("failed to collect rows: %s", err)
+		return
+	}
+
+	return
+}
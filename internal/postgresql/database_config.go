@@ -0,0 +1,65 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// DatabaseConfigParameter is a configuration parameter set on a database for all roles
+type DatabaseConfigParameter struct {
+	Name  string `db:"name"`
+	Value string `db:"value"`
+}
+
+// The parameters set on a database for all roles are stored with the role 0
+const GetDatabaseConfigSQLStatement = `SELECT split_part(c.setting, '=', 1) AS name, substr(c.setting, strpos(c.setting, '=') + 1) AS value
+FROM pg_db_role_setting s
+JOIN pg_database d ON d.oid = s.setdatabase
+CROSS JOIN LATERAL unnest(s.setconfig) AS c(setting)
+WHERE s.setrole = 0 AND d.datname = $1`
+
+// GetDatabaseConfig returns the configuration parameters set on the database
+func GetDatabaseConfig(pgpool PGPoolInterface, database string) (parameters []DatabaseConfigParameter, err error) {
+	rows, err := pgpool.Query(context.Background(), GetDatabaseConfigSQLStatement, database)
+	if err != nil {
+		return []DatabaseConfigParameter{}, fmt.Errorf("pg query failed: %s", err)
+	}
+	defer rows.Close()
+
+	parameters, err = pgx.CollectRows(rows, pgx.RowToStructByName[DatabaseConfigParameter])
+	if err != nil {
+		return []DatabaseConfigParameter{}, fmt.Errorf("failed to collect rows: %s", err)
+	}
+
+	return parameters, nil
+}
+
+// SetDatabaseConfigParameter sets the parameter's default value for the sessions connected to the database
+func SetDatabaseConfigParameter(pgpool PGPoolInterface, database, name, value string) (err error) {
+	sanitizedName, err := sanitizeConfigParameterName(name)
+	if err != nil {
+		return err
+	}
+
+	_, err = pgpool.Exec(context.Background(), fmt.Sprintf("ALTER DATABASE %s SET %s = %s", pgx.Identifier{database}.Sanitize(), sanitizedName, quoteConfigParameterValue(name, value)))
+	if err != nil {
+		return fmt.Errorf("pg exec failed: %s", err)
+	}
+	return nil
+}
+
+// ResetDatabaseConfigParameter removes the parameter's default value for the sessions connected to the database
+func ResetDatabaseConfigParameter(pgpool PGPoolInterface, database, name string) (err error) {
+	sanitizedName, err := sanitizeConfigParameterName(name)
+	if err != nil {
+		return err
+	}
+
+	_, err = pgpool.Exec(context.Background(), fmt.Sprintf("ALTER DATABASE %s RESET %s", pgx.Identifier{database}.Sanitize(), sanitizedName))
+	if err != nil {
+		return fmt.Errorf("pg exec failed: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package postgresql
+
+import (
+	"fmt"
+	"regexp"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	pgxmock "github.com/pashagolub/pgxmock/v4"
+)
+
+var _ = Describe("PostgreSQL Database Config", func() {
+	var pgpoolMock pgxmock.PgxPoolIface
+	var pgpool PGPoolInterface
+
+	BeforeEach(func() {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			Fail(err.Error())
+		}
+		pgpoolMock = mock
+		pgpool = mock
+	})
+	AfterEach(func() {
+		pgpoolMock.Close()
+	})
+
+	Context("Calling GetDatabaseConfig", func() {
+		It("should return the parameters set on the database", func() {
+			pgpoolMock.ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(GetDatabaseConfigSQLStatement))).
+				WithArgs("mydb").
+				WillReturnRows(
+					pgxmock.NewRows([]string{"name", "value"}).
+						AddRow("timezone", "UTC").
+						AddRow("search_path", "\"$user\", public"),
+				)
+
+			parameters, err := GetDatabaseConfig(pgpool, "mydb")
+
+			Expect(err).NotTo(HaveOccurred())
+			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+				Fail(err.Error())
+			}
+
+			Expect(parameters).To(Equal([]DatabaseConfigParameter{
+				{Name: "timezone", Value: "UTC"},
+				{Name: "search_path", Value: "\"$user\", public"},
+			}))
+		})
+
+		It("should return an error if the PostgreSQL request failed", func() {
+			pgpoolMock.ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(GetDatabaseConfigSQLStatement))).
+				WithArgs("mydb").
+				WillReturnError(fmt.Errorf("fake error from PostgreSQL"))
+
+			parameters, err := GetDatabaseConfig(pgpool, "mydb")
+
+			Expect(err).To(HaveOccurred())
+			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+				Fail(err.Error())
+			}
+			Expect(parameters).To(BeEmpty())
+		})
+	})
+
+	Context("Calling SetDatabaseConfigParameter", func() {
+		It("should set the parameter on the database", func() {
+			pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`ALTER DATABASE "mydb" SET "default_transaction_isolation" = 'repeatable read'`))).
+				WillReturnResult(pgxmock.NewResult("ALTER DATABASE", 0))
+
+			err := SetDatabaseConfigParameter(pgpool, "mydb", "default_transaction_isolation", "repeatable read")
+
+			Expect(err).NotTo(HaveOccurred())
+			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+				Fail(err.Error())
+			}
+		})
+
+		It("should set each element of a list parameter", func() {
+			pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`ALTER DATABASE "mydb" SET "search_path" = '$user', 'public'`))).
+				WillReturnResult(pgxmock.NewResult("ALTER DATABASE", 0))
+
+			err := SetDatabaseConfigParameter(pgpool, "mydb", "search_path", "\"$user\", public")
+
+			Expect(err).NotTo(HaveOccurred())
+			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+				Fail(err.Error())
+			}
+		})
+
+		It("should return an error if the parameter's name is empty", func() {
+			err := SetDatabaseConfigParameter(pgpool, "mydb", "", "UTC")
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("Calling ResetDatabaseConfigParameter", func() {
+		It("should reset the parameter on the database", func() {
+			pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`ALTER DATABASE "mydb" RESET "timezone"`))).
+				WillReturnResult(pgxmock.NewResult("ALTER DATABASE", 0))
+
+			err := ResetDatabaseConfigParameter(pgpool, "mydb", "timezone")
+
+			Expect(err).NotTo(HaveOccurred())
+			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+				Fail(err.Error())
+			}
+		})
+	})
+})
@@ -34,10 +34,18 @@ var _ = Describe("PostgreSQL Database", func() {
 					pgxmock.NewRows([]string{
 						"datname",
 						"owner",
+						"datconnlimit",
+						"datallowconn",
+						"datistemplate",
+						"tablespace",
 					}).
 						AddRow(
 							"foo",
 							"foo_owner",
+							int32(-1),
+							true,
+							false,
+							"pg_default",
 						),
 				)
 
@@ -45,6 +53,10 @@ var _ = Describe("PostgreSQL Database", func() {
 
 			Expect(database.Name).To(Equal("foo"))
 			Expect(database.Owner).To(Equal("foo_owner"))
+			Expect(database.ConnectionLimit).To(Equal(int32(-1)))
+			Expect(database.AllowConnections).To(BeTrue())
+			Expect(database.IsTemplate).To(BeFalse())
+			Expect(database.Tablespace).To(Equal("pg_default"))
 			Expect(err).NotTo(HaveOccurred())
 			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
 				Fail(err.Error())
@@ -133,10 +145,33 @@ var _ = Describe("PostgreSQL Database", func() {
 
 	Context("Calling CreateDatabase", func() {
 		It("should create a database and return no error", func() {
-			pgpoolMock.ExpectExec(regexp.QuoteMeta(`CREATE DATABASE "foo"`)).
+			pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`CREATE DATABASE "foo"`))).
 				WillReturnResult(pgxmock.NewResult("foo", 1))
 
-			err := CreateDatabase(pgpool, "foo")
+			err := CreateDatabase(pgpool, &Database{Name: "foo", ConnectionLimit: -1, AllowConnections: true})
+
+			Expect(err).NotTo(HaveOccurred())
+			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+				Fail(err.Error())
+			}
+		})
+		It("should create a database with the creation options and return no error", func() {
+			pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`CREATE DATABASE "foo" WITH TEMPLATE "template0" ENCODING 'UTF8' LC_COLLATE 'C' LC_CTYPE 'C' LOCALE_PROVIDER 'icu' ICU_LOCALE 'en-US' TABLESPACE "fast" ALLOW_CONNECTIONS false CONNECTION LIMIT 10 IS_TEMPLATE true`))).
+				WillReturnResult(pgxmock.NewResult("foo", 1))
+
+			err := CreateDatabase(pgpool, &Database{
+				Name:             "foo",
+				ConnectionLimit:  10,
+				AllowConnections: false,
+				IsTemplate:       true,
+				Tablespace:       "fast",
+				Template:         "template0",
+				Encoding:         "UTF8",
+				LcCollate:        "C",
+				LcCtype:          "C",
+				LocaleProvider:   "icu",
+				IcuLocale:        "en-US",
+			})
 
 			Expect(err).NotTo(HaveOccurred())
 			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
@@ -147,7 +182,7 @@ var _ = Describe("PostgreSQL Database", func() {
 			pgpoolMock.ExpectExec(regexp.QuoteMeta(`CREATE DATABASE "foo"`)).
 				WillReturnError(fmt.Errorf("fake error from PostgreSQL"))
 
-			err := CreateDatabase(pgpool, "foo")
+			err := CreateDatabase(pgpool, &Database{Name: "foo", ConnectionLimit: -1, AllowConnections: true})
 
 			Expect(err).To(HaveOccurred())
 			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
@@ -206,157 +241,67 @@ var _ = Describe("PostgreSQL Database", func() {
 		})
 	})
 
-	Context("Calling GetExtensions", func() {
-		It("should return the list of installed extensions in a database", func() {
-			pgpoolMock.ExpectQuery(regexp.QuoteMeta(`SELECT extname FROM pg_extension`)).
-				WillReturnRows(
-					pgxmock.NewRows([]string{
-						"extname",
-					}).
-						AddRow(
-							"plpgsql",
-						),
-				)
+	Context("Calling AlterDatabase", func() {
+		It("should only alter the options which have changed", func() {
+			pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`ALTER DATABASE "foo" WITH CONNECTION LIMIT 10 IS_TEMPLATE true`))).
+				WillReturnResult(pgxmock.NewResult("ALTER DATABASE", 0))
 
-			extensions, err := GetExtensions(pgpool)
+			err := AlterDatabase(pgpool,
+				&Database{Name: "foo", ConnectionLimit: -1, AllowConnections: true},
+				&Database{Name: "foo", ConnectionLimit: 10, AllowConnections: true, IsTemplate: true},
+			)
 
 			Expect(err).NotTo(HaveOccurred())
 			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
 				Fail(err.Error())
 			}
-
-			Expect(extensions).To(Equal([]string{"plpgsql"}))
-		})
-		It("should return an error if the PostgreSQL request failed", func() {
-			pgpoolMock.ExpectQuery(regexp.QuoteMeta(`SELECT extname FROM pg_extension`)).
-				WillReturnError(fmt.Errorf("fake error from PostgreSQL"))
-
-			extensions, err := GetExtensions(pgpool)
-
-			Expect(err).To(HaveOccurred())
-			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
-				Fail(err.Error())
-			}
-			Expect(extensions).To(BeNil())
-		})
-		It("should return an error if the row cannot be scanned", func() {
-			pgpoolMock.ExpectQuery(regexp.QuoteMeta(`SELECT extname FROM pg_extension`)).
-				WillReturnRows(
-					pgxmock.NewRows([]string{
-						"extname",
-					}).
-						AddRow(
-							"plpgsql",
-						).
-						RowError(0, fmt.Errorf("row error")),
-				)
-
-			extensions, err := GetExtensions(pgpool)
-
-			Expect(err).To(HaveOccurred())
-			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
-				Fail(err.Error())
-			}
-			Expect(extensions).To(BeNil())
 		})
-
-	})
-
-	Context("Calling CreateExtension", func() {
-		It("should return the list of installed extensions in a database", func() {
-			pgpoolMock.ExpectQuery(regexp.QuoteMeta(`SELECT extname FROM pg_extension`)).
-				WillReturnRows(
-					pgxmock.NewRows([]string{
-						"extname",
-					}).
-						AddRow(
-							"plpgsql",
-						),
-				)
-
-			extensions, err := GetExtensions(pgpool)
+		It("should do nothing if no option has changed", func() {
+			err := AlterDatabase(pgpool,
+				&Database{Name: "foo", ConnectionLimit: -1, AllowConnections: true},
+				&Database{Name: "foo", ConnectionLimit: -1, AllowConnections: true},
+			)
 
 			Expect(err).NotTo(HaveOccurred())
 			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
 				Fail(err.Error())
 			}
-
-			Expect(extensions).To(Equal([]string{"plpgsql"}))
 		})
 		It("should return an error if the PostgreSQL request failed", func() {
-			pgpoolMock.ExpectQuery(regexp.QuoteMeta(`SELECT extname FROM pg_extension`)).
+			pgpoolMock.ExpectExec(regexp.QuoteMeta(`ALTER DATABASE "foo" WITH ALLOW_CONNECTIONS false`)).
 				WillReturnError(fmt.Errorf("fake error from PostgreSQL"))
 
-			extensions, err := GetExtensions(pgpool)
+			err := AlterDatabase(pgpool,
+				&Database{Name: "foo", ConnectionLimit: -1, AllowConnections: true},
+				&Database{Name: "foo", ConnectionLimit: -1, AllowConnections: false},
+			)
 
 			Expect(err).To(HaveOccurred())
 			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
 				Fail(err.Error())
 			}
-			Expect(extensions).To(BeNil())
 		})
 	})
 
-	Context("Calling CreateExtension", func() {
-		It("should create an extension and return no error", func() {
-			pgpoolMock.ExpectExec(regexp.QuoteMeta(`CREATE EXTENSION "foo"`)).
-				WillReturnResult(pgxmock.NewResult("foo", 1))
+	Context("Calling AlterDatabaseTablespace", func() {
+		It("should move the database and return no error", func() {
+			pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`ALTER DATABASE "foo" SET TABLESPACE "fast"`))).
+				WillReturnResult(pgxmock.NewResult("ALTER DATABASE", 0))
 
-			err := CreateExtension(pgpool, "foo")
+			err := AlterDatabaseTablespace(pgpool, "foo", "fast")
 
 			Expect(err).NotTo(HaveOccurred())
 			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
 				Fail(err.Error())
 			}
 		})
-		It("should return an error if the PostgreSQL request failed", func() {
-			pgpoolMock.ExpectExec(regexp.QuoteMeta(`CREATE EXTENSION "foo"`)).
-				WillReturnError(fmt.Errorf("fake error from PostgreSQL"))
-
-			err := CreateExtension(pgpool, "foo")
-
-			Expect(err).To(HaveOccurred())
-			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
-				Fail(err.Error())
-			}
-		})
-	})
-
-	Context("Calling DropExtension", func() {
-		It("should drop an extension and return no error", func() {
-			pgpoolMock.ExpectExec(regexp.QuoteMeta(`DROP EXTENSION "foo"`)).
-				WillReturnResult(pgxmock.NewResult("foo", 1))
-
-			err := DropExtension(pgpool, "foo")
-
-			Expect(err).NotTo(HaveOccurred())
-			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
-				Fail(err.Error())
-			}
-		})
-		It("should return an error if the PostgreSQL request failed", func() {
-			pgpoolMock.ExpectExec(regexp.QuoteMeta(`DROP EXTENSION "foo"`)).
-				WillReturnError(fmt.Errorf("fake error from PostgreSQL"))
-
-			err := DropExtension(pgpool, "foo")
-
-			Expect(err).To(HaveOccurred())
-			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
-				Fail(err.Error())
-			}
-		})
 	})
 
 	Context("Calling DropDatabaseConnections", func() {
 		It("should drop connections to the database and return no error", func() {
-			pgpoolMock.ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(`SELECT pg_terminate_backend(pid) FROM pg_stat_activity WHERE datname = $1`))).
+			pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`SELECT pg_terminate_backend(pid) FROM pg_stat_activity WHERE datname = $1`))).
 				WithArgs("foo").
-				WillReturnRows(
-					pgxmock.NewRows([]string{
-						"pg_terminate_backend",
-					}).
-						AddRow(""),
-				)
+				WillReturnResult(pgxmock.NewResult("SELECT", 1))
 
 			err := DropDatabaseConnections(pgpool, "foo")
 
@@ -367,7 +312,7 @@ var _ = Describe("PostgreSQL Database", func() {
 		})
 
 		It("should return an error if the PostgreSQL request failed", func() {
-			pgpoolMock.ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(`SELECT pg_terminate_backend(pid) FROM pg_stat_activity WHERE datname = $1`))).
+			pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`SELECT pg_terminate_backend(pid) FROM pg_stat_activity WHERE datname = $1`))).
 				WithArgs("foo").
 				WillReturnError(fmt.Errorf("fake error from PostgreSQL"))
 
@@ -520,4 +465,45 @@ var _ = Describe("PostgreSQL Database", func() {
 
 	})
 
+	Context("Calling ListConnectableDatabases", func() {
+		It("should return the databases the role can connect to", func() {
+			pgpoolMock.ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(ListConnectableDatabasesSQLStatement))).
+				WithArgs("myrole").
+				WillReturnRows(
+					pgxmock.NewRows([]string{
+						"datname",
+					}).
+						AddRow(
+							"foo",
+						).
+						AddRow(
+							"postgres",
+						),
+				)
+
+			databases, err := ListConnectableDatabases(pgpool, "myrole")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(databases).To(Equal([]string{"foo", "postgres"}))
+			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+				Fail(err.Error())
+			}
+		})
+
+		It("should return an error if the PostgreSQL request failed", func() {
+			pgpoolMock.ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(ListConnectableDatabasesSQLStatement))).
+				WithArgs("myrole").
+				WillReturnError(fmt.Errorf("fake error from PostgreSQL"))
+
+			databases, err := ListConnectableDatabases(pgpool, "myrole")
+
+			Expect(err).To(HaveOccurred())
+			Expect(databases).To(BeEmpty())
+			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+				Fail(err.Error())
+			}
+		})
+
+	})
+
 })
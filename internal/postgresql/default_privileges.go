@@ -0,0 +1,117 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Object types on which default privileges can be defined
+const (
+	ObjectTypeTables    = "TABLES"
+	ObjectTypeSequences = "SEQUENCES"
+	ObjectTypeFunctions = "FUNCTIONS"
+	ObjectTypeTypes     = "TYPES"
+)
+
+// defaultACLObjectTypes maps object types to their value in pg_default_acl.defaclobjtype
+var defaultACLObjectTypes = map[string]string{
+	ObjectTypeTables:    "r",
+	ObjectTypeSequences: "S",
+	ObjectTypeFunctions: "f",
+	ObjectTypeTypes:     "T",
+}
+
+func ListDefaultPrivilegesObjectTypes() []string {
+	return []string{
+		ObjectTypeTables,
+		ObjectTypeSequences,
+		ObjectTypeFunctions,
+		ObjectTypeTypes,
+	}
+}
+
+func ListObjectTypeAvailablePrivileges(objectType string) []string {
+	switch objectType {
+	case ObjectTypeTables:
+		return []string{
+			"SELECT",
+			"INSERT",
+			"UPDATE",
+			"DELETE",
+			"TRUNCATE",
+			"REFERENCES",
+			"TRIGGER",
+		}
+	case ObjectTypeSequences:
+		return []string{
+			"USAGE",
+			"SELECT",
+			"UPDATE",
+		}
+	case ObjectTypeFunctions:
+		return []string{
+			"EXECUTE",
+		}
+	case ObjectTypeTypes:
+		return []string{
+			"USAGE",
+		}
+	}
+	return []string{}
+}
+
+const GetSchemaDefaultPrivilegesSQLStatement = `SELECT acl.privilege_type
+FROM pg_default_acl d
+JOIN pg_namespace n ON n.oid = d.defaclnamespace
+JOIN pg_roles grantor ON grantor.oid = d.defaclrole
+CROSS JOIN LATERAL aclexplode(d.defaclacl) acl
+JOIN pg_roles grantee ON grantee.oid = acl.grantee
+WHERE n.nspname = $1 AND grantor.rolname = $2 AND grantee.rolname = $3 AND d.defaclobjtype = $4`
+
+func GetSchemaDefaultPrivileges(pgpool PGPoolInterface, schema, grantor, grantee, objectType string) (existingPrivileges []string, err error) {
+	defaclObjectType, ok := defaultACLObjectTypes[objectType]
+	if !ok {
+		return []string{}, fmt.Errorf("unsupported object type \"%s\"", objectType)
+	}
+
+	rows, err := pgpool.Query(context.Background(), GetSchemaDefaultPrivilegesSQLStatement, schema, grantor, grantee, defaclObjectType)
+	if err != nil {
+		return []string{}, fmt.Errorf("pg query failed: %s", err)
+	}
+	defer rows.Close()
+
+	existingPrivileges, err = pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return []string{}, fmt.Errorf("failed to collect rows: %s", err)
+	}
+
+	return existingPrivileges, err
+}
+
+func GrantSchemaDefaultPrivilege(pgpool PGPoolInterface, schema, grantor, grantee, objectType, privilege string) (err error) {
+	sanitizedSchema := pgx.Identifier{schema}.Sanitize()
+	sanitizedGrantor := pgx.Identifier{grantor}.Sanitize()
+	sanitizedGrantee := pgx.Identifier{grantee}.Sanitize()
+
+	_, err = pgpool.Exec(context.Background(), fmt.Sprintf("ALTER DEFAULT PRIVILEGES FOR ROLE %s IN SCHEMA %s GRANT %s ON %s TO %s", sanitizedGrantor, sanitizedSchema, privilege, objectType, sanitizedGrantee))
+	if err != nil {
+		return fmt.Errorf("failed to grant default privilege \"%s\" on %s in schema %s to role %s: %s", privilege, objectType, sanitizedSchema, sanitizedGrantee, err)
+	}
+
+	return
+}
+
+func RevokeSchemaDefaultPrivilege(pgpool PGPoolInterface, schema, grantor, grantee, objectType, privilege string) (err error) {
+	sanitizedSchema := pgx.Identifier{schema}.Sanitize()
+	sanitizedGrantor := pgx.Identifier{grantor}.Sanitize()
+	sanitizedGrantee := pgx.Identifier{grantee}.Sanitize()
+
+	_, err = pgpool.Exec(context.Background(), fmt.Sprintf("ALTER DEFAULT PRIVILEGES FOR ROLE %s IN SCHEMA %s REVOKE %s ON %s FROM %s", sanitizedGrantor, sanitizedSchema, privilege, objectType, sanitizedGrantee))
+	if err != nil {
+		return fmt.Errorf("failed to revoke default privilege \"%s\" on %s in schema %s from role %s: %s", privilege, objectType, sanitizedSchema, sanitizedGrantee, err)
+	}
+
+	return
+}
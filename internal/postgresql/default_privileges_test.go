@@ -0,0 +1,143 @@
+package postgresql
+
+import (
+	"fmt"
+	"regexp"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	pgxmock "github.com/pashagolub/pgxmock/v4"
+)
+
+var _ = Describe("PostgreSQL Default Privileges", func() {
+	var pgpoolMock pgxmock.PgxPoolIface
+	var pgpool PGPoolInterface
+
+	BeforeEach(func() {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			Fail(err.Error())
+		}
+		pgpoolMock = mock
+		pgpool = mock
+	})
+	AfterEach(func() {
+		pgpoolMock.Close()
+	})
+
+	Context("Calling GetSchemaDefaultPrivileges", func() {
+		When("default privileges exist", func() {
+			It("should return the list of privileges", func() {
+				pgpoolMock.ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(GetSchemaDefaultPrivilegesSQLStatement))).
+					WithArgs("myschema", "owner", "reader", "r").
+					WillReturnRows(
+						pgxmock.NewRows([]string{
+							"privilege_type",
+						}).
+							AddRow("SELECT").
+							AddRow("REFERENCES"),
+					)
+
+				privileges, err := GetSchemaDefaultPrivileges(pgpool, "myschema", "owner", "reader", ObjectTypeTables)
+
+				Expect(err).NotTo(HaveOccurred())
+				if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+
+				Expect(privileges).To(Equal([]string{"SELECT", "REFERENCES"}))
+			})
+		})
+
+		When("the object type is not supported", func() {
+			It("should return an error without querying PostgreSQL", func() {
+				privileges, err := GetSchemaDefaultPrivileges(pgpool, "myschema", "owner", "reader", "DOMAINS")
+
+				Expect(err).To(HaveOccurred())
+				if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+
+				Expect(privileges).To(BeEmpty())
+			})
+		})
+
+		When("PostgreSQL returns an error", func() {
+			It("should return an error and an empty list of privileges", func() {
+				pgpoolMock.ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(GetSchemaDefaultPrivilegesSQLStatement))).
+					WithArgs("myschema", "owner", "reader", "S").
+					WillReturnError(fmt.Errorf("fake error from PostgreSQL"))
+
+				privileges, err := GetSchemaDefaultPrivileges(pgpool, "myschema", "owner", "reader", ObjectTypeSequences)
+
+				Expect(err).To(HaveOccurred())
+				if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+
+				Expect(privileges).To(BeEmpty())
+			})
+		})
+	})
+
+	Context("Calling GrantSchemaDefaultPrivilege", func() {
+		When("the schema and roles exist", func() {
+			It("should alter the default privileges and return no error", func() {
+				pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`ALTER DEFAULT PRIVILEGES FOR ROLE "owner" IN SCHEMA "myschema" GRANT SELECT ON TABLES TO "reader"`))).
+					WillReturnResult(pgxmock.NewResult("ALTER DEFAULT PRIVILEGES", 0))
+
+				err := GrantSchemaDefaultPrivilege(pgpool, "myschema", "owner", "reader", ObjectTypeTables, "SELECT")
+
+				Expect(err).NotTo(HaveOccurred())
+				if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+			})
+		})
+
+		When("PostgreSQL returns an error", func() {
+			It("should return an error", func() {
+				pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`ALTER DEFAULT PRIVILEGES FOR ROLE "owner" IN SCHEMA "myschema" GRANT SELECT ON TABLES TO "reader"`))).
+					WillReturnError(fmt.Errorf("fake error from PostgreSQL"))
+
+				err := GrantSchemaDefaultPrivilege(pgpool, "myschema", "owner", "reader", ObjectTypeTables, "SELECT")
+
+				Expect(err).To(HaveOccurred())
+				if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+			})
+		})
+	})
+
+	Context("Calling RevokeSchemaDefaultPrivilege", func() {
+		When("the schema and roles exist", func() {
+			It("should alter the default privileges and return no error", func() {
+				pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`ALTER DEFAULT PRIVILEGES FOR ROLE "owner" IN SCHEMA "myschema" REVOKE EXECUTE ON FUNCTIONS FROM "reader"`))).
+					WillReturnResult(pgxmock.NewResult("ALTER DEFAULT PRIVILEGES", 0))
+
+				err := RevokeSchemaDefaultPrivilege(pgpool, "myschema", "owner", "reader", ObjectTypeFunctions, "EXECUTE")
+
+				Expect(err).NotTo(HaveOccurred())
+				if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+			})
+		})
+
+		When("PostgreSQL returns an error", func() {
+			It("should return an error", func() {
+				pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`ALTER DEFAULT PRIVILEGES FOR ROLE "owner" IN SCHEMA "myschema" REVOKE EXECUTE ON FUNCTIONS FROM "reader"`))).
+					WillReturnError(fmt.Errorf("fake error from PostgreSQL"))
+
+				err := RevokeSchemaDefaultPrivilege(pgpool, "myschema", "owner", "reader", ObjectTypeFunctions, "EXECUTE")
+
+				Expect(err).To(HaveOccurred())
+				if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+			})
+		})
+	})
+})
@@ -0,0 +1,76 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// passwordClauseRegexp matches the password set by CREATE ROLE and ALTER ROLE, hidden from the plan
+var passwordClauseRegexp = regexp.MustCompile(`PASSWORD '(?:[^']|'')*'`)
+
+// DryRunPlan is the list of the statements which would have been executed on the server during a dry run
+type DryRunPlan struct {
+	statements []string
+}
+
+// Statements returns the planned statements, in their execution order
+func (plan *DryRunPlan) Statements() []string {
+	if plan == nil {
+		return nil
+	}
+	return plan.statements
+}
+
+func (plan *DryRunPlan) record(sql string, arguments []any) {
+	statement := passwordClauseRegexp.ReplaceAllString(sql, "PASSWORD '********'")
+
+	if len(arguments) > 0 {
+		values := []string{}
+		for i, argument := range arguments {
+			values = append(values, fmt.Sprintf("$%d = %s", i+1, quoteLiteral(fmt.Sprint(argument))))
+		}
+		statement += " -- " + strings.Join(values, ", ")
+	}
+
+	plan.statements = append(plan.statements, statement)
+}
+
+// dryRunPGPool runs the queries reading the server's state on the wrapped pool,
+// but records the statements changing it in the plan instead of executing them
+type dryRunPGPool struct {
+	PGPoolInterface
+	plan *DryRunPlan
+}
+
+func (pgpool *dryRunPGPool) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	pgpool.plan.record(sql, arguments)
+	return pgconn.NewCommandTag(""), nil
+}
+
+// Close leaves the wrapped pool open, as it is still used by the operator outside of the dry run
+func (pgpool *dryRunPGPool) Close() {}
+
+// DryRun returns the pools to use for a dry run, which record the statements changing the server in the plan.
+// The pools opened during the dry run are registered in the original pools, so that they are reused.
+func (pgpools *PGPools) DryRun(plan *DryRunPlan) *PGPools {
+	dryRunPGPools := &PGPools{
+		Databases: map[string]PGPoolInterface{},
+		dryRunOf:  pgpools,
+		plan:      plan,
+	}
+
+	if pgpools.Default != nil {
+		dryRunPGPools.Default = &dryRunPGPool{PGPoolInterface: pgpools.Default, plan: plan}
+	}
+	pgpools.mutex.Lock()
+	defer pgpools.mutex.Unlock()
+	for database, pgpool := range pgpools.Databases {
+		dryRunPGPools.Databases[database] = &dryRunPGPool{PGPoolInterface: pgpool, plan: plan}
+	}
+
+	return dryRunPGPools
+}
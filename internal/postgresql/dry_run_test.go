@@ -0,0 +1,92 @@
+package postgresql
+
+import (
+	"fmt"
+	"regexp"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	pgxmock "github.com/pashagolub/pgxmock/v4"
+)
+
+var _ = Describe("PostgreSQL Dry Run", func() {
+	var pgpoolMock pgxmock.PgxPoolIface
+	var pgpools *PGPools
+
+	BeforeEach(func() {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			Fail(err.Error())
+		}
+		pgpoolMock = mock
+		pgpools = &PGPools{
+			Default: mock,
+			Databases: map[string]PGPoolInterface{
+				"foo": mock,
+			},
+		}
+	})
+	AfterEach(func() {
+		pgpoolMock.Close()
+	})
+
+	It("should run the queries reading the server and plan the statements changing it", func() {
+		plan := &DryRunPlan{}
+		dryRunPGPools := pgpools.DryRun(plan)
+
+		pgpoolMock.ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(GetExtensionsSQLStatement))).
+			WillReturnRows(
+				pgxmock.NewRows([]string{"name", "version", "schema", "available_upgrades"}).
+					AddRow("plpgsql", "1.0", "pg_catalog", []string{}),
+			)
+
+		_, err := GetExtensions(dryRunPGPools.Databases["foo"])
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(DropExtension(dryRunPGPools.Databases["foo"], "hstore")).To(Succeed())
+		Expect(DropDatabaseConnections(dryRunPGPools.Default, "foo")).To(Succeed())
+
+		Expect(plan.Statements()).To(Equal([]string{
+			`DROP EXTENSION "hstore"`,
+			`SELECT pg_terminate_backend(pid) FROM pg_stat_activity WHERE datname = $1 -- $1 = 'foo'`,
+		}))
+		if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+			Fail(err.Error())
+		}
+	})
+
+	It("should hide the passwords of the planned statements", func() {
+		plan := &DryRunPlan{}
+		dryRunPGPools := pgpools.DryRun(plan)
+
+		err := CreateRole(dryRunPGPools.Default, &Role{Name: "operator", SuperUser: true}, &Role{Name: "foo", Password: "it's secret"})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(plan.Statements()).To(HaveLen(1))
+		Expect(plan.Statements()[0]).To(HavePrefix(`CREATE ROLE "foo" `))
+		Expect(plan.Statements()[0]).To(ContainSubstring(`PASSWORD '********'`))
+		Expect(plan.Statements()[0]).NotTo(ContainSubstring("SCRAM-SHA-256"))
+	})
+
+	It("should register the pools opened during the dry run in the original pools", func() {
+		plan := &DryRunPlan{}
+		dryRunPGPools := pgpools.DryRun(plan)
+
+		Expect(EnsurePGPoolExists(dryRunPGPools, "bar")).To(Succeed())
+		Expect(pgpools.Databases).To(HaveKey("bar"))
+		Expect(dryRunPGPools.Databases).To(HaveKey("bar"))
+
+		By("Closing the pool of the dry run, the original pool should stay open")
+		ClosePGPool(dryRunPGPools, "bar")
+		Expect(dryRunPGPools.Databases).NotTo(HaveKey("bar"))
+		Expect(pgpools.Databases).To(HaveKey("bar"))
+
+		ClosePGPool(pgpools, "bar")
+	})
+
+	It("should have no statements without a dry run", func() {
+		var plan *DryRunPlan
+		Expect(plan.Statements()).To(BeNil())
+	})
+})
@@ -0,0 +1,145 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Extension is an extension installed in a database
+type Extension struct {
+	Name    string `db:"name"`
+	Version string `db:"version"`
+	Schema  string `db:"schema"`
+	// AvailableUpgrades are the versions the extension can be updated to from its current version
+	AvailableUpgrades []string `db:"available_upgrades"`
+
+	// Cascade installs the extensions required by the extension on creation
+	Cascade bool `db:"-"`
+}
+
+// BuiltinExtensions are installed in every database by PostgreSQL
+var BuiltinExtensions = []string{
+	"plpgsql",
+}
+
+// The available upgrades are the versions installed on the server reachable by an update path from the current version.
+// The versions are free-form strings, so they are ordered by the number of update scripts to run, then by name for stability.
+const GetExtensionsSQLStatement = `SELECT e.extname AS name, e.extversion AS version, n.nspname AS schema,
+ARRAY(
+	SELECT v.version
+	FROM pg_available_extension_versions v
+	JOIN pg_extension_update_paths(e.extname) p ON p.target = v.version
+	WHERE v.name = e.extname AND p.source = e.extversion AND p.path IS NOT NULL
+	ORDER BY array_length(string_to_array(p.path, '--'), 1), v.version
+) AS available_upgrades
+FROM pg_extension e
+JOIN pg_namespace n ON n.oid = e.extnamespace
+ORDER BY e.extname`
+
+// GetExtensions returns the extensions installed in the database the pool is connected to
+func GetExtensions(pgpool PGPoolInterface) (extensions []Extension, err error) {
+	rows, err := pgpool.Query(context.Background(), GetExtensionsSQLStatement)
+	if err != nil {
+		err = fmt.Errorf("pg query failed: %s", err)
+		return
+	}
+	defer rows.Close()
+
+	extensions, err = pgx.CollectRows(rows, pgx.RowToStructByName[Extension])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows: %s", err)
+	}
+	return
+}
+
+// The dependencies between the extensions, e.g. earthdistance requiring cube, are recorded in pg_depend
+const GetExtensionDependenciesSQLStatement = `SELECT e.extname AS extension, r.extname AS required
+FROM pg_depend d
+JOIN pg_extension e ON e.oid = d.objid
+JOIN pg_extension r ON r.oid = d.refobjid
+WHERE d.classid = 'pg_extension'::regclass AND d.refclassid = 'pg_extension'::regclass
+ORDER BY e.extname, r.extname`
+
+type extensionDependency struct {
+	Extension string `db:"extension"`
+	Required  string `db:"required"`
+}
+
+// GetExtensionDependencies returns the extensions required by each extension installed in the database the pool is connected to
+func GetExtensionDependencies(pgpool PGPoolInterface) (dependencies map[string][]string, err error) {
+	rows, err := pgpool.Query(context.Background(), GetExtensionDependenciesSQLStatement)
+	if err != nil {
+		err = fmt.Errorf("pg query failed: %s", err)
+		return
+	}
+	defer rows.Close()
+
+	extensionDependencies, err := pgx.CollectRows(rows, pgx.RowToStructByName[extensionDependency])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows: %s", err)
+	}
+
+	dependencies = map[string][]string{}
+	for _, dependency := range extensionDependencies {
+		dependencies[dependency.Extension] = append(dependencies[dependency.Extension], dependency.Required)
+	}
+	return
+}
+
+// CreateExtension installs the extension, in its default version and schema unless specified
+func CreateExtension(pgpool PGPoolInterface, extension Extension) (err error) {
+	options := []string{}
+	if extension.Schema != "" {
+		options = append(options, fmt.Sprintf("SCHEMA %s", pgx.Identifier{extension.Schema}.Sanitize()))
+	}
+	if extension.Version != "" {
+		options = append(options, fmt.Sprintf("VERSION %s", quoteLiteral(extension.Version)))
+	}
+	if extension.Cascade {
+		options = append(options, "CASCADE")
+	}
+
+	statement := fmt.Sprintf("CREATE EXTENSION %s", pgx.Identifier{extension.Name}.Sanitize())
+	if len(options) > 0 {
+		statement += " WITH " + strings.Join(options, " ")
+	}
+
+	_, err = pgpool.Exec(context.Background(), statement)
+	if err != nil {
+		return fmt.Errorf("failed to create extension: %s", err)
+	}
+	return
+}
+
+// UpdateExtension updates the extension to the version, which requires an update path from the current version
+func UpdateExtension(pgpool PGPoolInterface, name, version string) (err error) {
+	sanitizedName := pgx.Identifier{name}.Sanitize()
+	_, err = pgpool.Exec(context.Background(), fmt.Sprintf("ALTER EXTENSION %s UPDATE TO %s", sanitizedName, quoteLiteral(version)))
+	if err != nil {
+		return fmt.Errorf("failed to update extension: %s", err)
+	}
+	return
+}
+
+// AlterExtensionSchema moves the objects of a relocatable extension to the schema
+func AlterExtensionSchema(pgpool PGPoolInterface, name, schema string) (err error) {
+	sanitizedName := pgx.Identifier{name}.Sanitize()
+	sanitizedSchema := pgx.Identifier{schema}.Sanitize()
+	_, err = pgpool.Exec(context.Background(), fmt.Sprintf("ALTER EXTENSION %s SET SCHEMA %s", sanitizedName, sanitizedSchema))
+	if err != nil {
+		return fmt.Errorf("failed to alter extension schema: %s", err)
+	}
+	return
+}
+
+func DropExtension(pgpool PGPoolInterface, name string) (err error) {
+	sanitizedName := pgx.Identifier{name}.Sanitize()
+	_, err = pgpool.Exec(context.Background(), fmt.Sprintf("DROP EXTENSION %s", sanitizedName))
+	if err != nil {
+		return fmt.Errorf("failed to drop extension: %s", err)
+	}
+	return
+}
@@ -0,0 +1,216 @@
+package postgresql
+
+import (
+	"fmt"
+	"regexp"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	pgxmock "github.com/pashagolub/pgxmock/v4"
+)
+
+var _ = Describe("PostgreSQL Extension", func() {
+	var pgpoolMock pgxmock.PgxPoolIface
+	var pgpool PGPoolInterface
+
+	extensionColumns := []string{"name", "version", "schema", "available_upgrades"}
+
+	BeforeEach(func() {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			Fail(err.Error())
+		}
+		pgpoolMock = mock
+		pgpool = mock
+	})
+	AfterEach(func() {
+		pgpoolMock.Close()
+	})
+
+	Context("Calling GetExtensions", func() {
+		It("should return the list of installed extensions in a database", func() {
+			pgpoolMock.ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(GetExtensionsSQLStatement))).
+				WillReturnRows(
+					pgxmock.NewRows(extensionColumns).
+						AddRow("plpgsql", "1.0", "pg_catalog", []string{}).
+						AddRow("postgis", "3.3.2", "public", []string{"3.4.0", "3.4.2"}),
+				)
+
+			extensions, err := GetExtensions(pgpool)
+
+			Expect(err).NotTo(HaveOccurred())
+			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+				Fail(err.Error())
+			}
+
+			Expect(extensions).To(Equal([]Extension{
+				{Name: "plpgsql", Version: "1.0", Schema: "pg_catalog", AvailableUpgrades: []string{}},
+				{Name: "postgis", Version: "3.3.2", Schema: "public", AvailableUpgrades: []string{"3.4.0", "3.4.2"}},
+			}))
+		})
+		It("should return an error if the PostgreSQL request failed", func() {
+			pgpoolMock.ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(GetExtensionsSQLStatement))).
+				WillReturnError(fmt.Errorf("fake error from PostgreSQL"))
+
+			extensions, err := GetExtensions(pgpool)
+
+			Expect(err).To(HaveOccurred())
+			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+				Fail(err.Error())
+			}
+			Expect(extensions).To(BeNil())
+		})
+		It("should return an error if the row cannot be scanned", func() {
+			pgpoolMock.ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(GetExtensionsSQLStatement))).
+				WillReturnRows(
+					pgxmock.NewRows(extensionColumns).
+						AddRow("plpgsql", "1.0", "pg_catalog", []string{}).
+						RowError(0, fmt.Errorf("row error")),
+				)
+
+			extensions, err := GetExtensions(pgpool)
+
+			Expect(err).To(HaveOccurred())
+			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+				Fail(err.Error())
+			}
+			Expect(extensions).To(BeNil())
+		})
+	})
+
+	Context("Calling GetExtensionDependencies", func() {
+		It("should return the extensions required by each extension", func() {
+			pgpoolMock.ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(GetExtensionDependenciesSQLStatement))).
+				WillReturnRows(
+					pgxmock.NewRows([]string{"extension", "required"}).
+						AddRow("earthdistance", "cube").
+						AddRow("postgis_topology", "postgis"),
+				)
+
+			dependencies, err := GetExtensionDependencies(pgpool)
+
+			Expect(err).NotTo(HaveOccurred())
+			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+				Fail(err.Error())
+			}
+
+			Expect(dependencies).To(Equal(map[string][]string{
+				"earthdistance":    {"cube"},
+				"postgis_topology": {"postgis"},
+			}))
+		})
+		It("should return an error if the PostgreSQL request failed", func() {
+			pgpoolMock.ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(GetExtensionDependenciesSQLStatement))).
+				WillReturnError(fmt.Errorf("fake error from PostgreSQL"))
+
+			dependencies, err := GetExtensionDependencies(pgpool)
+
+			Expect(err).To(HaveOccurred())
+			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+				Fail(err.Error())
+			}
+			Expect(dependencies).To(BeNil())
+		})
+	})
+
+	Context("Calling CreateExtension", func() {
+		It("should create an extension and return no error", func() {
+			pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`CREATE EXTENSION "foo"`))).
+				WillReturnResult(pgxmock.NewResult("foo", 1))
+
+			err := CreateExtension(pgpool, Extension{Name: "foo"})
+
+			Expect(err).NotTo(HaveOccurred())
+			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+				Fail(err.Error())
+			}
+		})
+		It("should create an extension with its schema, version and dependencies", func() {
+			pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`CREATE EXTENSION "postgis" WITH SCHEMA "gis" VERSION '3.4.2' CASCADE`))).
+				WillReturnResult(pgxmock.NewResult("foo", 1))
+
+			err := CreateExtension(pgpool, Extension{Name: "postgis", Schema: "gis", Version: "3.4.2", Cascade: true})
+
+			Expect(err).NotTo(HaveOccurred())
+			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+				Fail(err.Error())
+			}
+		})
+		It("should return an error if the PostgreSQL request failed", func() {
+			pgpoolMock.ExpectExec(regexp.QuoteMeta(`CREATE EXTENSION "foo"`)).
+				WillReturnError(fmt.Errorf("fake error from PostgreSQL"))
+
+			err := CreateExtension(pgpool, Extension{Name: "foo"})
+
+			Expect(err).To(HaveOccurred())
+			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+				Fail(err.Error())
+			}
+		})
+	})
+
+	Context("Calling UpdateExtension", func() {
+		It("should update the extension to the version", func() {
+			pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`ALTER EXTENSION "postgis" UPDATE TO '3.4.2'`))).
+				WillReturnResult(pgxmock.NewResult("ALTER EXTENSION", 0))
+
+			err := UpdateExtension(pgpool, "postgis", "3.4.2")
+
+			Expect(err).NotTo(HaveOccurred())
+			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+				Fail(err.Error())
+			}
+		})
+		It("should return an error if the PostgreSQL request failed", func() {
+			pgpoolMock.ExpectExec(regexp.QuoteMeta(`ALTER EXTENSION "postgis" UPDATE TO '1.0'`)).
+				WillReturnError(fmt.Errorf("fake error from PostgreSQL"))
+
+			err := UpdateExtension(pgpool, "postgis", "1.0")
+
+			Expect(err).To(HaveOccurred())
+			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+				Fail(err.Error())
+			}
+		})
+	})
+
+	Context("Calling AlterExtensionSchema", func() {
+		It("should move the extension to the schema", func() {
+			pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`ALTER EXTENSION "hstore" SET SCHEMA "extensions"`))).
+				WillReturnResult(pgxmock.NewResult("ALTER EXTENSION", 0))
+
+			err := AlterExtensionSchema(pgpool, "hstore", "extensions")
+
+			Expect(err).NotTo(HaveOccurred())
+			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+				Fail(err.Error())
+			}
+		})
+	})
+
+	Context("Calling DropExtension", func() {
+		It("should drop an extension and return no error", func() {
+			pgpoolMock.ExpectExec(regexp.QuoteMeta(`DROP EXTENSION "foo"`)).
+				WillReturnResult(pgxmock.NewResult("foo", 1))
+
+			err := DropExtension(pgpool, "foo")
+
+			Expect(err).NotTo(HaveOccurred())
+			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+				Fail(err.Error())
+			}
+		})
+		It("should return an error if the PostgreSQL request failed", func() {
+			pgpoolMock.ExpectExec(regexp.QuoteMeta(`DROP EXTENSION "foo"`)).
+				WillReturnError(fmt.Errorf("fake error from PostgreSQL"))
+
+			err := DropExtension(pgpool, "foo")
+
+			Expect(err).To(HaveOccurred())
+			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+				Fail(err.Error())
+			}
+		})
+	})
+})
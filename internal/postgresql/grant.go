@@ -0,0 +1,259 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Object types on which privileges can be granted
+const (
+	GrantObjectTypeTable              = "TABLE"
+	GrantObjectTypeColumn             = "COLUMN"
+	GrantObjectTypeView               = "VIEW"
+	GrantObjectTypeSequence           = "SEQUENCE"
+	GrantObjectTypeFunction           = "FUNCTION"
+	GrantObjectTypeForeignDataWrapper = "FOREIGN DATA WRAPPER"
+	GrantObjectTypeLargeObject        = "LARGE OBJECT"
+	GrantObjectTypeTablespace         = "TABLESPACE"
+)
+
+// Grant describes the privileges of a grantee on a single object
+type Grant struct {
+	ObjectType string
+	// ObjectName is the object's name, as returned by GetGrantObjectName
+	ObjectName string
+	Columns    []string
+	Grantee    string
+}
+
+// GrantPrivilege is a privilege granted on an object
+type GrantPrivilege struct {
+	Privilege   string `db:"privilege_type"`
+	IsGrantable bool   `db:"is_grantable"`
+	// GrantedOnAll is false if the privilege is granted on some of the columns only
+	GrantedOnAll bool `db:"granted_on_all"`
+}
+
+func ListGrantObjectTypeAvailablePrivileges(objectType string) []string {
+	switch objectType {
+	case GrantObjectTypeTable, GrantObjectTypeView:
+		return ListObjectTypeAvailablePrivileges(ObjectTypeTables)
+	case GrantObjectTypeColumn:
+		return []string{
+			"SELECT",
+			"INSERT",
+			"UPDATE",
+			"REFERENCES",
+		}
+	case GrantObjectTypeSequence:
+		return ListObjectTypeAvailablePrivileges(ObjectTypeSequences)
+	case GrantObjectTypeFunction:
+		return ListObjectTypeAvailablePrivileges(ObjectTypeFunctions)
+	case GrantObjectTypeForeignDataWrapper:
+		return []string{
+			"USAGE",
+		}
+	case GrantObjectTypeLargeObject:
+		return []string{
+			"SELECT",
+			"UPDATE",
+		}
+	case GrantObjectTypeTablespace:
+		return []string{
+			"CREATE",
+		}
+	}
+	return []string{}
+}
+
+// Statements returning the quoted name of an object from its identity, or nothing if the object doesn't exist
+const (
+	GetRelationNameSQLStatement           = "SELECT to_regclass($1)::text"
+	GetFunctionNameSQLStatement           = "SELECT to_regprocedure($1)::text"
+	GetForeignDataWrapperNameSQLStatement = "SELECT quote_ident(fdwname) FROM pg_foreign_data_wrapper WHERE fdwname = $1"
+	GetLargeObjectNameSQLStatement        = "SELECT oid::text FROM pg_largeobject_metadata WHERE oid = $1::oid"
+	GetTablespaceNameSQLStatement         = "SELECT quote_ident(spcname) FROM pg_tablespace WHERE spcname = $1"
+)
+
+// Statements returning the privileges granted to the role $2 on the object identified by $1
+const (
+	GetRelationPrivilegesSQLStatement = `SELECT acl.privilege_type, bool_or(acl.is_grantable) AS is_grantable, true AS granted_on_all
+FROM pg_class c
+CROSS JOIN LATERAL aclexplode(COALESCE(c.relacl, acldefault(CASE WHEN c.relkind = 'S' THEN 's' ELSE 'r' END, c.relowner))) acl
+JOIN pg_roles r ON r.oid = acl.grantee
+WHERE c.oid = to_regclass($1) AND r.rolname = $2
+GROUP BY acl.privilege_type`
+	GetColumnsPrivilegesSQLStatement = `SELECT acl.privilege_type, bool_and(acl.is_grantable) AS is_grantable, count(DISTINCT a.attname) = cardinality($3::text[]) AS granted_on_all
+FROM pg_attribute a
+CROSS JOIN LATERAL aclexplode(COALESCE(a.attacl, '{}'::aclitem[])) acl
+JOIN pg_roles r ON r.oid = acl.grantee
+WHERE a.attrelid = to_regclass($1) AND a.attname = ANY($3::text[]) AND r.rolname = $2
+GROUP BY acl.privilege_type`
+	GetFunctionPrivilegesSQLStatement = `SELECT acl.privilege_type, bool_or(acl.is_grantable) AS is_grantable, true AS granted_on_all
+FROM pg_proc p
+CROSS JOIN LATERAL aclexplode(COALESCE(p.proacl, acldefault('f', p.proowner))) acl
+JOIN pg_roles r ON r.oid = acl.grantee
+WHERE p.oid = to_regprocedure($1) AND r.rolname = $2
+GROUP BY acl.privilege_type`
+	GetForeignDataWrapperPrivilegesSQLStatement = `SELECT acl.privilege_type, bool_or(acl.is_grantable) AS is_grantable, true AS granted_on_all
+FROM pg_foreign_data_wrapper f
+CROSS JOIN LATERAL aclexplode(COALESCE(f.fdwacl, acldefault('F', f.fdwowner))) acl
+JOIN pg_roles r ON r.oid = acl.grantee
+WHERE f.fdwname = $1 AND r.rolname = $2
+GROUP BY acl.privilege_type`
+	GetLargeObjectPrivilegesSQLStatement = `SELECT acl.privilege_type, bool_or(acl.is_grantable) AS is_grantable, true AS granted_on_all
+FROM pg_largeobject_metadata l
+CROSS JOIN LATERAL aclexplode(COALESCE(l.lomacl, acldefault('L', l.lomowner))) acl
+JOIN pg_roles r ON r.oid = acl.grantee
+WHERE l.oid = $1::oid AND r.rolname = $2
+GROUP BY acl.privilege_type`
+	GetTablespacePrivilegesSQLStatement = `SELECT acl.privilege_type, bool_or(acl.is_grantable) AS is_grantable, true AS granted_on_all
+FROM pg_tablespace t
+CROSS JOIN LATERAL aclexplode(COALESCE(t.spcacl, acldefault('t', t.spcowner))) acl
+JOIN pg_roles r ON r.oid = acl.grantee
+WHERE t.spcname = $1 AND r.rolname = $2
+GROUP BY acl.privilege_type`
+)
+
+// ValidateGrantIdentity checks that the identity can be used with the object type
+func ValidateGrantIdentity(objectType, identity string) (err error) {
+	if objectType == GrantObjectTypeLargeObject {
+		if _, err := strconv.ParseUint(identity, 10, 32); err != nil {
+			return fmt.Errorf("large object identity must be an OID: %s", err)
+		}
+	}
+	return nil
+}
+
+// GetGrantObjectName returns the quoted name of the object to use in GRANT and REVOKE statements.
+// It returns an empty name if the object doesn't exist.
+func GetGrantObjectName(pgpool PGPoolInterface, objectType, identity string) (name string, err error) {
+	var statement string
+	switch objectType {
+	case GrantObjectTypeTable, GrantObjectTypeColumn, GrantObjectTypeView, GrantObjectTypeSequence:
+		statement = GetRelationNameSQLStatement
+	case GrantObjectTypeFunction:
+		statement = GetFunctionNameSQLStatement
+	case GrantObjectTypeForeignDataWrapper:
+		statement = GetForeignDataWrapperNameSQLStatement
+	case GrantObjectTypeLargeObject:
+		statement = GetLargeObjectNameSQLStatement
+	case GrantObjectTypeTablespace:
+		statement = GetTablespaceNameSQLStatement
+	default:
+		return "", fmt.Errorf("unsupported object type \"%s\"", objectType)
+	}
+
+	rows, err := pgpool.Query(context.Background(), statement, identity)
+	if err != nil {
+		return "", fmt.Errorf("pg query failed: %s", err)
+	}
+	defer rows.Close()
+
+	names, err := pgx.CollectRows(rows, pgx.RowTo[*string])
+	if err != nil {
+		return "", fmt.Errorf("failed to collect rows: %s", err)
+	}
+
+	if len(names) == 0 || names[0] == nil {
+		return "", nil
+	}
+
+	return *names[0], nil
+}
+
+// GetGrantPrivileges returns the privileges granted to the grantee on the object
+func GetGrantPrivileges(pgpool PGPoolInterface, objectType, identity string, columns []string, grantee string) (privileges []GrantPrivilege, err error) {
+	var rows pgx.Rows
+	switch objectType {
+	case GrantObjectTypeTable, GrantObjectTypeView, GrantObjectTypeSequence:
+		rows, err = pgpool.Query(context.Background(), GetRelationPrivilegesSQLStatement, identity, grantee)
+	case GrantObjectTypeColumn:
+		rows, err = pgpool.Query(context.Background(), GetColumnsPrivilegesSQLStatement, identity, grantee, columns)
+	case GrantObjectTypeFunction:
+		rows, err = pgpool.Query(context.Background(), GetFunctionPrivilegesSQLStatement, identity, grantee)
+	case GrantObjectTypeForeignDataWrapper:
+		rows, err = pgpool.Query(context.Background(), GetForeignDataWrapperPrivilegesSQLStatement, identity, grantee)
+	case GrantObjectTypeLargeObject:
+		rows, err = pgpool.Query(context.Background(), GetLargeObjectPrivilegesSQLStatement, identity, grantee)
+	case GrantObjectTypeTablespace:
+		rows, err = pgpool.Query(context.Background(), GetTablespacePrivilegesSQLStatement, identity, grantee)
+	default:
+		return []GrantPrivilege{}, fmt.Errorf("unsupported object type \"%s\"", objectType)
+	}
+	if err != nil {
+		return []GrantPrivilege{}, fmt.Errorf("pg query failed: %s", err)
+	}
+	defer rows.Close()
+
+	privileges, err = pgx.CollectRows(rows, pgx.RowToStructByName[GrantPrivilege])
+	if err != nil {
+		return []GrantPrivilege{}, fmt.Errorf("failed to collect rows: %s", err)
+	}
+
+	return privileges, err
+}
+
+// target returns the object part of the GRANT and REVOKE statements
+func (g *Grant) target() string {
+	switch g.ObjectType {
+	case GrantObjectTypeColumn, GrantObjectTypeView:
+		return fmt.Sprintf("TABLE %s", g.ObjectName)
+	}
+	return fmt.Sprintf("%s %s", g.ObjectType, g.ObjectName)
+}
+
+// privilege returns the privilege part of the GRANT and REVOKE statements
+func (g *Grant) privilege(privilege string) string {
+	if g.ObjectType != GrantObjectTypeColumn {
+		return privilege
+	}
+
+	sanitizedColumns := []string{}
+	for _, column := range g.Columns {
+		sanitizedColumns = append(sanitizedColumns, pgx.Identifier{column}.Sanitize())
+	}
+	return fmt.Sprintf("%s (%s)", privilege, strings.Join(sanitizedColumns, ", "))
+}
+
+func GrantObjectPrivilege(pgpool PGPoolInterface, grant *Grant, privilege string, withGrantOption bool) (err error) {
+	sanitizedGrantee := pgx.Identifier{grant.Grantee}.Sanitize()
+
+	statement := fmt.Sprintf("GRANT %s ON %s TO %s", grant.privilege(privilege), grant.target(), sanitizedGrantee)
+	if withGrantOption {
+		statement += " WITH GRANT OPTION"
+	}
+
+	_, err = pgpool.Exec(context.Background(), statement)
+	if err != nil {
+		return fmt.Errorf("failed to grant privilege \"%s\" on %s to role %s: %s", privilege, grant.target(), sanitizedGrantee, err)
+	}
+
+	return
+}
+
+func RevokeObjectPrivilege(pgpool PGPoolInterface, grant *Grant, privilege string) (err error) {
+	sanitizedGrantee := pgx.Identifier{grant.Grantee}.Sanitize()
+
+	_, err = pgpool.Exec(context.Background(), fmt.Sprintf("REVOKE %s ON %s FROM %s", grant.privilege(privilege), grant.target(), sanitizedGrantee))
+	if err != nil {
+		return fmt.Errorf("failed to revoke privilege \"%s\" on %s from role %s: %s", privilege, grant.target(), sanitizedGrantee, err)
+	}
+
+	return
+}
+
+func RevokeObjectPrivilegeGrantOption(pgpool PGPoolInterface, grant *Grant, privilege string) (err error) {
+	sanitizedGrantee := pgx.Identifier{grant.Grantee}.Sanitize()
+
+	_, err = pgpool.Exec(context.Background(), fmt.Sprintf("REVOKE GRANT OPTION FOR %s ON %s FROM %s", grant.privilege(privilege), grant.target(), sanitizedGrantee))
+	if err != nil {
+		return fmt.Errorf("failed to revoke grant option for privilege \"%s\" on %s from role %s: %s", privilege, grant.target(), sanitizedGrantee, err)
+	}
+
+	return
+}
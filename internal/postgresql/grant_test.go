@@ -0,0 +1,258 @@
+package postgresql
+
+import (
+	"fmt"
+	"regexp"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	pgxmock "github.com/pashagolub/pgxmock/v4"
+)
+
+var _ = Describe("PostgreSQL Grant", func() {
+	var pgpoolMock pgxmock.PgxPoolIface
+	var pgpool PGPoolInterface
+
+	BeforeEach(func() {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			Fail(err.Error())
+		}
+		pgpoolMock = mock
+		pgpool = mock
+	})
+	AfterEach(func() {
+		pgpoolMock.Close()
+	})
+
+	Context("Calling ValidateGrantIdentity", func() {
+		When("the identity of a large object is not an OID", func() {
+			It("should return an error", func() {
+				Expect(ValidateGrantIdentity(GrantObjectTypeLargeObject, "mylargeobject")).To(HaveOccurred())
+				Expect(ValidateGrantIdentity(GrantObjectTypeLargeObject, "16384")).NotTo(HaveOccurred())
+				Expect(ValidateGrantIdentity(GrantObjectTypeTable, "public.mytable")).NotTo(HaveOccurred())
+			})
+		})
+	})
+
+	Context("Calling GetGrantObjectName", func() {
+		When("the table exists", func() {
+			It("should return its quoted name", func() {
+				pgpoolMock.ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(GetRelationNameSQLStatement))).
+					WithArgs("public.MyTable").
+					WillReturnRows(
+						pgxmock.NewRows([]string{"to_regclass"}).
+							AddRow(&[]string{`"MyTable"`}[0]),
+					)
+
+				name, err := GetGrantObjectName(pgpool, GrantObjectTypeTable, "public.MyTable")
+
+				Expect(err).NotTo(HaveOccurred())
+				if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+
+				Expect(name).To(Equal(`"MyTable"`))
+			})
+		})
+
+		When("the function doesn't exist", func() {
+			It("should return an empty name", func() {
+				pgpoolMock.ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(GetFunctionNameSQLStatement))).
+					WithArgs("public.myfunction(integer)").
+					WillReturnRows(
+						pgxmock.NewRows([]string{"to_regprocedure"}).
+							AddRow(nil),
+					)
+
+				name, err := GetGrantObjectName(pgpool, GrantObjectTypeFunction, "public.myfunction(integer)")
+
+				Expect(err).NotTo(HaveOccurred())
+				if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+
+				Expect(name).To(BeEmpty())
+			})
+		})
+
+		When("the tablespace doesn't exist", func() {
+			It("should return an empty name", func() {
+				pgpoolMock.ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(GetTablespaceNameSQLStatement))).
+					WithArgs("mytablespace").
+					WillReturnRows(
+						pgxmock.NewRows([]string{"quote_ident"}),
+					)
+
+				name, err := GetGrantObjectName(pgpool, GrantObjectTypeTablespace, "mytablespace")
+
+				Expect(err).NotTo(HaveOccurred())
+				if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+
+				Expect(name).To(BeEmpty())
+			})
+		})
+
+		When("PostgreSQL returns an error", func() {
+			It("should return an error", func() {
+				pgpoolMock.ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(GetForeignDataWrapperNameSQLStatement))).
+					WithArgs("myfdw").
+					WillReturnError(fmt.Errorf("fake error from PostgreSQL"))
+
+				_, err := GetGrantObjectName(pgpool, GrantObjectTypeForeignDataWrapper, "myfdw")
+
+				Expect(err).To(HaveOccurred())
+				if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+			})
+		})
+	})
+
+	Context("Calling GetGrantPrivileges", func() {
+		When("privileges are granted on the columns", func() {
+			It("should return the privileges", func() {
+				pgpoolMock.ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(GetColumnsPrivilegesSQLStatement))).
+					WithArgs("public.mytable", "myrole", []string{"id", "name"}).
+					WillReturnRows(
+						pgxmock.NewRows([]string{
+							"privilege_type",
+							"is_grantable",
+							"granted_on_all",
+						}).
+							AddRow("SELECT", true, true).
+							AddRow("UPDATE", false, false),
+					)
+
+				privileges, err := GetGrantPrivileges(pgpool, GrantObjectTypeColumn, "public.mytable", []string{"id", "name"}, "myrole")
+
+				Expect(err).NotTo(HaveOccurred())
+				if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+
+				Expect(privileges).To(Equal([]GrantPrivilege{
+					{Privilege: "SELECT", IsGrantable: true, GrantedOnAll: true},
+					{Privilege: "UPDATE", IsGrantable: false, GrantedOnAll: false},
+				}))
+			})
+		})
+
+		When("PostgreSQL returns an error", func() {
+			It("should return an error and an empty list of privileges", func() {
+				pgpoolMock.ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(GetLargeObjectPrivilegesSQLStatement))).
+					WithArgs("16384", "myrole").
+					WillReturnError(fmt.Errorf("fake error from PostgreSQL"))
+
+				privileges, err := GetGrantPrivileges(pgpool, GrantObjectTypeLargeObject, "16384", nil, "myrole")
+
+				Expect(err).To(HaveOccurred())
+				if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+
+				Expect(privileges).To(BeEmpty())
+			})
+		})
+	})
+
+	Context("Calling GrantObjectPrivilege", func() {
+		When("the privilege is granted with grant option on a view", func() {
+			It("should grant the privilege on the view as a table", func() {
+				pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`GRANT SELECT ON TABLE myschema.myview TO "myrole" WITH GRANT OPTION`))).
+					WillReturnResult(pgxmock.NewResult("GRANT", 0))
+
+				err := GrantObjectPrivilege(pgpool, &Grant{
+					ObjectType: GrantObjectTypeView,
+					ObjectName: "myschema.myview",
+					Grantee:    "myrole",
+				}, "SELECT", true)
+
+				Expect(err).NotTo(HaveOccurred())
+				if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+			})
+		})
+
+		When("the privilege is granted on columns", func() {
+			It("should grant the privilege on the listed columns", func() {
+				pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`GRANT UPDATE ("id", "Name") ON TABLE mytable TO "myrole"`))).
+					WillReturnResult(pgxmock.NewResult("GRANT", 0))
+
+				err := GrantObjectPrivilege(pgpool, &Grant{
+					ObjectType: GrantObjectTypeColumn,
+					ObjectName: "mytable",
+					Columns:    []string{"id", "Name"},
+					Grantee:    "myrole",
+				}, "UPDATE", false)
+
+				Expect(err).NotTo(HaveOccurred())
+				if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+			})
+		})
+
+		When("PostgreSQL returns an error", func() {
+			It("should return an error", func() {
+				pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`GRANT USAGE ON FOREIGN DATA WRAPPER myfdw TO "myrole"`))).
+					WillReturnError(fmt.Errorf("fake error from PostgreSQL"))
+
+				err := GrantObjectPrivilege(pgpool, &Grant{
+					ObjectType: GrantObjectTypeForeignDataWrapper,
+					ObjectName: "myfdw",
+					Grantee:    "myrole",
+				}, "USAGE", false)
+
+				Expect(err).To(HaveOccurred())
+				if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+			})
+		})
+	})
+
+	Context("Calling RevokeObjectPrivilege", func() {
+		When("the object exists", func() {
+			It("should revoke the privilege", func() {
+				pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`REVOKE EXECUTE ON FUNCTION myfunction(integer) FROM "myrole"`))).
+					WillReturnResult(pgxmock.NewResult("REVOKE", 0))
+
+				err := RevokeObjectPrivilege(pgpool, &Grant{
+					ObjectType: GrantObjectTypeFunction,
+					ObjectName: "myfunction(integer)",
+					Grantee:    "myrole",
+				}, "EXECUTE")
+
+				Expect(err).NotTo(HaveOccurred())
+				if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+			})
+		})
+	})
+
+	Context("Calling RevokeObjectPrivilegeGrantOption", func() {
+		When("the object exists", func() {
+			It("should revoke the grant option of the privilege", func() {
+				pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`REVOKE GRANT OPTION FOR SELECT ON LARGE OBJECT 16384 FROM "myrole"`))).
+					WillReturnResult(pgxmock.NewResult("REVOKE", 0))
+
+				err := RevokeObjectPrivilegeGrantOption(pgpool, &Grant{
+					ObjectType: GrantObjectTypeLargeObject,
+					ObjectName: "16384",
+					Grantee:    "myrole",
+				}, "SELECT")
+
+				Expect(err).NotTo(HaveOccurred())
+				if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+			})
+		})
+	})
+})
@@ -0,0 +1,85 @@
+package postgresql
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// MaxIdentifierLength is the maximum length in bytes of an identifier (NAMEDATALEN-1), longer identifiers are truncated by PostgreSQL
+const MaxIdentifierLength = 63
+
+// ReservedPrefix is the prefix of the names PostgreSQL reserves for the system roles and schemas
+const ReservedPrefix = "pg_"
+
+// ReservedRoles are the roles which can't be managed by the operator, as they are keywords or the bootstrap superuser
+var ReservedRoles = []string{
+	"public",
+	"none",
+	"current_role",
+	"current_user",
+	"session_user",
+	"postgres",
+}
+
+// ReservedDatabases are the databases created by initdb, which can't be managed by the operator
+var ReservedDatabases = []string{
+	"template0",
+	"template1",
+	"postgres",
+}
+
+// ReservedSchemas are the system schemas which can't be managed by the operator, in addition to the ones prefixed with "pg_"
+var ReservedSchemas = []string{
+	"information_schema",
+}
+
+// ValidateIdentifier returns an error if the identifier is empty or would be truncated by PostgreSQL
+func ValidateIdentifier(identifier string) error {
+	if identifier == "" {
+		return fmt.Errorf("identifier can't be empty")
+	}
+	if len(identifier) > MaxIdentifierLength {
+		return fmt.Errorf("identifier \"%s\" is longer than %d bytes", identifier, MaxIdentifierLength)
+	}
+	return nil
+}
+
+// ValidateRoleName returns an error if the role's name isn't a valid identifier or is reserved
+func ValidateRoleName(name string) error {
+	if err := ValidateIdentifier(name); err != nil {
+		return err
+	}
+	if strings.HasPrefix(name, ReservedPrefix) {
+		return fmt.Errorf("role name \"%s\" is reserved, the prefix \"%s\" is used by system roles", name, ReservedPrefix)
+	}
+	if slices.Contains(ReservedRoles, name) {
+		return fmt.Errorf("role name \"%s\" is reserved", name)
+	}
+	return nil
+}
+
+// ValidateDatabaseName returns an error if the database's name isn't a valid identifier or is reserved
+func ValidateDatabaseName(name string) error {
+	if err := ValidateIdentifier(name); err != nil {
+		return err
+	}
+	if slices.Contains(ReservedDatabases, name) {
+		return fmt.Errorf("database name \"%s\" is reserved", name)
+	}
+	return nil
+}
+
+// ValidateSchemaName returns an error if the schema's name isn't a valid identifier or is reserved
+func ValidateSchemaName(name string) error {
+	if err := ValidateIdentifier(name); err != nil {
+		return err
+	}
+	if strings.HasPrefix(name, ReservedPrefix) {
+		return fmt.Errorf("schema name \"%s\" is reserved, the prefix \"%s\" is used by system schemas", name, ReservedPrefix)
+	}
+	if slices.Contains(ReservedSchemas, name) {
+		return fmt.Errorf("schema name \"%s\" is reserved", name)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package postgresql
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PostgreSQL Identifier", func() {
+	Context("Calling ValidateIdentifier", func() {
+		It("should accept an identifier of 63 bytes", func() {
+			Expect(ValidateIdentifier(strings.Repeat("a", 63))).To(Succeed())
+		})
+		It("should reject an identifier longer than 63 bytes", func() {
+			Expect(ValidateIdentifier(strings.Repeat("a", 64))).NotTo(Succeed())
+		})
+		It("should count the bytes of multibyte characters", func() {
+			Expect(ValidateIdentifier(strings.Repeat("é", 32))).NotTo(Succeed())
+		})
+		It("should reject an empty identifier", func() {
+			Expect(ValidateIdentifier("")).NotTo(Succeed())
+		})
+	})
+
+	Context("Calling ValidateRoleName", func() {
+		It("should accept a role name", func() {
+			Expect(ValidateRoleName("myrole")).To(Succeed())
+		})
+		It("should reject the names prefixed with pg_", func() {
+			Expect(ValidateRoleName("pg_monitor")).NotTo(Succeed())
+		})
+		It("should reject the reserved role names", func() {
+			Expect(ValidateRoleName("postgres")).NotTo(Succeed())
+			Expect(ValidateRoleName("public")).NotTo(Succeed())
+		})
+	})
+
+	Context("Calling ValidateDatabaseName", func() {
+		It("should accept a database name", func() {
+			Expect(ValidateDatabaseName("mydb")).To(Succeed())
+		})
+		It("should accept the names prefixed with pg_", func() {
+			Expect(ValidateDatabaseName("pg_app")).To(Succeed())
+		})
+		It("should reject the reserved database names", func() {
+			Expect(ValidateDatabaseName("template1")).NotTo(Succeed())
+			Expect(ValidateDatabaseName("postgres")).NotTo(Succeed())
+		})
+	})
+
+	Context("Calling ValidateSchemaName", func() {
+		It("should accept a schema name", func() {
+			Expect(ValidateSchemaName("public")).To(Succeed())
+		})
+		It("should reject the names prefixed with pg_", func() {
+			Expect(ValidateSchemaName("pg_catalog")).NotTo(Succeed())
+		})
+		It("should reject the reserved schema names", func() {
+			Expect(ValidateSchemaName("information_schema")).NotTo(Succeed())
+		})
+	})
+})
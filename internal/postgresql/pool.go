@@ -3,15 +3,28 @@ package postgresql
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// PGPools is the registry of the connection pools opened by the operator.
+// Default and Databases hold the pools of the operator's default server, while
+// Servers holds the pools of each PostgresServer, keyed by the server's name.
 type PGPools struct {
 	Default   PGPoolInterface
 	Databases map[string]PGPoolInterface
+
+	Servers map[string]*PGPools
+
+	// dryRunOf is the registry whose pools are wrapped by the pools of a dry run, recording their statements in plan
+	dryRunOf *PGPools
+	plan     *DryRunPlan
+
+	// mutex guards Databases and Servers, which are shared by the concurrent reconciliations
+	mutex sync.Mutex
 }
 
 type PGPoolInterface interface {
@@ -24,12 +37,32 @@ type PGPoolInterface interface {
 	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
 }
 
+// Database returns the pool of the database, or nil if it isn't open
+func (pgpools *PGPools) Database(database string) PGPoolInterface {
+	pgpools.mutex.Lock()
+	defer pgpools.mutex.Unlock()
+
+	return pgpools.Databases[database]
+}
+
 func EnsurePGPoolExists(pgpools *PGPools, database string) (err error) {
+	pgpools.mutex.Lock()
+	defer pgpools.mutex.Unlock()
+
 	// Check if pool already exists
 	if _, ok := pgpools.Databases[database]; ok {
 		return
 	}
 
+	if pgpools.dryRunOf != nil {
+		err = EnsurePGPoolExists(pgpools.dryRunOf, database)
+		if err != nil {
+			return
+		}
+		pgpools.Databases[database] = &dryRunPGPool{PGPoolInterface: pgpools.dryRunOf.Database(database), plan: pgpools.plan}
+		return
+	}
+
 	// Create new pool
 	config, err := pgxpool.ParseConfig("")
 	if err != nil {
@@ -53,3 +86,101 @@ func EnsurePGPoolExists(pgpools *PGPools, database string) (err error) {
 
 	return
 }
+
+// ClosePGPool closes the pool of the database, if open, so that no session of the operator stays connected to it.
+// The pool is opened again by EnsurePGPoolExists.
+func ClosePGPool(pgpools *PGPools, database string) {
+	pgpools.mutex.Lock()
+	pool, ok := pgpools.Databases[database]
+	delete(pgpools.Databases, database)
+	pgpools.mutex.Unlock()
+
+	// The pool is closed once unregistered, as closing waits for its connections to be released
+	if ok {
+		pool.Close()
+	}
+}
+
+// GetServerPGPools returns the pools of the given server.
+// If no server is provided, the pools of the operator's default server are returned.
+func GetServerPGPools(pgpools *PGPools, server string) (serverPGPools *PGPools, err error) {
+	if server == "" {
+		return pgpools, nil
+	}
+
+	pgpools.mutex.Lock()
+	defer pgpools.mutex.Unlock()
+
+	serverPGPools, ok := pgpools.Servers[server]
+	if !ok {
+		err = fmt.Errorf("no connection available for server \"%s\", the PostgresServer may not be reconciled yet", server)
+		return
+	}
+
+	return
+}
+
+// EnsurePGServerPoolsExist opens the default pool of a server.
+// If the server's pools already exist with a different connection string, they are replaced,
+// then closed once the new pools are registered, so that the next reconciliations use the new ones.
+func EnsurePGServerPoolsExist(pgpools *PGPools, server, connString string) (err error) {
+	pgpools.mutex.Lock()
+	existingPGPools, ok := pgpools.Servers[server]
+	if ok && existingPGPools.Default.Config().ConnString() == connString {
+		pgpools.mutex.Unlock()
+		return
+	}
+
+	pgpool, err := pgxpool.New(context.Background(), connString)
+	if err != nil {
+		pgpools.mutex.Unlock()
+		err = fmt.Errorf("failed to open pool for server \"%s\": %s", server, err)
+		return
+	}
+
+	if pgpools.Servers == nil {
+		pgpools.Servers = map[string]*PGPools{}
+	}
+
+	pgpools.Servers[server] = &PGPools{
+		Default: pgpool,
+		Databases: map[string]PGPoolInterface{
+			pgpool.Config().ConnConfig.Database: pgpool,
+		},
+	}
+	pgpools.mutex.Unlock()
+
+	if ok {
+		closePGPools(existingPGPools)
+	}
+
+	return
+}
+
+// ClosePGServerPools closes all the pools of a server and removes them from the registry
+func ClosePGServerPools(pgpools *PGPools, server string) {
+	pgpools.mutex.Lock()
+	existingPGPools, ok := pgpools.Servers[server]
+	delete(pgpools.Servers, server)
+	pgpools.mutex.Unlock()
+
+	if ok {
+		closePGPools(existingPGPools)
+	}
+}
+
+// closePGPools closes the pools of a server which is no longer registered.
+// Closing a pool waits for its connections to be released by the reconciliations still using it.
+func closePGPools(pgpools *PGPools) {
+	pgpools.mutex.Lock()
+	databasePGPools := pgpools.Databases
+	pgpools.Databases = map[string]PGPoolInterface{}
+	pgpools.mutex.Unlock()
+
+	for _, pgpool := range databasePGPools {
+		if pgpool != pgpools.Default {
+			pgpool.Close()
+		}
+	}
+	pgpools.Default.Close()
+}
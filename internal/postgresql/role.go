@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 )
@@ -17,11 +18,17 @@ type Role struct {
 	Login       bool   `db:"rolcanlogin"`
 	Replication bool   `db:"rolreplication"`
 	BypassRLS   bool   `db:"rolbypassrls"`
+	// ConnectionLimit is the maximum number of concurrent connections of the role, -1 means no limit
+	ConnectionLimit int32 `db:"rolconnlimit"`
+	// ValidUntil is the RFC 3339 date after which the role's password is no longer valid, empty means no expiration
+	ValidUntil string `db:"rolvaliduntil"`
 
 	Password string `db:"-"`
+	// PasswordEncryption are the parameters of the SCRAM-SHA-256 verifier sent instead of the password
+	PasswordEncryption ScramOptions `db:"-"`
 }
 
-const GetRoleSQLStatement = "SELECT rolname, rolsuper, rolinherit, rolcreaterole, rolcreatedb, rolcanlogin, rolreplication, rolbypassrls FROM pg_roles WHERE rolname = $1"
+const GetRoleSQLStatement = `SELECT rolname, rolsuper, rolinherit, rolcreaterole, rolcreatedb, rolcanlogin, rolreplication, rolbypassrls, rolconnlimit, COALESCE(to_char(rolvaliduntil AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"'), '') AS rolvaliduntil FROM pg_roles WHERE rolname = $1`
 
 func GetRole(pgpool PGPoolInterface, name string) (role *Role, err error) {
 	rows, err := pgpool.Query(context.Background(), GetRoleSQLStatement, name)
@@ -53,7 +60,8 @@ func GetRole(pgpool PGPoolInterface, name string) (role *Role, err error) {
 func CreateRole(pgpool PGPoolInterface, operatorRole, role *Role) (err error) {
 	sanitizedName := pgx.Identifier{role.Name}.Sanitize()
 
-	options, err := generateRoleOptionsString(operatorRole, &Role{}, role)
+	// A new role has no connection limit
+	options, err := generateRoleOptionsString(operatorRole, &Role{ConnectionLimit: -1}, role)
 	if err != nil {
 		return err
 	}
@@ -162,8 +170,25 @@ func generateRoleOptionsString(operatorRole, existingRole, desiredRole *Role) (o
 		}
 	}
 
+	if existingRole.ConnectionLimit != desiredRole.ConnectionLimit {
+		rawOptions += fmt.Sprintf("CONNECTION LIMIT %d ", desiredRole.ConnectionLimit)
+	}
+
+	if existingRole.ValidUntil != desiredRole.ValidUntil {
+		validUntil := desiredRole.ValidUntil
+		if validUntil == "" {
+			validUntil = "infinity"
+		}
+		rawOptions += fmt.Sprintf("VALID UNTIL '%s' ", strings.ReplaceAll(validUntil, "'", "''"))
+	}
+
 	if desiredRole.Password != "" {
-		rawOptions += fmt.Sprintf("PASSWORD '%s' ", strings.ReplaceAll(desiredRole.Password, "'", "''"))
+		// The password is never sent in plaintext, so that it can't be found in the server's logs or statistics
+		verifier, err := GenerateScramSHA256Verifier(desiredRole.Password, desiredRole.PasswordEncryption)
+		if err != nil {
+			return "", fmt.Errorf("failed to compute password verifier: %s", err)
+		}
+		rawOptions += fmt.Sprintf("PASSWORD '%s' ", strings.ReplaceAll(verifier, "'", "''"))
 	}
 
 	options = rawOptions
@@ -207,3 +232,20 @@ func ReassignOwnedToRole(pgpool PGPoolInterface, oldRole, newRole string) (err e
 	}
 	return
 }
+
+// SetRoleValidUntil sets the date after which the role's password is no longer valid. A nil date means the password never expires.
+func SetRoleValidUntil(pgpool PGPoolInterface, name string, validUntil *time.Time) (err error) {
+	sanitizedName := pgx.Identifier{name}.Sanitize()
+
+	validUntilString := "infinity"
+	if validUntil != nil {
+		validUntilString = validUntil.UTC().Format(time.RFC3339)
+	}
+
+	_, err = pgpool.Exec(context.Background(), fmt.Sprintf("ALTER ROLE %s VALID UNTIL '%s'", sanitizedName, validUntilString))
+	if err != nil {
+		err = fmt.Errorf("pg exec failed: %s", err)
+		return
+	}
+	return
+}
@@ -0,0 +1,122 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// RoleConfigParameter is a configuration parameter set on a role, in all databases if Database is empty
+type RoleConfigParameter struct {
+	Database string `db:"database"`
+	Name     string `db:"name"`
+	Value    string `db:"value"`
+}
+
+const GetRoleConfigSQLStatement = `SELECT COALESCE(d.datname, '') AS database, split_part(c.setting, '=', 1) AS name, substr(c.setting, strpos(c.setting, '=') + 1) AS value
+FROM pg_db_role_setting s
+JOIN pg_roles r ON r.oid = s.setrole
+LEFT JOIN pg_database d ON d.oid = s.setdatabase
+CROSS JOIN LATERAL unnest(s.setconfig) AS c(setting)
+WHERE r.rolname = $1`
+
+// listConfigParameters are the parameters whose value is a list, and whose elements must be set separately
+var listConfigParameters = []string{
+	"search_path",
+	"temp_tablespaces",
+	"local_preload_libraries",
+	"session_preload_libraries",
+}
+
+// GetRoleConfig returns the configuration parameters set on the role
+func GetRoleConfig(pgpool PGPoolInterface, role string) (parameters []RoleConfigParameter, err error) {
+	rows, err := pgpool.Query(context.Background(), GetRoleConfigSQLStatement, role)
+	if err != nil {
+		return []RoleConfigParameter{}, fmt.Errorf("pg query failed: %s", err)
+	}
+	defer rows.Close()
+
+	parameters, err = pgx.CollectRows(rows, pgx.RowToStructByName[RoleConfigParameter])
+	if err != nil {
+		return []RoleConfigParameter{}, fmt.Errorf("failed to collect rows: %s", err)
+	}
+
+	return parameters, nil
+}
+
+// SetRoleConfigParameter sets the parameter's value for the role's sessions, in all databases if database is empty
+func SetRoleConfigParameter(pgpool PGPoolInterface, role, database, name, value string) (err error) {
+	sanitizedName, err := sanitizeConfigParameterName(name)
+	if err != nil {
+		return err
+	}
+
+	_, err = pgpool.Exec(context.Background(), fmt.Sprintf("ALTER ROLE %s%s SET %s = %s", pgx.Identifier{role}.Sanitize(), inDatabaseClause(database), sanitizedName, quoteConfigParameterValue(name, value)))
+	if err != nil {
+		return fmt.Errorf("pg exec failed: %s", err)
+	}
+	return nil
+}
+
+// ResetRoleConfigParameter removes the parameter's value of the role's sessions, in all databases if database is empty
+func ResetRoleConfigParameter(pgpool PGPoolInterface, role, database, name string) (err error) {
+	sanitizedName, err := sanitizeConfigParameterName(name)
+	if err != nil {
+		return err
+	}
+
+	_, err = pgpool.Exec(context.Background(), fmt.Sprintf("ALTER ROLE %s%s RESET %s", pgx.Identifier{role}.Sanitize(), inDatabaseClause(database), sanitizedName))
+	if err != nil {
+		return fmt.Errorf("pg exec failed: %s", err)
+	}
+	return nil
+}
+
+// ConfigParameterValuesEqual returns whether two values of the parameter are equivalent,
+// as PostgreSQL normalizes the values of list parameters when storing them
+func ConfigParameterValuesEqual(name, a, b string) bool {
+	if !slices.Contains(listConfigParameters, name) {
+		return a == b
+	}
+	return slices.Equal(splitConfigParameterList(a), splitConfigParameterList(b))
+}
+
+// quoteConfigParameterValue returns the value as string literals, one per element for list parameters
+func quoteConfigParameterValue(name, value string) string {
+	values := []string{value}
+	if slices.Contains(listConfigParameters, name) {
+		values = splitConfigParameterList(value)
+	}
+
+	quotedValues := []string{}
+	for _, v := range values {
+		quotedValues = append(quotedValues, fmt.Sprintf("'%s'", strings.ReplaceAll(v, "'", "''")))
+	}
+	return strings.Join(quotedValues, ", ")
+}
+
+func splitConfigParameterList(value string) []string {
+	elements := []string{}
+	for _, element := range strings.Split(value, ",") {
+		elements = append(elements, strings.Trim(strings.TrimSpace(element), "\""))
+	}
+	return elements
+}
+
+func sanitizeConfigParameterName(name string) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("configuration parameter name can't be empty")
+	}
+	// Custom parameters are qualified by their extension's name, e.g. "pg_stat_statements.track"
+	return pgx.Identifier(strings.Split(name, ".")).Sanitize(), nil
+}
+
+func inDatabaseClause(database string) string {
+	if database == "" {
+		return ""
+	}
+	return fmt.Sprintf(" IN DATABASE %s", pgx.Identifier{database}.Sanitize())
+}
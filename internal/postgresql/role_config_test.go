@@ -0,0 +1,145 @@
+package postgresql
+
+import (
+	"fmt"
+	"regexp"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	pgxmock "github.com/pashagolub/pgxmock/v4"
+)
+
+var _ = Describe("PostgreSQL Role Config", func() {
+	var pgpoolMock pgxmock.PgxPoolIface
+	var pgpool PGPoolInterface
+
+	BeforeEach(func() {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			Fail(err.Error())
+		}
+		pgpoolMock = mock
+		pgpool = mock
+	})
+	AfterEach(func() {
+		pgpoolMock.Close()
+	})
+
+	Context("Calling GetRoleConfig", func() {
+		It("should return the parameters set on the role", func() {
+			pgpoolMock.ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(GetRoleConfigSQLStatement))).
+				WithArgs("myrole").
+				WillReturnRows(
+					pgxmock.NewRows([]string{"database", "name", "value"}).
+						AddRow("", "statement_timeout", "30s").
+						AddRow("mydb", "work_mem", "64MB"),
+				)
+
+			parameters, err := GetRoleConfig(pgpool, "myrole")
+
+			Expect(err).NotTo(HaveOccurred())
+			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+				Fail(err.Error())
+			}
+
+			Expect(parameters).To(Equal([]RoleConfigParameter{
+				{Database: "", Name: "statement_timeout", Value: "30s"},
+				{Database: "mydb", Name: "work_mem", Value: "64MB"},
+			}))
+		})
+
+		It("should return an error if the PostgreSQL request failed", func() {
+			pgpoolMock.ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(GetRoleConfigSQLStatement))).
+				WithArgs("myrole").
+				WillReturnError(fmt.Errorf("fake error from PostgreSQL"))
+
+			parameters, err := GetRoleConfig(pgpool, "myrole")
+
+			Expect(err).To(HaveOccurred())
+			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+				Fail(err.Error())
+			}
+
+			Expect(parameters).To(BeEmpty())
+		})
+	})
+
+	Context("Calling SetRoleConfigParameter", func() {
+		When("the parameter is set in all databases", func() {
+			It("should quote the value", func() {
+				pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`ALTER ROLE "myrole" SET "application_name" = 'my''app'`))).
+					WillReturnResult(pgxmock.NewResult("ALTER ROLE", 0))
+
+				err := SetRoleConfigParameter(pgpool, "myrole", "", "application_name", "my'app")
+
+				Expect(err).NotTo(HaveOccurred())
+				if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+			})
+		})
+
+		When("the parameter is a list set in a database", func() {
+			It("should set each element of the list in the database", func() {
+				pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`ALTER ROLE "myrole" IN DATABASE "mydb" SET "search_path" = '$user', 'public'`))).
+					WillReturnResult(pgxmock.NewResult("ALTER ROLE", 0))
+
+				err := SetRoleConfigParameter(pgpool, "myrole", "mydb", "search_path", "$user,public")
+
+				Expect(err).NotTo(HaveOccurred())
+				if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+			})
+		})
+
+		When("the parameter is qualified", func() {
+			It("should sanitize each part of the name", func() {
+				pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`ALTER ROLE "myrole" SET "pg_stat_statements"."track" = 'all'`))).
+					WillReturnResult(pgxmock.NewResult("ALTER ROLE", 0))
+
+				err := SetRoleConfigParameter(pgpool, "myrole", "", "pg_stat_statements.track", "all")
+
+				Expect(err).NotTo(HaveOccurred())
+				if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+			})
+		})
+	})
+
+	Context("Calling ResetRoleConfigParameter", func() {
+		It("should reset the parameter in the database", func() {
+			pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`ALTER ROLE "myrole" IN DATABASE "mydb" RESET "work_mem"`))).
+				WillReturnResult(pgxmock.NewResult("ALTER ROLE", 0))
+
+			err := ResetRoleConfigParameter(pgpool, "myrole", "mydb", "work_mem")
+
+			Expect(err).NotTo(HaveOccurred())
+			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+				Fail(err.Error())
+			}
+		})
+
+		It("should return an error if the PostgreSQL request failed", func() {
+			pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`ALTER ROLE "myrole" RESET "work_mem"`))).
+				WillReturnError(fmt.Errorf("fake error from PostgreSQL"))
+
+			err := ResetRoleConfigParameter(pgpool, "myrole", "", "work_mem")
+
+			Expect(err).To(HaveOccurred())
+			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+				Fail(err.Error())
+			}
+		})
+	})
+
+	Context("Calling ConfigParameterValuesEqual", func() {
+		It("should compare the elements of list parameters", func() {
+			Expect(ConfigParameterValuesEqual("search_path", `"$user", public`, "$user,public")).To(BeTrue())
+			Expect(ConfigParameterValuesEqual("search_path", "public", "myschema")).To(BeFalse())
+			Expect(ConfigParameterValuesEqual("application_name", "a, b", "a,b")).To(BeFalse())
+		})
+	})
+})
@@ -8,33 +8,82 @@ import (
 	"github.com/jackc/pgx/v5"
 )
 
-const GetRoleMembershipStatement = "SELECT roleid::regrole::text AS group_role FROM pg_auth_members WHERE member::regrole::text = $1"
+// MembershipOptionsMinServerVersion is the first server version supporting the INHERIT and SET options of a membership
+const MembershipOptionsMinServerVersion = 160000
 
-func GetRoleMembership(pgpool PGPoolInterface, role string) (membership []string, err error) {
-	rows, err := pgpool.Query(context.Background(), GetRoleMembershipStatement, role)
+// RoleMembership is a group role the role is a member of, with the options of the membership
+type RoleMembership struct {
+	GroupRole string `db:"group_role"`
+	Admin     bool   `db:"admin_option"`
+	Inherit   bool   `db:"inherit_option"`
+	Set       bool   `db:"set_option"`
+}
+
+// Since PostgreSQL 16, a role can be granted the same group role by several grantors, and GRANT and REVOKE only apply to the
+// grants of the current role, so only these ones are listed. The grants of a superuser are recorded as made by the bootstrap superuser.
+const GetRoleMembershipStatement = `SELECT m.roleid::regrole::text AS group_role, m.admin_option, m.inherit_option, m.set_option
+FROM pg_auth_members m
+WHERE m.member::regrole::text = $1
+AND m.grantor = (SELECT CASE WHEN rolsuper THEN 10::oid ELSE oid END FROM pg_roles WHERE rolname = current_user)`
+
+// Before PostgreSQL 16, the group roles' privileges are inherited depending on the member's INHERIT option, and SET ROLE is always allowed
+const GetLegacyRoleMembershipStatement = `SELECT m.roleid::regrole::text AS group_role, m.admin_option, r.rolinherit AS inherit_option, true AS set_option
+FROM pg_auth_members m
+JOIN pg_roles r ON r.oid = m.member
+WHERE m.member::regrole::text = $1`
+
+func GetRoleMembership(pgpool PGPoolInterface, role string, serverVersion int) (membership []RoleMembership, err error) {
+	statement := GetRoleMembershipStatement
+	if serverVersion < MembershipOptionsMinServerVersion {
+		statement = GetLegacyRoleMembershipStatement
+	}
+
+	rows, err := pgpool.Query(context.Background(), statement, role)
 	if err != nil {
 		err = fmt.Errorf("pg query failed: %s", err)
 		return
 	}
 	defer rows.Close()
 
-	membership, err = pgx.CollectRows(rows, pgx.RowTo[string])
+	membership, err = pgx.CollectRows(rows, pgx.RowToStructByName[RoleMembership])
 	if err != nil {
 		err = fmt.Errorf("failed to collect rows: %s", err)
 		return
 	}
 
-	for i, group := range membership {
-		membership[i] = strings.Trim(group, "\"")
+	for i := range membership {
+		membership[i].GroupRole = strings.Trim(membership[i].GroupRole, "\"")
+	}
+
+	return
+}
+
+// GrantRoleMembership grants the group role to the role, or updates the options of the existing membership.
+// Below PostgreSQL 16, only the ADMIN option is granted, see RevokeRoleMembershipAdminOption to remove it.
+func GrantRoleMembership(pgpool PGPoolInterface, membership RoleMembership, role string, serverVersion int) (err error) {
+	sanitizedGroupRole := pgx.Identifier{membership.GroupRole}.Sanitize()
+	sanitizedRole := pgx.Identifier{role}.Sanitize()
+
+	options := ""
+	if serverVersion >= MembershipOptionsMinServerVersion {
+		options = fmt.Sprintf(" WITH ADMIN %s, INHERIT %s, SET %s", sqlBoolean(membership.Admin), sqlBoolean(membership.Inherit), sqlBoolean(membership.Set))
+	} else if membership.Admin {
+		options = " WITH ADMIN OPTION"
 	}
 
+	_, err = pgpool.Exec(context.Background(), fmt.Sprintf("GRANT %s TO %s%s", sanitizedGroupRole, sanitizedRole, options))
+	if err != nil {
+		err = fmt.Errorf("pg exec failed: %s", err)
+		return
+	}
 	return
 }
 
-func GrantRoleMembership(pgpool PGPoolInterface, groupRole, role string) (err error) {
+// RevokeRoleMembershipAdminOption removes the ADMIN option of the membership, the role stays a member of the group role
+func RevokeRoleMembershipAdminOption(pgpool PGPoolInterface, groupRole, role string) (err error) {
 	sanitizedGroupRole := pgx.Identifier{groupRole}.Sanitize()
 	sanitizedRole := pgx.Identifier{role}.Sanitize()
-	_, err = pgpool.Exec(context.Background(), fmt.Sprintf("GRANT %s TO %s", sanitizedGroupRole, sanitizedRole))
+	_, err = pgpool.Exec(context.Background(), fmt.Sprintf("REVOKE ADMIN OPTION FOR %s FROM %s", sanitizedGroupRole, sanitizedRole))
 	if err != nil {
 		err = fmt.Errorf("pg exec failed: %s", err)
 		return
@@ -52,3 +101,10 @@ func RevokeRoleMembership(pgpool PGPoolInterface, groupRole, role string) (err e
 	}
 	return
 }
+
+func sqlBoolean(value bool) string {
+	if value {
+		return "TRUE"
+	}
+	return "FALSE"
+}
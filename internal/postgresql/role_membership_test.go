@@ -34,10 +34,13 @@ var _ = Describe("PostgreSQL Role", func() {
 					WillReturnRows(
 						pgxmock.NewRows([]string{
 							"group_role",
+							"admin_option",
+							"inherit_option",
+							"set_option",
 						}),
 					)
 
-				result, err := GetRoleMembership(pgpool, "foo")
+				result, err := GetRoleMembership(pgpool, "foo", 160000)
 
 				Expect(err).NotTo(HaveOccurred())
 				if err := pgpoolMock.ExpectationsWereMet(); err != nil {
@@ -55,34 +58,113 @@ var _ = Describe("PostgreSQL Role", func() {
 					WillReturnRows(
 						pgxmock.NewRows([]string{
 							"group_role",
+							"admin_option",
+							"inherit_option",
+							"set_option",
 						}).
 							AddRow(
 								"\"alpha\"",
+								true,
+								false,
+								true,
 							).
 							AddRow(
 								"\"beta\"",
+								false,
+								true,
+								false,
 							),
 					)
 
-				result, err := GetRoleMembership(pgpool, "foo")
+				result, err := GetRoleMembership(pgpool, "foo", 160000)
 
 				Expect(err).NotTo(HaveOccurred())
 				if err := pgpoolMock.ExpectationsWereMet(); err != nil {
 					Fail(err.Error())
 				}
 
-				Expect(result).To(Equal([]string{"alpha", "beta"}))
+				Expect(result).To(Equal([]RoleMembership{
+					{GroupRole: "alpha", Admin: true, Inherit: false, Set: true},
+					{GroupRole: "beta", Admin: false, Inherit: true, Set: false},
+				}))
+			})
+		})
+
+		When("the server is older than PostgreSQL 16", func() {
+			It("should query the membership without the INHERIT and SET options", func() {
+				pgpoolMock.ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(GetLegacyRoleMembershipStatement))).
+					WithArgs("foo").
+					WillReturnRows(
+						pgxmock.NewRows([]string{
+							"group_role",
+							"admin_option",
+							"inherit_option",
+							"set_option",
+						}).
+							AddRow(
+								"alpha",
+								true,
+								true,
+								true,
+							),
+					)
+
+				result, err := GetRoleMembership(pgpool, "foo", 150004)
+
+				Expect(err).NotTo(HaveOccurred())
+				if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+
+				Expect(result).To(Equal([]RoleMembership{
+					{GroupRole: "alpha", Admin: true, Inherit: true, Set: true},
+				}))
 			})
 		})
 	})
 
 	Context("Calling GrantRoleMembership", func() {
-		It("should successfully grant the role to the group role", func() {
+		It("should successfully grant the role to the group role with the membership's options", func() {
+
+			pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`GRANT "foo" TO "bar" WITH ADMIN FALSE, INHERIT TRUE, SET FALSE`))).
+				WillReturnResult(pgxmock.NewResult("", 1))
+
+			err := GrantRoleMembership(pgpool, RoleMembership{GroupRole: "foo", Inherit: true}, "bar", 160000)
+
+			Expect(err).NotTo(HaveOccurred())
+			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+				Fail(err.Error())
+			}
+		})
+
+		When("the server is older than PostgreSQL 16", func() {
+			It("should only grant the ADMIN option", func() {
+
+				pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`GRANT "foo" TO "bar"`))).
+					WillReturnResult(pgxmock.NewResult("", 1))
+				pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`GRANT "foo" TO "bar" WITH ADMIN OPTION`))).
+					WillReturnResult(pgxmock.NewResult("", 1))
+
+				err := GrantRoleMembership(pgpool, RoleMembership{GroupRole: "foo", Inherit: true, Set: true}, "bar", 150004)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = GrantRoleMembership(pgpool, RoleMembership{GroupRole: "foo", Admin: true}, "bar", 150004)
+				Expect(err).NotTo(HaveOccurred())
+
+				if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+			})
+		})
+	})
+
+	Context("Calling RevokeRoleMembershipAdminOption", func() {
+		It("should successfully revoke the ADMIN option of the membership", func() {
 
-			pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`GRANT "foo" TO "bar"`))).
+			pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`REVOKE ADMIN OPTION FOR "foo" FROM "bar"`))).
 				WillReturnResult(pgxmock.NewResult("", 1))
 
-			err := GrantRoleMembership(pgpool, "foo", "bar")
+			err := RevokeRoleMembershipAdminOption(pgpool, "foo", "bar")
 
 			Expect(err).NotTo(HaveOccurred())
 			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
@@ -3,6 +3,7 @@ package postgresql
 import (
 	"fmt"
 	"regexp"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -40,6 +41,8 @@ var _ = Describe("PostgreSQL Role", func() {
 						"rolcanlogin",
 						"rolreplication",
 						"rolbypassrls",
+						"rolconnlimit",
+						"rolvaliduntil",
 					}).
 						AddRow(
 							"foo",
@@ -50,6 +53,8 @@ var _ = Describe("PostgreSQL Role", func() {
 							false,
 							false,
 							false,
+							int32(-1),
+							"",
 						),
 				)
 
@@ -84,6 +89,8 @@ var _ = Describe("PostgreSQL Role", func() {
 						"rolcanlogin",
 						"rolreplication",
 						"rolbypassrls",
+						"rolconnlimit",
+						"rolvaliduntil",
 					}),
 				)
 
@@ -123,6 +130,8 @@ var _ = Describe("PostgreSQL Role", func() {
 						"rolcanlogin",
 						"rolreplication",
 						"rolbypassrls",
+						"rolconnlimit",
+						"rolvaliduntil",
 					}).
 						AddRow(
 							"foo",
@@ -133,6 +142,8 @@ var _ = Describe("PostgreSQL Role", func() {
 							false,
 							false,
 							false,
+							int32(-1),
+							"",
 						).
 						AddRow(
 							"foo2",
@@ -143,6 +154,8 @@ var _ = Describe("PostgreSQL Role", func() {
 							false,
 							false,
 							false,
+							int32(-1),
+							"",
 						),
 				)
 
@@ -168,6 +181,8 @@ var _ = Describe("PostgreSQL Role", func() {
 						"rolcanlogin",
 						"rolreplication",
 						"rolbypassrls",
+						"rolconnlimit",
+						"rolvaliduntil",
 						"fake",
 					}).
 						AddRow(
@@ -179,6 +194,8 @@ var _ = Describe("PostgreSQL Role", func() {
 							false,
 							false,
 							false,
+							int32(-1),
+							"",
 							"fake",
 						).
 						AddRow(
@@ -190,6 +207,8 @@ var _ = Describe("PostgreSQL Role", func() {
 							false,
 							false,
 							false,
+							int32(-1),
+							"",
 							"fake",
 						),
 				)
@@ -206,7 +225,11 @@ var _ = Describe("PostgreSQL Role", func() {
 
 	Context("Calling CreateRole", func() {
 		It("should create a role with the defined options and return no error", func() {
-			pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`CREATE ROLE "foo" WITH SUPERUSER CREATEROLE BYPASSRLS PASSWORD 'password' ADMIN "operator"`))).
+			pgpoolMock.ExpectExec(fmt.Sprintf("^%s %s %s$",
+				regexp.QuoteMeta(`CREATE ROLE "foo" WITH SUPERUSER CREATEROLE BYPASSRLS PASSWORD`),
+				`'SCRAM-SHA-256\$4096:[a-zA-Z0-9+/]{22}==\$[a-zA-Z0-9+/]{43}=:[a-zA-Z0-9+/]{43}='`,
+				regexp.QuoteMeta(`ADMIN "operator"`),
+			)).
 				WillReturnResult(pgxmock.NewResult("foo", 1))
 
 			role := Role{
@@ -216,6 +239,8 @@ var _ = Describe("PostgreSQL Role", func() {
 				CreateRole: true,
 				BypassRLS:  true,
 				Password:   "password",
+
+				ConnectionLimit: -1,
 			}
 
 			operatorRole := Role{
@@ -237,6 +262,32 @@ var _ = Describe("PostgreSQL Role", func() {
 			}
 		})
 
+		It("should create a role with a connection limit and an expiration date", func() {
+			pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`CREATE ROLE "foo" WITH LOGIN CONNECTION LIMIT 10 VALID UNTIL '2026-01-01T00:00:00Z' ADMIN "operator"`))).
+				WillReturnResult(pgxmock.NewResult("foo", 1))
+
+			role := Role{
+				Name:  "foo",
+				Login: true,
+
+				ConnectionLimit: 10,
+				ValidUntil:      "2026-01-01T00:00:00Z",
+			}
+
+			operatorRole := Role{
+				Name:      "operator",
+				SuperUser: true,
+				Login:     true,
+			}
+
+			err := CreateRole(pgpool, &operatorRole, &role)
+
+			Expect(err).NotTo(HaveOccurred())
+			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+				Fail(err.Error())
+			}
+		})
+
 		It("should return an error if the PostgreSQL request failed", func() {
 			pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`CREATE ROLE "foo" WITH INHERIT CREATEDB LOGIN REPLICATION ADMIN "operator"`))).
 				WillReturnError(fmt.Errorf("fake error from PostgreSQL"))
@@ -247,6 +298,8 @@ var _ = Describe("PostgreSQL Role", func() {
 				CreateDB:    true,
 				Login:       true,
 				Replication: true,
+
+				ConnectionLimit: -1,
 			}
 
 			operatorRole := Role{
@@ -448,4 +501,30 @@ var _ = Describe("PostgreSQL Role", func() {
 		})
 	})
 
+	Context("Calling SetRoleValidUntil", func() {
+		It("should set the expiration date of the role's password", func() {
+			pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`ALTER ROLE "foo" VALID UNTIL '2025-06-01T12:00:00Z'`))).
+				WillReturnResult(pgxmock.NewResult("ALTER ROLE", 0))
+
+			validUntil := time.Date(2025, time.June, 1, 14, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+			err := SetRoleValidUntil(pgpool, "foo", &validUntil)
+
+			Expect(err).NotTo(HaveOccurred())
+			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+				Fail(err.Error())
+			}
+		})
+		It("should remove the expiration date if no date is given", func() {
+			pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`ALTER ROLE "foo" VALID UNTIL 'infinity'`))).
+				WillReturnResult(pgxmock.NewResult("ALTER ROLE", 0))
+
+			err := SetRoleValidUntil(pgpool, "foo", nil)
+
+			Expect(err).NotTo(HaveOccurred())
+			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+				Fail(err.Error())
+			}
+		})
+	})
+
 })
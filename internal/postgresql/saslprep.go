@@ -0,0 +1,71 @@
+package postgresql
+
+import (
+	"slices"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// saslPrep normalizes the password with SASLprep (RFC 4013) as PostgreSQL does before computing its SCRAM-SHA-256 verifier.
+// Like PostgreSQL, it returns false if the password can't be prepared, in which case the password is used as is.
+func saslPrep(password string) (string, bool) {
+	// An ASCII password is used as is, including its control characters
+	if isASCII(password) {
+		return password, true
+	}
+
+	if !utf8.ValidString(password) {
+		return password, false
+	}
+
+	// 1) Map the non-ASCII spaces to a space, and remove the characters commonly mapped to nothing
+	mapped := make([]rune, 0, len(password))
+	for _, r := range password {
+		switch {
+		case unicode.Is(saslprepNonASCIISpace, r):
+			mapped = append(mapped, ' ')
+		case unicode.Is(saslprepMappedToNothing, r):
+		default:
+			mapped = append(mapped, r)
+		}
+	}
+
+	// PostgreSQL doesn't allow a password which is empty once mapped
+	if len(mapped) == 0 {
+		return password, false
+	}
+
+	// 2) Normalize with the form KC
+	prepared := []rune(norm.NFKC.String(string(mapped)))
+
+	// 3) Prohibit the output characters and the unassigned code points
+	for _, r := range prepared {
+		if unicode.Is(saslprepProhibited, r) || unicode.Is(saslprepUnassigned, r) {
+			return password, false
+		}
+	}
+
+	// 4) Check the bidirectional strings: a string with a right-to-left character can't contain a left-to-right one,
+	// and must start and end with a right-to-left character
+	if slices.ContainsFunc(prepared, func(r rune) bool { return unicode.Is(saslprepRandALCat, r) }) {
+		if slices.ContainsFunc(prepared, func(r rune) bool { return unicode.Is(saslprepLCat, r) }) {
+			return password, false
+		}
+		if !unicode.Is(saslprepRandALCat, prepared[0]) || !unicode.Is(saslprepRandALCat, prepared[len(prepared)-1]) {
+			return password, false
+		}
+	}
+
+	return string(prepared), true
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,34 @@
+package postgresql
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PostgreSQL SASLprep", func() {
+	Context("Calling saslPrep", func() {
+		DescribeTable("should prepare the password as PostgreSQL does",
+			func(password, expectedPassword string, expectedPrepared bool) {
+				preparedPassword, prepared := saslPrep(password)
+
+				Expect(prepared).To(Equal(expectedPrepared))
+				Expect(preparedPassword).To(Equal(expectedPassword))
+			},
+			// Examples of RFC 4013
+			Entry("soft hyphen mapped to nothing", "I\u00adX", "IX", true),
+			Entry("no transformation", "user", "user", true),
+			Entry("case preserved", "USER", "USER", true),
+			Entry("output is NFKC, ordinal indicator", "\u00aa", "a", true),
+			Entry("output is NFKC, roman numeral", "\u2168", "IX", true),
+			Entry("bidi check", "\u06271", "\u06271", false),
+			// PostgreSQL uses an ASCII password as is, even with prohibited characters
+			Entry("ASCII control character", "\u0007", "\u0007", true),
+			Entry("non-ASCII space mapped to a space", "my\u00a0password", "my password", true),
+			Entry("right-to-left string", "\u05d0\u05d1", "\u05d0\u05d1", true),
+			Entry("prohibited character", "my\u2028password", "my\u2028password", false),
+			Entry("unassigned code point", "my\u0221password", "my\u0221password", false),
+			Entry("empty once mapped", "\u00ad", "\u00ad", false),
+			Entry("invalid UTF-8", "my\xffpassword", "my\xffpassword", false),
+		)
+	})
+})
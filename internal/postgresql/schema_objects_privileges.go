@@ -0,0 +1,118 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// SchemaObjectsPrivilege describes whether a privilege is granted to a role on the objects of a schema
+type SchemaObjectsPrivilege struct {
+	Privilege    string `db:"privilege"`
+	GrantedOnAll bool   `db:"granted_on_all"`
+	GrantedOnAny bool   `db:"granted_on_any"`
+}
+
+// getSchemaObjectsPrivilegesSQLStatementFormat checks, for each privilege, whether the role has been explicitly granted
+// the privilege on all or any of the objects listed by the subquery.
+// An object without ACL has the default privileges of its owner. A schema without objects has all privileges granted on all of them.
+const getSchemaObjectsPrivilegesSQLStatementFormat = `WITH objects AS (%s)
+SELECT privilege, COALESCE(bool_and(granted), true) AS granted_on_all, COALESCE(bool_or(granted), false) AS granted_on_any
+FROM unnest($3::text[]) AS privilege
+LEFT JOIN LATERAL (
+  SELECT EXISTS (
+    SELECT 1 FROM aclexplode(objects.acl) acl JOIN pg_roles r ON r.oid = acl.grantee
+    WHERE r.rolname = $2 AND acl.privilege_type = privilege
+  ) AS granted
+  FROM objects
+) AS grants ON true
+GROUP BY privilege`
+
+var (
+	GetSchemaTablesPrivilegesSQLStatement = fmt.Sprintf(
+		getSchemaObjectsPrivilegesSQLStatementFormat,
+		"SELECT COALESCE(c.relacl, acldefault('r', c.relowner)) AS acl FROM pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace WHERE n.nspname = $1 AND c.relkind IN ('r', 'p', 'v', 'm', 'f')",
+	)
+	GetSchemaSequencesPrivilegesSQLStatement = fmt.Sprintf(
+		getSchemaObjectsPrivilegesSQLStatementFormat,
+		"SELECT COALESCE(c.relacl, acldefault('s', c.relowner)) AS acl FROM pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace WHERE n.nspname = $1 AND c.relkind = 'S'",
+	)
+	GetSchemaFunctionsPrivilegesSQLStatement = fmt.Sprintf(
+		getSchemaObjectsPrivilegesSQLStatementFormat,
+		"SELECT COALESCE(p.proacl, acldefault('f', p.proowner)) AS acl FROM pg_proc p JOIN pg_namespace n ON n.oid = p.pronamespace WHERE n.nspname = $1 AND p.prokind <> 'p'",
+	)
+)
+
+// ListSchemaObjectTypes returns the object types which can be granted with GRANT ... ON ALL ... IN SCHEMA
+func ListSchemaObjectTypes() []string {
+	return []string{
+		ObjectTypeTables,
+		ObjectTypeSequences,
+		ObjectTypeFunctions,
+	}
+}
+
+// GetSchemaObjectsRolePrivileges returns the privileges granted to the role on all the objects of the given type in the schema,
+// and the privileges granted on at least one of them
+func GetSchemaObjectsRolePrivileges(pgpool PGPoolInterface, schema, role, objectType string) (grantedOnAll, grantedOnAny []string, err error) {
+	var statement string
+	switch objectType {
+	case ObjectTypeTables:
+		statement = GetSchemaTablesPrivilegesSQLStatement
+	case ObjectTypeSequences:
+		statement = GetSchemaSequencesPrivilegesSQLStatement
+	case ObjectTypeFunctions:
+		statement = GetSchemaFunctionsPrivilegesSQLStatement
+	default:
+		return []string{}, []string{}, fmt.Errorf("unsupported object type \"%s\"", objectType)
+	}
+
+	rows, err := pgpool.Query(context.Background(), statement, schema, role, ListObjectTypeAvailablePrivileges(objectType))
+	if err != nil {
+		return []string{}, []string{}, fmt.Errorf("pg query failed: %s", err)
+	}
+	defer rows.Close()
+
+	privileges, err := pgx.CollectRows(rows, pgx.RowToStructByName[SchemaObjectsPrivilege])
+	if err != nil {
+		return []string{}, []string{}, fmt.Errorf("failed to collect rows: %s", err)
+	}
+
+	grantedOnAll = []string{}
+	grantedOnAny = []string{}
+	for _, privilege := range privileges {
+		if privilege.GrantedOnAll {
+			grantedOnAll = append(grantedOnAll, privilege.Privilege)
+		}
+		if privilege.GrantedOnAny {
+			grantedOnAny = append(grantedOnAny, privilege.Privilege)
+		}
+	}
+
+	return grantedOnAll, grantedOnAny, err
+}
+
+func GrantSchemaObjectsRolePrivilege(pgpool PGPoolInterface, schema, role, objectType, privilege string) (err error) {
+	sanitizedSchema := pgx.Identifier{schema}.Sanitize()
+	sanitizedRole := pgx.Identifier{role}.Sanitize()
+
+	_, err = pgpool.Exec(context.Background(), fmt.Sprintf("GRANT %s ON ALL %s IN SCHEMA %s TO %s", privilege, objectType, sanitizedSchema, sanitizedRole))
+	if err != nil {
+		return fmt.Errorf("failed to grant privilege \"%s\" on all %s in schema %s to role %s: %s", privilege, objectType, sanitizedSchema, sanitizedRole, err)
+	}
+
+	return
+}
+
+func RevokeSchemaObjectsRolePrivilege(pgpool PGPoolInterface, schema, role, objectType, privilege string) (err error) {
+	sanitizedSchema := pgx.Identifier{schema}.Sanitize()
+	sanitizedRole := pgx.Identifier{role}.Sanitize()
+
+	_, err = pgpool.Exec(context.Background(), fmt.Sprintf("REVOKE %s ON ALL %s IN SCHEMA %s FROM %s", privilege, objectType, sanitizedSchema, sanitizedRole))
+	if err != nil {
+		return fmt.Errorf("failed to revoke privilege \"%s\" on all %s in schema %s from role %s: %s", privilege, objectType, sanitizedSchema, sanitizedRole, err)
+	}
+
+	return
+}
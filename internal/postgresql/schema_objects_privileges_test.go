@@ -0,0 +1,146 @@
+package postgresql
+
+import (
+	"fmt"
+	"regexp"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	pgxmock "github.com/pashagolub/pgxmock/v4"
+)
+
+var _ = Describe("PostgreSQL Schema Objects Privileges", func() {
+	var pgpoolMock pgxmock.PgxPoolIface
+	var pgpool PGPoolInterface
+
+	BeforeEach(func() {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			Fail(err.Error())
+		}
+		pgpoolMock = mock
+		pgpool = mock
+	})
+	AfterEach(func() {
+		pgpoolMock.Close()
+	})
+
+	Context("Calling GetSchemaObjectsRolePrivileges", func() {
+		When("privileges are granted on some tables", func() {
+			It("should return the privileges granted on all tables and on any table", func() {
+				pgpoolMock.ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(GetSchemaTablesPrivilegesSQLStatement))).
+					WithArgs("myschema", "myrole", ListObjectTypeAvailablePrivileges(ObjectTypeTables)).
+					WillReturnRows(
+						pgxmock.NewRows([]string{
+							"privilege",
+							"granted_on_all",
+							"granted_on_any",
+						}).
+							AddRow("SELECT", true, true).
+							AddRow("INSERT", false, true).
+							AddRow("UPDATE", false, false),
+					)
+
+				grantedOnAll, grantedOnAny, err := GetSchemaObjectsRolePrivileges(pgpool, "myschema", "myrole", ObjectTypeTables)
+
+				Expect(err).NotTo(HaveOccurred())
+				if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+
+				Expect(grantedOnAll).To(Equal([]string{"SELECT"}))
+				Expect(grantedOnAny).To(Equal([]string{"SELECT", "INSERT"}))
+			})
+		})
+
+		When("the object type is not supported", func() {
+			It("should return an error without querying PostgreSQL", func() {
+				_, _, err := GetSchemaObjectsRolePrivileges(pgpool, "myschema", "myrole", ObjectTypeTypes)
+
+				Expect(err).To(HaveOccurred())
+				if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+			})
+		})
+
+		When("PostgreSQL returns an error", func() {
+			It("should return an error and empty lists of privileges", func() {
+				pgpoolMock.ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(GetSchemaSequencesPrivilegesSQLStatement))).
+					WithArgs("myschema", "myrole", ListObjectTypeAvailablePrivileges(ObjectTypeSequences)).
+					WillReturnError(fmt.Errorf("fake error from PostgreSQL"))
+
+				grantedOnAll, grantedOnAny, err := GetSchemaObjectsRolePrivileges(pgpool, "myschema", "myrole", ObjectTypeSequences)
+
+				Expect(err).To(HaveOccurred())
+				if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+
+				Expect(grantedOnAll).To(BeEmpty())
+				Expect(grantedOnAny).To(BeEmpty())
+			})
+		})
+	})
+
+	Context("Calling GrantSchemaObjectsRolePrivilege", func() {
+		When("the schema and role exist", func() {
+			It("should grant privilege on all objects to the role and return no error", func() {
+				pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`GRANT SELECT ON ALL TABLES IN SCHEMA "myschema" TO "myrole"`))).
+					WillReturnResult(pgxmock.NewResult("GRANT", 0))
+
+				err := GrantSchemaObjectsRolePrivilege(pgpool, "myschema", "myrole", ObjectTypeTables, "SELECT")
+
+				Expect(err).NotTo(HaveOccurred())
+				if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+			})
+		})
+
+		When("PostgreSQL returns an error", func() {
+			It("should return an error", func() {
+				pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`GRANT SELECT ON ALL TABLES IN SCHEMA "myschema" TO "myrole"`))).
+					WillReturnError(fmt.Errorf("fake error from PostgreSQL"))
+
+				err := GrantSchemaObjectsRolePrivilege(pgpool, "myschema", "myrole", ObjectTypeTables, "SELECT")
+
+				Expect(err).To(HaveOccurred())
+				if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+			})
+		})
+	})
+
+	Context("Calling RevokeSchemaObjectsRolePrivilege", func() {
+		When("the schema and role exist", func() {
+			It("should revoke privilege on all objects from the role and return no error", func() {
+				pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`REVOKE EXECUTE ON ALL FUNCTIONS IN SCHEMA "myschema" FROM "myrole"`))).
+					WillReturnResult(pgxmock.NewResult("REVOKE", 0))
+
+				err := RevokeSchemaObjectsRolePrivilege(pgpool, "myschema", "myrole", ObjectTypeFunctions, "EXECUTE")
+
+				Expect(err).NotTo(HaveOccurred())
+				if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+			})
+		})
+
+		When("PostgreSQL returns an error", func() {
+			It("should return an error", func() {
+				pgpoolMock.ExpectExec(fmt.Sprintf("^%s$", regexp.QuoteMeta(`REVOKE EXECUTE ON ALL FUNCTIONS IN SCHEMA "myschema" FROM "myrole"`))).
+					WillReturnError(fmt.Errorf("fake error from PostgreSQL"))
+
+				err := RevokeSchemaObjectsRolePrivilege(pgpool, "myschema", "myrole", ObjectTypeFunctions, "EXECUTE")
+
+				Expect(err).To(HaveOccurred())
+				if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+			})
+		})
+	})
+})
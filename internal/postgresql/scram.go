@@ -0,0 +1,150 @@
+package postgresql
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Default parameters of the SCRAM-SHA-256 verifiers, identical to the ones used by PostgreSQL
+const (
+	DefaultScramIterations = 4096
+	DefaultScramSaltLength = 16
+)
+
+const scramSHA256Prefix = "SCRAM-SHA-256"
+
+// ScramOptions are the parameters used to compute a SCRAM-SHA-256 verifier.
+// Zero values fall back to the PostgreSQL defaults.
+type ScramOptions struct {
+	Iterations int
+	SaltLength int
+}
+
+const GetRolePasswordSQLStatement = "SELECT rolpassword FROM pg_authid WHERE rolname = $1"
+
+// GenerateScramSHA256Verifier computes the SCRAM-SHA-256 verifier of the password with a random salt,
+// so that the password can be set without being sent in plaintext to the server.
+func GenerateScramSHA256Verifier(password string, options ScramOptions) (verifier string, err error) {
+	iterations := options.Iterations
+	if iterations == 0 {
+		iterations = DefaultScramIterations
+	}
+
+	saltLength := options.SaltLength
+	if saltLength == 0 {
+		saltLength = DefaultScramSaltLength
+	}
+
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %s", err)
+	}
+
+	return buildScramSHA256Verifier(password, salt, iterations)
+}
+
+// VerifyScramSHA256 returns whether the verifier has been computed from the password
+func VerifyScramSHA256(password, verifier string) bool {
+	// SCRAM-SHA-256$<iterations>:<salt>$<StoredKey>:<ServerKey>
+	parts := strings.Split(verifier, "$")
+	if len(parts) != 3 || parts[0] != scramSHA256Prefix {
+		return false
+	}
+
+	iterationsAndSalt := strings.SplitN(parts[1], ":", 2)
+	if len(iterationsAndSalt) != 2 {
+		return false
+	}
+
+	iterations, err := strconv.Atoi(iterationsAndSalt[0])
+	if err != nil || iterations <= 0 {
+		return false
+	}
+
+	salt, err := base64.StdEncoding.DecodeString(iterationsAndSalt[1])
+	if err != nil {
+		return false
+	}
+
+	expectedVerifier, err := buildScramSHA256Verifier(password, salt, iterations)
+	if err != nil {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(expectedVerifier), []byte(verifier)) == 1
+}
+
+// VerifyRolePassword returns whether the password matches the SCRAM-SHA-256 verifier stored for the role, without changing it.
+// Reading the verifier from pg_authid requires the SUPERUSER option: if the operator isn't allowed to read it,
+// the password is reported as not matching, so that it is set again.
+func VerifyRolePassword(pgpool PGPoolInterface, name, password string) (matches bool, err error) {
+	rows, err := pgpool.Query(context.Background(), GetRolePasswordSQLStatement, name)
+	if err != nil {
+		if isInsufficientPrivilege(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("pg query failed: %s", err)
+	}
+	defer rows.Close()
+
+	verifiers, err := pgx.CollectRows(rows, pgx.RowTo[*string])
+	if err != nil {
+		if isInsufficientPrivilege(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to collect rows: %s", err)
+	}
+
+	if len(verifiers) == 0 || verifiers[0] == nil {
+		return false, nil
+	}
+
+	return VerifyScramSHA256(password, *verifiers[0]), nil
+}
+
+// buildScramSHA256Verifier computes the verifier as PostgreSQL does, see RFC 5802 and RFC 7677
+func buildScramSHA256Verifier(password string, salt []byte, iterations int) (verifier string, err error) {
+	// PostgreSQL normalizes the password with SASLprep, unless the password isn't valid for it
+	preparedPassword, _ := saslPrep(password)
+
+	saltedPassword, err := pbkdf2.Key(sha256.New, preparedPassword, salt, iterations, sha256.Size)
+	if err != nil {
+		return "", fmt.Errorf("failed to salt password: %s", err)
+	}
+
+	clientKey := computeHMAC(saltedPassword, "Client Key")
+	storedKey := sha256.Sum256(clientKey)
+	serverKey := computeHMAC(saltedPassword, "Server Key")
+
+	return fmt.Sprintf("%s$%d:%s$%s:%s",
+		scramSHA256Prefix,
+		iterations,
+		base64.StdEncoding.EncodeToString(salt),
+		base64.StdEncoding.EncodeToString(storedKey[:]),
+		base64.StdEncoding.EncodeToString(serverKey),
+	), nil
+}
+
+func computeHMAC(key []byte, message string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(message))
+	return mac.Sum(nil)
+}
+
+// isInsufficientPrivilege returns whether the statement failed because the operator lacks a privilege
+func isInsufficientPrivilege(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "42501"
+}
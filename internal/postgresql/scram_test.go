@@ -0,0 +1,162 @@
+package postgresql
+
+import (
+	"fmt"
+	"regexp"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	pgxmock "github.com/pashagolub/pgxmock/v4"
+)
+
+var _ = Describe("PostgreSQL SCRAM-SHA-256", func() {
+	var pgpoolMock pgxmock.PgxPoolIface
+	var pgpool PGPoolInterface
+
+	// Verifier of "mypassword" with 4096 iterations and the salt 0x000102030405060708090a0b0c0d0e0f
+	const myPasswordVerifier = "SCRAM-SHA-256$4096:AAECAwQFBgcICQoLDA0ODw==$4mUelWJ9HNXsHOTWQv11IwFfDXDFJb3mVFmfbouFJPo=:Em39rPXp2G2Kog1uzS7wmoUbVuaFCcyot9qu7Pu5iAA="
+
+	// Verifier of "IX" with 4096 iterations and the salt 0x000102030405060708090a0b0c0d0e0f
+	const ixVerifier = "SCRAM-SHA-256$4096:AAECAwQFBgcICQoLDA0ODw==$Hvybl93RfCHqfqLiTzsBHz9FA2JH0lY8NzX3ES+JAB0=:36RFvraaEsq6EdU8f0zs6/hpb0vgxhjNZecZXSUZKgs="
+
+	BeforeEach(func() {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			Fail(err.Error())
+		}
+		pgpoolMock = mock
+		pgpool = mock
+	})
+	AfterEach(func() {
+		pgpoolMock.Close()
+	})
+
+	Context("Calling buildScramSHA256Verifier", func() {
+		It("should compute the verifier as PostgreSQL does", func() {
+			salt := []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+
+			verifier, err := buildScramSHA256Verifier("mypassword", salt, 4096)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(verifier).To(Equal(myPasswordVerifier))
+		})
+
+		It("should prepare a non-ASCII password with SASLprep", func() {
+			salt := []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+
+			// PostgreSQL prepares "I<SOFT HYPHEN>X" into "IX", as checked by its test src/test/authentication/t/002_saslprep.pl
+			verifier, err := buildScramSHA256Verifier("I\u00adX", salt, 4096)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(verifier).To(Equal(ixVerifier))
+		})
+	})
+
+	Context("Calling GenerateScramSHA256Verifier", func() {
+		When("no option is defined", func() {
+			It("should use the PostgreSQL defaults", func() {
+				verifier, err := GenerateScramSHA256Verifier("mypassword", ScramOptions{})
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(verifier).To(MatchRegexp(`^SCRAM-SHA-256\$4096:[a-zA-Z0-9+/]{22}==\$[a-zA-Z0-9+/]{43}=:[a-zA-Z0-9+/]{43}=$`))
+				Expect(VerifyScramSHA256("mypassword", verifier)).To(BeTrue())
+			})
+		})
+
+		When("the iterations and the salt's length are defined", func() {
+			It("should use them", func() {
+				verifier, err := GenerateScramSHA256Verifier("mypassword", ScramOptions{Iterations: 10000, SaltLength: 32})
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(verifier).To(MatchRegexp(`^SCRAM-SHA-256\$10000:[a-zA-Z0-9+/]{43}=\$`))
+				Expect(VerifyScramSHA256("mypassword", verifier)).To(BeTrue())
+			})
+		})
+	})
+
+	Context("Calling VerifyScramSHA256", func() {
+		It("should only match the verifier's password", func() {
+			Expect(VerifyScramSHA256("mypassword", myPasswordVerifier)).To(BeTrue())
+			Expect(VerifyScramSHA256("otherpassword", myPasswordVerifier)).To(BeFalse())
+			Expect(VerifyScramSHA256("mypassword", "md5a3556571e93b0d20722ba62be61e8c2d")).To(BeFalse())
+			Expect(VerifyScramSHA256("mypassword", "SCRAM-SHA-256$abc:AAECAwQFBgcICQoLDA0ODw==$a:b")).To(BeFalse())
+		})
+	})
+
+	Context("Calling VerifyRolePassword", func() {
+		When("the password matches the stored verifier", func() {
+			It("should return true", func() {
+				pgpoolMock.ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(GetRolePasswordSQLStatement))).
+					WithArgs("myrole").
+					WillReturnRows(
+						pgxmock.NewRows([]string{"rolpassword"}).
+							AddRow(&[]string{myPasswordVerifier}[0]),
+					)
+
+				matches, err := VerifyRolePassword(pgpool, "myrole", "mypassword")
+
+				Expect(err).NotTo(HaveOccurred())
+				if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+
+				Expect(matches).To(BeTrue())
+			})
+		})
+
+		When("the role has no password", func() {
+			It("should return false", func() {
+				pgpoolMock.ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(GetRolePasswordSQLStatement))).
+					WithArgs("myrole").
+					WillReturnRows(
+						pgxmock.NewRows([]string{"rolpassword"}).
+							AddRow(nil),
+					)
+
+				matches, err := VerifyRolePassword(pgpool, "myrole", "mypassword")
+
+				Expect(err).NotTo(HaveOccurred())
+				if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+
+				Expect(matches).To(BeFalse())
+			})
+		})
+
+		When("the operator isn't allowed to read the stored verifier", func() {
+			It("should return false", func() {
+				pgpoolMock.ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(GetRolePasswordSQLStatement))).
+					WithArgs("myrole").
+					WillReturnError(&pgconn.PgError{Code: "42501", Message: "permission denied for table pg_authid"})
+
+				matches, err := VerifyRolePassword(pgpool, "myrole", "mypassword")
+
+				Expect(err).NotTo(HaveOccurred())
+				if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+
+				Expect(matches).To(BeFalse())
+			})
+		})
+
+		When("PostgreSQL returns an error", func() {
+			It("should return an error", func() {
+				pgpoolMock.ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(GetRolePasswordSQLStatement))).
+					WithArgs("myrole").
+					WillReturnError(fmt.Errorf("connection refused"))
+
+				_, err := VerifyRolePassword(pgpool, "myrole", "mypassword")
+
+				Expect(err).To(HaveOccurred())
+				if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+					Fail(err.Error())
+				}
+			})
+		})
+	})
+})
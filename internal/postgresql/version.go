@@ -0,0 +1,26 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const GetServerVersionNumSQLStatement = "SELECT current_setting('server_version_num')::int"
+
+// GetServerVersionNum returns the version of the server as a number, e.g. 160002 for 16.2
+func GetServerVersionNum(pgpool PGPoolInterface) (version int, err error) {
+	rows, err := pgpool.Query(context.Background(), GetServerVersionNumSQLStatement)
+	if err != nil {
+		return 0, fmt.Errorf("pg query failed: %s", err)
+	}
+	defer rows.Close()
+
+	version, err = pgx.CollectExactlyOneRow(rows, pgx.RowTo[int])
+	if err != nil {
+		return 0, fmt.Errorf("failed to collect rows: %s", err)
+	}
+
+	return version, nil
+}
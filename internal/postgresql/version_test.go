@@ -0,0 +1,54 @@
+package postgresql
+
+import (
+	"fmt"
+	"regexp"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	pgxmock "github.com/pashagolub/pgxmock/v4"
+)
+
+var _ = Describe("PostgreSQL Version", func() {
+	var pgpoolMock pgxmock.PgxPoolIface
+	var pgpool PGPoolInterface
+
+	BeforeEach(func() {
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			Fail(err.Error())
+		}
+		pgpoolMock = mock
+		pgpool = mock
+	})
+	AfterEach(func() {
+		pgpoolMock.Close()
+	})
+
+	Context("Calling GetServerVersionNum", func() {
+		It("should return the server's version number", func() {
+			pgpoolMock.ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(GetServerVersionNumSQLStatement))).
+				WillReturnRows(pgxmock.NewRows([]string{"current_setting"}).AddRow(160002))
+
+			version, err := GetServerVersionNum(pgpool)
+
+			Expect(err).NotTo(HaveOccurred())
+			if err := pgpoolMock.ExpectationsWereMet(); err != nil {
+				Fail(err.Error())
+			}
+			Expect(version).To(Equal(160002))
+		})
+
+		When("the query fails", func() {
+			It("should return an error", func() {
+				pgpoolMock.ExpectQuery(fmt.Sprintf("^%s$", regexp.QuoteMeta(GetServerVersionNumSQLStatement))).
+					WillReturnError(fmt.Errorf("connection refused"))
+
+				_, err := GetServerVersionNum(pgpool)
+
+				Expect(err).To(MatchError("pg query failed: connection refused"))
+			})
+		})
+	})
+})
@@ -0,0 +1,123 @@
+package utils
+
+import (
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// PasswordHashAnnotationName is the annotation in which the previous versions of the operator stored the hash
+// of the last password applied to a role. The hash is now stored in the resource's status.
+const PasswordHashAnnotationName string = "managed-postgres-operator.hoppscale.com/password-hash"
+
+const (
+	passwordHashAlgorithm = "pbkdf2-sha256"
+	// passwordHashIterations follows the OWASP recommendation for PBKDF2-HMAC-SHA256,
+	// as the hash can be read by anyone allowed to read the resource
+	passwordHashIterations = 600000
+	passwordHashSaltLength = 16
+	passwordHashKeyLength  = 32
+	// passwordHashMaxIterations bounds the cost of verifying a hash read from the resource
+	passwordHashMaxIterations = 1 << 20
+)
+
+// HashPassword returns a salted hash of the role's password, formatted as "pbkdf2-sha256$<iterations>$<salt>$<key>".
+// The role's name is part of the hash, so that the same password applied to another role doesn't match.
+func HashPassword(role, password string) (string, error) {
+	salt := make([]byte, passwordHashSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %s", err)
+	}
+
+	key, err := derivePasswordKey(role, password, salt, passwordHashIterations)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s$%d$%s$%s",
+		passwordHashAlgorithm,
+		passwordHashIterations,
+		base64.StdEncoding.EncodeToString(salt),
+		base64.StdEncoding.EncodeToString(key),
+	), nil
+}
+
+// VerifyPasswordHash returns whether the hash has been computed from the role's password.
+// A malformed hash never matches.
+func VerifyPasswordHash(role, password, hash string) bool {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 4 || parts[0] != passwordHashAlgorithm {
+		return false
+	}
+
+	iterations, err := strconv.Atoi(parts[1])
+	if err != nil || iterations <= 0 || iterations > passwordHashMaxIterations {
+		return false
+	}
+
+	salt, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false
+	}
+
+	expectedKey, err := base64.StdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return false
+	}
+
+	key, err := derivePasswordKey(role, password, salt, iterations)
+	if err != nil {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare(key, expectedKey) == 1
+}
+
+// PasswordHashCache remembers the last hash verified against the password of each role, as verifying a hash is deliberately slow.
+// Only a digest of the password is kept, and the zero value is ready to use.
+type PasswordHashCache struct {
+	verified sync.Map
+}
+
+type verifiedPasswordHash struct {
+	hash     string
+	password [sha256.Size]byte
+}
+
+// Verify returns whether the hash has been computed from the role's password, like VerifyPasswordHash,
+// without deriving the key again if the hash has already been verified against the same password.
+func (c *PasswordHashCache) Verify(role, password, hash string) bool {
+	digest := sha256.Sum256([]byte(password))
+	if value, ok := c.verified.Load(role); ok {
+		verified := value.(verifiedPasswordHash)
+		if verified.hash == hash && subtle.ConstantTimeCompare(verified.password[:], digest[:]) == 1 {
+			return true
+		}
+	}
+
+	if !VerifyPasswordHash(role, password, hash) {
+		return false
+	}
+	c.verified.Store(role, verifiedPasswordHash{hash: hash, password: digest})
+	return true
+}
+
+// Store records a hash just computed from the role's password, so that it isn't verified on the next reconciliation
+func (c *PasswordHashCache) Store(role, password, hash string) {
+	c.verified.Store(role, verifiedPasswordHash{hash: hash, password: sha256.Sum256([]byte(password))})
+}
+
+func derivePasswordKey(role, password string, salt []byte, iterations int) ([]byte, error) {
+	key, err := pbkdf2.Key(sha256.New, password, slices.Concat(salt, []byte(role)), iterations, passwordHashKeyLength)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive password key: %s", err)
+	}
+	return key, nil
+}
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Password functions", func() {
+	Context("Calling HashPassword", func() {
+		It("should return a salted hash", func() {
+			hash, err := HashPassword("myrole", "mypassword")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hash).To(MatchRegexp(`^pbkdf2-sha256\$600000\$[a-zA-Z0-9+/=]+\$[a-zA-Z0-9+/=]+$`))
+
+			otherHash, err := HashPassword("myrole", "mypassword")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(otherHash).NotTo(Equal(hash))
+		})
+	})
+
+	Context("Calling VerifyPasswordHash", func() {
+		When("the hash has been computed from the role's password", func() {
+			It("should return true", func() {
+				hash, err := HashPassword("myrole", "mypassword")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(VerifyPasswordHash("myrole", "mypassword", hash)).To(BeTrue())
+			})
+		})
+
+		When("the password or the role is different", func() {
+			It("should return false", func() {
+				hash, err := HashPassword("myrole", "mypassword")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(VerifyPasswordHash("myrole", "otherpassword", hash)).To(BeFalse())
+				Expect(VerifyPasswordHash("otherrole", "mypassword", hash)).To(BeFalse())
+			})
+		})
+
+		When("the hash is malformed", func() {
+			It("should return false", func() {
+				Expect(VerifyPasswordHash("myrole", "mypassword", "")).To(BeFalse())
+				Expect(VerifyPasswordHash("myrole", "mypassword", "md5$4096$c2FsdA==$a2V5")).To(BeFalse())
+				Expect(VerifyPasswordHash("myrole", "mypassword", "pbkdf2-sha256$abc$c2FsdA==$a2V5")).To(BeFalse())
+				Expect(VerifyPasswordHash("myrole", "mypassword", "pbkdf2-sha256$1000000000$c2FsdA==$a2V5")).To(BeFalse())
+			})
+		})
+	})
+
+	Context("Calling PasswordHashCache.Verify", func() {
+		It("should only match the password the hash has been computed from", func() {
+			hash, err := HashPassword("myrole", "mypassword")
+			Expect(err).NotTo(HaveOccurred())
+
+			cache := &PasswordHashCache{}
+			Expect(cache.Verify("myrole", "mypassword", hash)).To(BeTrue())
+
+			By("Verifying the same hash again, the cached result should be used")
+			Expect(cache.Verify("myrole", "mypassword", hash)).To(BeTrue())
+			Expect(cache.Verify("myrole", "otherpassword", hash)).To(BeFalse())
+			Expect(cache.Verify("otherrole", "mypassword", hash)).To(BeFalse())
+			Expect(cache.Verify("myrole", "mypassword", "")).To(BeFalse())
+		})
+
+		It("should match a stored hash without verifying it", func() {
+			cache := &PasswordHashCache{}
+			cache.Store("myrole", "mypassword", "pbkdf2-sha256$600000$c2FsdA==$a2V5")
+
+			Expect(cache.Verify("myrole", "mypassword", "pbkdf2-sha256$600000$c2FsdA==$a2V5")).To(BeTrue())
+			Expect(cache.Verify("myrole", "otherpassword", "pbkdf2-sha256$600000$c2FsdA==$a2V5")).To(BeFalse())
+		})
+	})
+})
@@ -19,6 +19,15 @@ func IsManagedByOperatorInstance(annotations map[string]string, instanceName str
 	return false
 }
 
+// DryRunAnnotationName is the annotation putting a resource in dry-run mode, when set to "true"
+const DryRunAnnotationName string = "managed-postgres-operator.hoppscale.com/dry-run"
+
+// IsDryRun returns whether the changes of the resource are only planned,
+// because either the operator or the resource is in dry-run mode
+func IsDryRun(annotations map[string]string, operatorDryRun bool) bool {
+	return operatorDryRun || annotations[DryRunAnnotationName] == "true"
+}
+
 func GetLeaderElectionID(instanceName string) string {
 	leaderName := "default"
 
@@ -95,4 +95,28 @@ var _ = Describe("Utils functions", func() {
 			})
 		})
 	})
+
+	Context("Calling IsDryRun", func() {
+		When("neither the operator nor the resource is in dry-run mode", func() {
+
+			It("should return false", func() {
+				Expect(IsDryRun(map[string]string{}, false)).To(BeFalse())
+				Expect(IsDryRun(map[string]string{DryRunAnnotationName: "false"}, false)).To(BeFalse())
+			})
+		})
+
+		When("the resource has the dry-run annotation", func() {
+
+			It("should return true", func() {
+				Expect(IsDryRun(map[string]string{DryRunAnnotationName: "true"}, false)).To(BeTrue())
+			})
+		})
+
+		When("the operator is in dry-run mode", func() {
+
+			It("should return true", func() {
+				Expect(IsDryRun(map[string]string{}, true)).To(BeTrue())
+			})
+		})
+	})
 })
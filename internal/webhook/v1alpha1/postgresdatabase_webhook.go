@@ -0,0 +1,71 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	managedpostgresoperatorhoppscalecomv1alpha1 "github.com/hoppscale/managed-postgres-operator/api/v1alpha1"
+	"github.com/hoppscale/managed-postgres-operator/internal/postgresql"
+)
+
+// SetupPostgresDatabaseWebhookWithManager registers the webhook for PostgresDatabase in the manager.
+func SetupPostgresDatabaseWebhookWithManager(mgr ctrl.Manager, operator OperatorIdentity) error {
+	return ctrl.NewWebhookManagedBy(mgr, &managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase{}).
+		WithValidator(&PostgresDatabaseCustomValidator{Operator: operator}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-managed-postgres-operator-hoppscale-com-v1alpha1-postgresdatabase,mutating=false,failurePolicy=fail,sideEffects=None,groups=managed-postgres-operator.hoppscale.com,resources=postgresdatabases,verbs=create,versions=v1alpha1,name=vpostgresdatabase-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// PostgresDatabaseCustomValidator validates the PostgresDatabase resources when they are created.
+type PostgresDatabaseCustomValidator struct {
+	Operator OperatorIdentity
+}
+
+// ValidateCreate validates the database's name
+func (v *PostgresDatabaseCustomValidator) ValidateCreate(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase) (admission.Warnings, error) {
+	namePath := field.NewPath("spec", "name")
+	allErrs := field.ErrorList{}
+
+	if err := postgresql.ValidateDatabaseName(resource.Spec.Name); err != nil {
+		allErrs = append(allErrs, field.Invalid(namePath, resource.Spec.Name, err.Error()))
+	} else if _, database, ok := v.Operator.connection(ctx, resource.ObjectMeta.Annotations, resource.Spec.ServerRef); ok && resource.Spec.Name == database {
+		allErrs = append(allErrs, field.Forbidden(namePath, fmt.Sprintf("database \"%s\" is used by the operator to connect to the server", resource.Spec.Name)))
+	}
+
+	if len(allErrs) == 0 {
+		return nil, nil
+	}
+	return nil, apierrors.NewInvalid(groupKind("PostgresDatabase"), resource.Name, allErrs)
+}
+
+// ValidateUpdate doesn't validate anything, as the database's name is immutable
+func (v *PostgresDatabaseCustomValidator) ValidateUpdate(_ context.Context, _, _ *managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateDelete doesn't validate anything, as the deletion is always allowed
+func (v *PostgresDatabaseCustomValidator) ValidateDelete(_ context.Context, _ *managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase) (admission.Warnings, error) {
+	return nil, nil
+}
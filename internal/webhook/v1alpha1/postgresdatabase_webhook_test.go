@@ -0,0 +1,86 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	managedpostgresoperatorhoppscalecomv1alpha1 "github.com/hoppscale/managed-postgres-operator/api/v1alpha1"
+	"github.com/hoppscale/managed-postgres-operator/internal/utils"
+)
+
+var _ = Describe("PostgresDatabase Webhook", func() {
+	var validator *PostgresDatabaseCustomValidator
+	var resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase
+
+	BeforeEach(func() {
+		validator = &PostgresDatabaseCustomValidator{
+			Operator: OperatorIdentity{InstanceName: "foo", RoleName: "operator", DatabaseName: "operator"},
+		}
+		resource = &managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "mydb",
+				Namespace:   "default",
+				Annotations: map[string]string{utils.OperatorInstanceAnnotationName: "foo"},
+			},
+			Spec: managedpostgresoperatorhoppscalecomv1alpha1.PostgresDatabaseSpec{
+				Name: "mydb",
+			},
+		}
+	})
+
+	When("creating a database", func() {
+		It("should accept a valid database", func() {
+			_, err := validator.ValidateCreate(context.Background(), resource)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject a reserved database name", func() {
+			resource.Spec.Name = "template1"
+			_, err := validator.ValidateCreate(context.Background(), resource)
+			Expect(err).To(MatchError(ContainSubstring("is reserved")))
+		})
+
+		It("should reject the operator's database", func() {
+			resource.Spec.Name = "operator"
+			_, err := validator.ValidateCreate(context.Background(), resource)
+			Expect(err).To(MatchError(ContainSubstring("used by the operator")))
+		})
+
+		It("should reject the database used by the operator to connect to another server", func() {
+			validator.Operator.ServerConnection = func(_ context.Context, server string) (string, string, error) {
+				Expect(server).To(Equal("other"))
+				return "admin", "maintenance", nil
+			}
+			resource.Spec.Name = "maintenance"
+			resource.Spec.ServerRef = "other"
+			_, err := validator.ValidateCreate(context.Background(), resource)
+			Expect(err).To(MatchError(ContainSubstring("used by the operator")))
+		})
+
+		It("should accept the operator's database name if managed by another instance", func() {
+			resource.Spec.Name = "operator"
+			resource.ObjectMeta.Annotations[utils.OperatorInstanceAnnotationName] = "bar"
+			_, err := validator.ValidateCreate(context.Background(), resource)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
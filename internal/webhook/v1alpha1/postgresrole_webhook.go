@@ -0,0 +1,127 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	managedpostgresoperatorhoppscalecomv1alpha1 "github.com/hoppscale/managed-postgres-operator/api/v1alpha1"
+	"github.com/hoppscale/managed-postgres-operator/internal/postgresql"
+)
+
+// dualRoleLoginRoleSuffixLength is the length of the suffix of the login roles created with the DualRole password rotation mode
+const dualRoleLoginRoleSuffixLength = len("_a")
+
+// SetupPostgresRoleWebhookWithManager registers the webhook for PostgresRole in the manager.
+func SetupPostgresRoleWebhookWithManager(mgr ctrl.Manager, operator OperatorIdentity) error {
+	return ctrl.NewWebhookManagedBy(mgr, &managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole{}).
+		WithValidator(&PostgresRoleCustomValidator{Operator: operator}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-managed-postgres-operator-hoppscale-com-v1alpha1-postgresrole,mutating=false,failurePolicy=fail,sideEffects=None,groups=managed-postgres-operator.hoppscale.com,resources=postgresroles,verbs=create;update,versions=v1alpha1,name=vpostgresrole-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// PostgresRoleCustomValidator validates the PostgresRole resources when they are created or updated.
+type PostgresRoleCustomValidator struct {
+	Operator OperatorIdentity
+}
+
+// ValidateCreate validates the role's name and its Secrets
+func (v *PostgresRoleCustomValidator) ValidateCreate(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole) (admission.Warnings, error) {
+	allErrs := v.validateName(ctx, resource)
+	allErrs = append(allErrs, validatePostgresRoleSecrets(resource)...)
+
+	return postgresRoleWarnings(resource), v.invalid(resource, allErrs)
+}
+
+// ValidateUpdate validates the length of the role's name with the password rotation mode, and the role's Secrets, the name being immutable
+func (v *PostgresRoleCustomValidator) ValidateUpdate(_ context.Context, _, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole) (admission.Warnings, error) {
+	// The finalizer of a resource being deleted must be removable, even if its spec is invalid
+	if !resource.ObjectMeta.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+
+	allErrs := validateLoginRolesName(resource)
+	allErrs = append(allErrs, validatePostgresRoleSecrets(resource)...)
+
+	return postgresRoleWarnings(resource), v.invalid(resource, allErrs)
+}
+
+// ValidateDelete doesn't validate anything, as the deletion is always allowed
+func (v *PostgresRoleCustomValidator) ValidateDelete(_ context.Context, _ *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *PostgresRoleCustomValidator) validateName(ctx context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole) field.ErrorList {
+	namePath := field.NewPath("spec", "name")
+
+	if err := postgresql.ValidateRoleName(resource.Spec.Name); err != nil {
+		return field.ErrorList{field.Invalid(namePath, resource.Spec.Name, err.Error())}
+	}
+
+	if allErrs := validateLoginRolesName(resource); len(allErrs) > 0 {
+		return allErrs
+	}
+
+	if role, _, ok := v.Operator.connection(ctx, resource.ObjectMeta.Annotations, resource.Spec.ServerRef); ok && resource.Spec.Name == role {
+		return field.ErrorList{field.Forbidden(namePath, fmt.Sprintf("role \"%s\" is used by the operator to connect to the server", resource.Spec.Name))}
+	}
+
+	return nil
+}
+
+// validateLoginRolesName rejects a role's name too long for the login roles of the DualRole password rotation mode,
+// which are named after the role. The rotation mode can be switched after the role's creation.
+func validateLoginRolesName(resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole) field.ErrorList {
+	if resource.Spec.PasswordRotation != nil && resource.Spec.PasswordRotation.Mode == managedpostgresoperatorhoppscalecomv1alpha1.PasswordRotationModeDualRole &&
+		len(resource.Spec.Name)+dualRoleLoginRoleSuffixLength > postgresql.MaxIdentifierLength {
+		return field.ErrorList{field.Invalid(field.NewPath("spec", "name"), resource.Spec.Name, fmt.Sprintf("must be at most %d bytes with the DualRole password rotation mode", postgresql.MaxIdentifierLength-dualRoleLoginRoleSuffixLength))}
+	}
+	return nil
+}
+
+func (v *PostgresRoleCustomValidator) invalid(resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole, allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(groupKind("PostgresRole"), resource.Name, allErrs)
+}
+
+// validatePostgresRoleSecrets rejects a generated Secret overwriting the Secret the password is read from
+func validatePostgresRoleSecrets(resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole) field.ErrorList {
+	if resource.Spec.PasswordFromSecret != nil && resource.Spec.SecretName != "" && resource.Spec.PasswordFromSecret.Name == resource.Spec.SecretName {
+		return field.ErrorList{field.Invalid(
+			field.NewPath("spec", "secretName"),
+			resource.Spec.SecretName,
+			"must be different from passwordFromSecret.name, as the generated Secret would overwrite the Secret the password is read from",
+		)}
+	}
+	return nil
+}
+
+func postgresRoleWarnings(resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole) admission.Warnings {
+	if len(resource.Spec.SecretTemplate) > 0 && resource.Spec.SecretName == "" {
+		return admission.Warnings{"spec.secretTemplate is ignored without spec.secretName"}
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	managedpostgresoperatorhoppscalecomv1alpha1 "github.com/hoppscale/managed-postgres-operator/api/v1alpha1"
+)
+
+var _ = Describe("PostgresRole Webhook", func() {
+	var validator *PostgresRoleCustomValidator
+	var resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole
+
+	BeforeEach(func() {
+		validator = &PostgresRoleCustomValidator{
+			Operator: OperatorIdentity{RoleName: "operator", DatabaseName: "operator"},
+		}
+		resource = &managedpostgresoperatorhoppscalecomv1alpha1.PostgresRole{
+			ObjectMeta: metav1.ObjectMeta{Name: "myrole", Namespace: "default"},
+			Spec: managedpostgresoperatorhoppscalecomv1alpha1.PostgresRoleSpec{
+				Name: "myrole",
+			},
+		}
+	})
+
+	When("creating a role", func() {
+		It("should accept a valid role", func() {
+			warnings, err := validator.ValidateCreate(context.Background(), resource)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("should reject a reserved role name", func() {
+			resource.Spec.Name = "pg_monitor"
+			_, err := validator.ValidateCreate(context.Background(), resource)
+			Expect(err).To(MatchError(ContainSubstring("spec.name")))
+		})
+
+		It("should reject a name longer than 63 bytes", func() {
+			resource.Spec.Name = strings.Repeat("a", 64)
+			_, err := validator.ValidateCreate(context.Background(), resource)
+			Expect(err).To(MatchError(ContainSubstring("longer than 63 bytes")))
+		})
+
+		It("should reject a name whose login roles would be longer than 63 bytes with the DualRole mode", func() {
+			resource.Spec.Name = strings.Repeat("a", 62)
+			resource.Spec.SecretName = "myrole-credentials"
+			resource.Spec.PasswordRotation = &managedpostgresoperatorhoppscalecomv1alpha1.PostgresRolePasswordRotationSpec{
+				Schedule: "0 3 * * *",
+				Mode:     managedpostgresoperatorhoppscalecomv1alpha1.PasswordRotationModeDualRole,
+			}
+			_, err := validator.ValidateCreate(context.Background(), resource)
+			Expect(err).To(MatchError(ContainSubstring("at most 61 bytes")))
+		})
+
+		It("should reject the operator's role on the default server", func() {
+			resource.Spec.Name = "operator"
+			_, err := validator.ValidateCreate(context.Background(), resource)
+			Expect(err).To(MatchError(ContainSubstring("used by the operator")))
+		})
+
+		It("should accept the operator's role name on another server", func() {
+			resource.Spec.Name = "operator"
+			resource.Spec.ServerRef = "other"
+			_, err := validator.ValidateCreate(context.Background(), resource)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject the role used by the operator to connect to another server", func() {
+			validator.Operator.ServerConnection = func(_ context.Context, server string) (string, string, error) {
+				Expect(server).To(Equal("other"))
+				return "admin", "postgres", nil
+			}
+			resource.Spec.Name = "admin"
+			resource.Spec.ServerRef = "other"
+			_, err := validator.ValidateCreate(context.Background(), resource)
+			Expect(err).To(MatchError(ContainSubstring("used by the operator")))
+		})
+
+		It("should accept the role if the connection to the other server can't be retrieved", func() {
+			validator.Operator.ServerConnection = func(_ context.Context, server string) (string, string, error) {
+				return "", "", fmt.Errorf("PostgresServer \"%s\" not found", server)
+			}
+			resource.Spec.Name = "admin"
+			resource.Spec.ServerRef = "other"
+			_, err := validator.ValidateCreate(context.Background(), resource)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject a generated Secret overwriting the Secret of the password", func() {
+			resource.Spec.SecretName = "myrole-credentials"
+			resource.Spec.PasswordFromSecret = &managedpostgresoperatorhoppscalecomv1alpha1.PostgresRolePasswordFromSecret{
+				Name: "myrole-credentials",
+				Key:  "password",
+			}
+			_, err := validator.ValidateCreate(context.Background(), resource)
+			Expect(err).To(MatchError(ContainSubstring("spec.secretName")))
+		})
+
+		It("should warn that the Secret template is ignored without a Secret", func() {
+			resource.Spec.SecretTemplate = map[string]string{"DSN": "{{ .Role }}"}
+			warnings, err := validator.ValidateCreate(context.Background(), resource)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(HaveLen(1))
+		})
+	})
+
+	When("updating a role", func() {
+		It("should reject a generated Secret overwriting the Secret of the password", func() {
+			updatedResource := resource.DeepCopy()
+			updatedResource.Spec.SecretName = "myrole-credentials"
+			updatedResource.Spec.PasswordFromSecret = &managedpostgresoperatorhoppscalecomv1alpha1.PostgresRolePasswordFromSecret{
+				Name: "myrole-credentials",
+				Key:  "password",
+			}
+			_, err := validator.ValidateUpdate(context.Background(), resource, updatedResource)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject the DualRole mode on a name whose login roles would be longer than 63 bytes", func() {
+			resource.Spec.Name = strings.Repeat("a", 62)
+			updatedResource := resource.DeepCopy()
+			updatedResource.Spec.SecretName = "myrole-credentials"
+			updatedResource.Spec.PasswordRotation = &managedpostgresoperatorhoppscalecomv1alpha1.PostgresRolePasswordRotationSpec{
+				Schedule: "0 3 * * *",
+				Mode:     managedpostgresoperatorhoppscalecomv1alpha1.PasswordRotationModeDualRole,
+			}
+			_, err := validator.ValidateUpdate(context.Background(), resource, updatedResource)
+			Expect(err).To(MatchError(ContainSubstring("at most 61 bytes")))
+		})
+
+		It("should accept any change of a role being deleted", func() {
+			updatedResource := resource.DeepCopy()
+			now := metav1.Now()
+			updatedResource.ObjectMeta.DeletionTimestamp = &now
+			updatedResource.Spec.SecretName = "myrole-credentials"
+			updatedResource.Spec.PasswordFromSecret = &managedpostgresoperatorhoppscalecomv1alpha1.PostgresRolePasswordFromSecret{
+				Name: "myrole-credentials",
+				Key:  "password",
+			}
+			_, err := validator.ValidateUpdate(context.Background(), resource, updatedResource)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
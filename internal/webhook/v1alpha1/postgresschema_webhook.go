@@ -0,0 +1,73 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	managedpostgresoperatorhoppscalecomv1alpha1 "github.com/hoppscale/managed-postgres-operator/api/v1alpha1"
+	"github.com/hoppscale/managed-postgres-operator/internal/postgresql"
+)
+
+// SetupPostgresSchemaWebhookWithManager registers the webhook for PostgresSchema in the manager.
+func SetupPostgresSchemaWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchema{}).
+		WithValidator(&PostgresSchemaCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-managed-postgres-operator-hoppscale-com-v1alpha1-postgresschema,mutating=false,failurePolicy=fail,sideEffects=None,groups=managed-postgres-operator.hoppscale.com,resources=postgresschemas,verbs=create,versions=v1alpha1,name=vpostgresschema-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// PostgresSchemaCustomValidator validates the PostgresSchema resources when they are created.
+type PostgresSchemaCustomValidator struct{}
+
+// ValidateCreate validates the schema's name and its database's name
+func (v *PostgresSchemaCustomValidator) ValidateCreate(_ context.Context, resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchema) (admission.Warnings, error) {
+	allErrs := field.ErrorList{}
+
+	if err := postgresql.ValidateSchemaName(resource.Spec.Name); err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "name"), resource.Spec.Name, err.Error()))
+	}
+
+	// Schemas can be managed in the reserved databases, e.g. "postgres".
+	// The database is resolved from databaseRef if omitted.
+	if resource.Spec.Database != "" || resource.Spec.DatabaseRef == "" {
+		if err := postgresql.ValidateIdentifier(resource.Spec.Database); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "database"), resource.Spec.Database, err.Error()))
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return nil, nil
+	}
+	return nil, apierrors.NewInvalid(groupKind("PostgresSchema"), resource.Name, allErrs)
+}
+
+// ValidateUpdate doesn't validate anything, as the schema's name and database are immutable
+func (v *PostgresSchemaCustomValidator) ValidateUpdate(_ context.Context, _, _ *managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchema) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateDelete doesn't validate anything, as the deletion is always allowed
+func (v *PostgresSchemaCustomValidator) ValidateDelete(_ context.Context, _ *managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchema) (admission.Warnings, error) {
+	return nil, nil
+}
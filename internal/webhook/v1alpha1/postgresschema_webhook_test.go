@@ -0,0 +1,70 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	managedpostgresoperatorhoppscalecomv1alpha1 "github.com/hoppscale/managed-postgres-operator/api/v1alpha1"
+)
+
+var _ = Describe("PostgresSchema Webhook", func() {
+	var validator *PostgresSchemaCustomValidator
+	var resource *managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchema
+
+	BeforeEach(func() {
+		validator = &PostgresSchemaCustomValidator{}
+		resource = &managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchema{
+			ObjectMeta: metav1.ObjectMeta{Name: "myschema", Namespace: "default"},
+			Spec: managedpostgresoperatorhoppscalecomv1alpha1.PostgresSchemaSpec{
+				Database: "postgres",
+				Name:     "myschema",
+			},
+		}
+	})
+
+	When("creating a schema", func() {
+		It("should accept a valid schema", func() {
+			_, err := validator.ValidateCreate(context.Background(), resource)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject a system schema", func() {
+			resource.Spec.Name = "pg_catalog"
+			_, err := validator.ValidateCreate(context.Background(), resource)
+			Expect(err).To(MatchError(ContainSubstring("spec.name")))
+		})
+
+		It("should reject a database name longer than 63 bytes", func() {
+			resource.Spec.Database = strings.Repeat("a", 64)
+			_, err := validator.ValidateCreate(context.Background(), resource)
+			Expect(err).To(MatchError(ContainSubstring("spec.database")))
+		})
+
+		It("should accept a schema whose database is referenced by databaseRef", func() {
+			resource.Spec.Database = ""
+			resource.Spec.DatabaseRef = "mydb"
+			_, err := validator.ValidateCreate(context.Background(), resource)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
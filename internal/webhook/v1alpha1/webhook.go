@@ -0,0 +1,78 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	managedpostgresoperatorhoppscalecomv1alpha1 "github.com/hoppscale/managed-postgres-operator/api/v1alpha1"
+	"github.com/hoppscale/managed-postgres-operator/internal/utils"
+)
+
+// ServerConnectionFunc returns the role and the database used by the operator to connect to a PostgresServer
+type ServerConnectionFunc func(ctx context.Context, server string) (role, database string, err error)
+
+// OperatorIdentity is the role and the database used by the operator to connect to its default server and to the PostgresServers.
+// The validators reject the resources which would alter them, as the operator would lose its connection.
+type OperatorIdentity struct {
+	InstanceName string
+	RoleName     string
+	DatabaseName string
+
+	ServerConnection ServerConnectionFunc
+}
+
+// connection returns the role and the database used by the operator to connect to the resource's server.
+// It returns false if the resource isn't managed by this operator's instance, or if the server's connection can't be retrieved,
+// e.g. because the PostgresServer doesn't exist yet.
+func (o OperatorIdentity) connection(ctx context.Context, annotations map[string]string, serverRef string) (role, database string, ok bool) {
+	if !utils.IsManagedByOperatorInstance(annotations, o.InstanceName) {
+		return "", "", false
+	}
+
+	if serverRef == "" {
+		return o.RoleName, o.DatabaseName, true
+	}
+
+	if o.ServerConnection == nil {
+		return "", "", false
+	}
+
+	role, database, err := o.ServerConnection(ctx, serverRef)
+	if err != nil {
+		return "", "", false
+	}
+	return role, database, true
+}
+
+// SetupWebhooksWithManager registers the validating webhooks of all the kinds in the manager
+func SetupWebhooksWithManager(mgr ctrl.Manager, operator OperatorIdentity) error {
+	if err := SetupPostgresRoleWebhookWithManager(mgr, operator); err != nil {
+		return err
+	}
+	if err := SetupPostgresDatabaseWebhookWithManager(mgr, operator); err != nil {
+		return err
+	}
+	return SetupPostgresSchemaWebhookWithManager(mgr)
+}
+
+func groupKind(kind string) schema.GroupKind {
+	return managedpostgresoperatorhoppscalecomv1alpha1.GroupVersion.WithKind(kind).GroupKind()
+}